@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/c-kuroki/alien_invasion/pkg/adapters/renderer"
+	"github.com/c-kuroki/alien_invasion/pkg/adapters/world"
+	"github.com/c-kuroki/alien_invasion/pkg/app"
+	"github.com/c-kuroki/alien_invasion/pkg/model"
+)
+
+// batchCommand runs many headless simulations with consecutive seeds and merges their stats
+func batchCommand(args []string) (err error) {
+	l := newLoader("batch", "batch [OPTIONS] [<num aliens>]")
+	l.simulationFlags()
+	l.themeFlag()
+	runs := l.fs.Int("runs", 10, "number of runs, seeded from -seed onwards")
+	overlay := l.fs.String("overlay", string(renderer.OverlayDestructionProbability), "overlay metric: visits, fights, time_to_destruction or destruction_probability")
+	output := l.fs.String("o", "", "output overlay SVG file (not rendered if not set)")
+	statsFile := l.fs.String("stats", "", "output stats JSON file (not written if not set)")
+	cfg, err := l.load(args)
+	if err != nil {
+		return err
+	}
+	switch len(l.args()) {
+	case 0:
+	case 1:
+		cfg.NumAliens, err = strconv.Atoi(l.args()[0])
+		if err != nil {
+			return l.usageError("invalid number of aliens")
+		}
+	default:
+		return l.usageError("too many arguments")
+	}
+	if *runs < 1 || cfg.MaxMoves < 1 || cfg.NumAliens < 1 {
+		return l.usageError("invalid parameters : runs, max moves and num aliens should be greater than 0")
+	}
+	if !renderer.IsValidOverlay(renderer.Overlay(*overlay)) {
+		return l.usageError("invalid overlay")
+	}
+	rnd, err := newSVGRenderer(cfg.Theme)
+	if err != nil {
+		return err
+	}
+	// initial cities, used to render the overlay
+	initial := world.NewInMemoryState(cfg.MapFilename)
+	if err := initial.Load(); err != nil {
+		return err
+	}
+
+	seed := cfg.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	stats := &model.Stats{Cities: make(map[int]*model.CityStats)}
+	for i := 0; i < *runs; i++ {
+		runCfg := *cfg
+		runCfg.Seed = seed + int64(i)
+		runCfg.TickInterval = 0
+		runCfg.NoFinalMap = true
+		invasion := app.NewAlienInvasionApp(&runCfg, world.NewInMemoryState(cfg.MapFilename), rnd, zap.NewNop().Sugar())
+		invasion.Start()
+		if err := invasion.Err(); err != nil {
+			return fmt.Errorf("run %d (seed %d): %w", i+1, runCfg.Seed, err)
+		}
+		status := invasion.Status()
+		fmt.Printf("run %d (seed %d): %d ticks, %d aliens alive, %d cities remaining\n",
+			i+1, runCfg.Seed, status.Tick, status.AliveAliens, status.RemainingCities)
+		stats.Merge(invasion.Stats())
+	}
+
+	if *statsFile != "" {
+		data, err := json.MarshalIndent(stats, "", "  ")
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(*statsFile, data, 0o644); err != nil {
+			return err
+		}
+	}
+	if *output == "" {
+		return nil
+	}
+	f, err := createOutput(*output)
+	if err != nil {
+		return err
+	}
+	defer func() { err = closeOutput(f, err) }()
+	return rnd.RenderOverlay(context.Background(), initial.GetAllCities(), stats, renderer.Overlay(*overlay), f)
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"runtime"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/c-kuroki/alien_invasion/pkg/adapters/renderer"
+	"github.com/c-kuroki/alien_invasion/pkg/adapters/world"
+	"github.com/c-kuroki/alien_invasion/pkg/app"
+)
+
+// benchReport is the result of a bench run
+type benchReport struct {
+	Map             string  `json:"map"`
+	Cities          int     `json:"cities"`
+	Aliens          int     `json:"aliens"`
+	Seed            int64   `json:"seed"`
+	LoadSeconds     float64 `json:"load_seconds"`
+	Ticks           int     `json:"ticks"`
+	Seconds         float64 `json:"seconds"`
+	TicksPerSecond  float64 `json:"ticks_per_second"`
+	MovesPerSecond  float64 `json:"moves_per_second"`
+	AllocsPerTick   float64 `json:"allocs_per_tick"`
+	BytesPerTick    float64 `json:"bytes_per_tick"`
+	HeapBytes       uint64  `json:"heap_bytes"`
+	AliveAliens     int     `json:"alive_aliens"`
+	RemainingCities int     `json:"remaining_cities"`
+}
+
+// benchCommand runs a headless simulation as fast as possible and reports its speed and allocations
+func benchCommand(args []string) error {
+	l := newLoader("bench", "bench [OPTIONS] [<num aliens>]")
+	l.simulationFlags()
+	genCfg := app.GenerateConfig{Roads: 0.3}
+	l.fs.IntVar(&genCfg.Width, "width", 0, "run on a generated map of width x height cities instead of -map (0 reads -map)")
+	l.fs.IntVar(&genCfg.Height, "height", 0, "number of rows of the generated map (defaults to -width)")
+	l.fs.Float64Var(&genCfg.Roads, "roads", genCfg.Roads, "probability (0 to 1) of each road of the generated map not needed to connect all cities")
+	asJSON := l.fs.Bool("json", false, "write the report as JSON")
+	cfg, err := l.load(args)
+	if err != nil {
+		return err
+	}
+	switch len(l.args()) {
+	case 0:
+	case 1:
+		cfg.NumAliens, err = strconv.Atoi(l.args()[0])
+		if err != nil {
+			return l.usageError("invalid number of aliens")
+		}
+	default:
+		return l.usageError("too many arguments")
+	}
+	if cfg.MaxMoves < 1 || cfg.NumAliens < 1 || genCfg.Width < 0 || genCfg.Height < 0 {
+		return l.usageError("invalid parameters : max moves and num aliens should be greater than 0, width and height can not be negative")
+	}
+	if cfg.Seed == 0 {
+		cfg.Seed = time.Now().UnixNano()
+	}
+	cfg.TickInterval = 0
+	cfg.NoFinalMap = true
+	// nothing streams the events, keeping them would be measured as part of the simulation
+	cfg.EventTicks = -1
+
+	report := &benchReport{Map: cfg.MapFilename, Aliens: cfg.NumAliens, Seed: cfg.Seed}
+	state := world.NewInMemoryState(cfg.MapFilename)
+	if genCfg.Width > 0 {
+		if genCfg.Height == 0 {
+			genCfg.Height = genCfg.Width
+		}
+		genCfg.Seed = cfg.Seed
+		var b bytes.Buffer
+		if err := app.GenerateMap(genCfg, &b); err != nil {
+			return err
+		}
+		report.Map = fmt.Sprintf("generated %dx%d", genCfg.Width, genCfg.Height)
+		state = world.NewInMemoryStateFromReader(&b)
+	}
+	invasion := app.NewAlienInvasionApp(cfg, state, renderer.NewSVGRenderer(), zap.NewNop().Sugar())
+
+	start := time.Now()
+	if err := invasion.Init(); err != nil {
+		return err
+	}
+	report.LoadSeconds = time.Since(start).Seconds()
+	report.Cities = state.GetNumCities()
+
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+	start = time.Now()
+	invasion.MainLoop()
+	report.Seconds = time.Since(start).Seconds()
+	runtime.ReadMemStats(&after)
+	if err := invasion.Err(); err != nil {
+		return err
+	}
+
+	metrics := invasion.Metrics()
+	report.Ticks = metrics.Tick
+	report.AliveAliens = metrics.AliveAliens
+	report.RemainingCities = metrics.RemainingCities
+	report.HeapBytes = after.HeapInuse
+	if report.Seconds > 0 {
+		report.TicksPerSecond = float64(report.Ticks) / report.Seconds
+		report.MovesPerSecond = float64(metrics.Moves) / report.Seconds
+	}
+	if report.Ticks > 0 {
+		report.AllocsPerTick = float64(after.Mallocs-before.Mallocs) / float64(report.Ticks)
+		report.BytesPerTick = float64(after.TotalAlloc-before.TotalAlloc) / float64(report.Ticks)
+	}
+
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+	}
+	fmt.Printf("map: %s, %d cities, %d aliens, seed %d\n", report.Map, report.Cities, report.Aliens, report.Seed)
+	fmt.Printf("load: %.3fs (map and aliens)\n", report.LoadSeconds)
+	fmt.Printf("ticks: %d in %.3fs, %.1f ticks/s, %.0f moves/s\n", report.Ticks, report.Seconds, report.TicksPerSecond, report.MovesPerSecond)
+	fmt.Printf("allocations: %.0f allocs/tick, %.0f bytes/tick, %d bytes of heap in use\n", report.AllocsPerTick, report.BytesPerTick, report.HeapBytes)
+	fmt.Printf("end: %d aliens alive, %d cities remaining\n", report.AliveAliens, report.RemainingCities)
+	return nil
+}
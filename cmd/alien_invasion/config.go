@@ -0,0 +1,253 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/c-kuroki/alien_invasion/pkg/adapters/export"
+	"github.com/c-kuroki/alien_invasion/pkg/adapters/renderer"
+	"github.com/c-kuroki/alien_invasion/pkg/app"
+	"github.com/c-kuroki/alien_invasion/pkg/model"
+)
+
+// prefix of the environment variables setting flags (e.g. ALIEN_MAX_MOVES for -max-moves)
+const envPrefix = "ALIEN_"
+
+// errPrintConfig is returned by load after printing the effective configuration
+var errPrintConfig = errors.New("configuration printed")
+
+func defaultConfig() *model.Config {
+	sessions := app.DefaultSessionsConfig()
+	return &model.Config{
+		MapFilename:  "./examples/big.map",
+		TickInterval: 1000,
+		MaxMoves:     10000,
+		Theme:        renderer.ThemeLight,
+		// with a checkpoint file
+		CheckpointEvery: 100,
+		Output: model.OutputConfig{
+			Dir:      ".",
+			Template: app.DefaultOutputTemplate,
+			Format:   export.FormatText,
+			Report:   export.FormatText,
+		},
+		Service: model.ServiceConfig{
+			HTTPAddress:     ":8080",
+			GRPCAddress:     "-1",
+			MapsDir:         "./maps",
+			MapPresets:      "./examples",
+			ReadTimeout:     model.Duration(30 * time.Second),
+			IdleTimeout:     model.Duration(2 * time.Minute),
+			MaxSessions:     sessions.MaxSessions,
+			SessionLifetime: model.Duration(sessions.MaxLifetime),
+		},
+	}
+}
+
+// loader builds the configuration of a subcommand merging, by increasing priority, defaults,
+// a config file (-config or ALIEN_CONFIG), environment variables and flags
+type loader struct {
+	fs          *flag.FlagSet
+	cfg         *model.Config
+	configFile  string
+	printConfig bool
+	// short aliases of flags, not set from the environment
+	aliases map[string]bool
+}
+
+func newLoader(command, usage string) *loader {
+	l := &loader{
+		fs:      flag.NewFlagSet(command, flag.ContinueOnError),
+		cfg:     defaultConfig(),
+		aliases: make(map[string]bool),
+	}
+	l.fs.Usage = func() {
+		fmt.Fprintf(l.fs.Output(), "Usage: alien_invasion %s\n\nOPTIONS\n-------\n", usage)
+		l.fs.PrintDefaults()
+	}
+	l.fs.StringVar(&l.configFile, "config", "", "config file (.json, .yaml), also set by "+envPrefix+"CONFIG")
+	l.fs.BoolVar(&l.printConfig, "print-config", false, "print the effective configuration and exit")
+	return l
+}
+
+// alias registers a short name of a flag
+func (l *loader) alias(name, flagName string) {
+	f := l.fs.Lookup(flagName)
+	l.fs.Var(f.Value, name, "alias of -"+flagName)
+	l.aliases[name] = true
+}
+
+// simulationFlags binds the flags of a single simulation
+func (l *loader) simulationFlags() {
+	l.fs.StringVar(&l.cfg.MapFilename, "map", l.cfg.MapFilename, "map filename")
+	l.alias("f", "map")
+	l.fs.IntVar(&l.cfg.TickInterval, "tick", l.cfg.TickInterval, "tick interval in ms (0 for no pause between moves)")
+	l.alias("t", "tick")
+	l.fs.IntVar(&l.cfg.MaxMoves, "max-moves", l.cfg.MaxMoves, "max number of moves")
+	l.alias("m", "max-moves")
+	l.fs.IntVar(&l.cfg.NumAliens, "aliens", l.cfg.NumAliens, "number of aliens (also set as argument)")
+	l.fs.Int64Var(&l.cfg.Seed, "seed", l.cfg.Seed, "random seed (0 for current time)")
+	l.fs.BoolVar(&l.cfg.NoFinalMap, "no-final-map", l.cfg.NoFinalMap, "do not write the final map file")
+	l.fs.BoolVar(&l.cfg.Debug, "debug", l.cfg.Debug, "check the world invariants after every tick, aborting with a dump of the world on violations")
+}
+
+// outputFlags binds the flags of the final map output
+func (l *loader) outputFlags() {
+	out := &l.cfg.Output
+	l.fs.StringVar(&out.Dir, "output-dir", out.Dir, "directory of output files")
+	l.fs.StringVar(&out.Template, "output-template", out.Template, "final map filename template, with {{.Time}}, {{.Seed}}, {{.Tick}} and {{.Ext}} fields")
+	l.fs.BoolVar(&out.Stdout, "stdout", out.Stdout, "write the final map to stdout instead of a file")
+	l.fs.StringVar(&out.Format, "format", out.Format, "final map format: text, json or dot")
+	l.fs.StringVar(&out.Report, "report", out.Report, "summary report format on stdout (stderr with -stdout): text, json or none")
+	l.fs.StringVar(&out.SummaryFile, "summary", out.SummaryFile, "summary report file, JSON if its extension is .json and text otherwise (filename template)")
+}
+
+func (l *loader) themeFlag() {
+	l.fs.StringVar(&l.cfg.Theme, "theme", l.cfg.Theme, "map theme: light, dark, high-contrast or a theme file (.json, .yaml)")
+}
+
+// serviceFlags binds the flags of the http and grpc services
+func (l *loader) serviceFlags() {
+	svc := &l.cfg.Service
+	l.fs.StringVar(&svc.HTTPAddress, "http", svc.HTTPAddress, "http service address (-1 to disable http service)")
+	l.alias("a", "http")
+	l.fs.StringVar(&svc.GRPCAddress, "grpc", svc.GRPCAddress, "grpc service address (-1 to disable grpc service)")
+	l.fs.StringVar(&svc.MapsDir, "maps", svc.MapsDir, "directory of maps managed by the http service (and used by name in server mode)")
+	l.fs.StringVar(&svc.MapPresets, "map-presets", svc.MapPresets, "directory of read-only maps listed with the managed ones (empty for none)")
+	l.fs.Var(&svc.ReadTimeout, "read-timeout", "http server read timeout (0 for none)")
+	l.fs.Var(&svc.WriteTimeout, "write-timeout", "http server write timeout, also closes event streams (0 for none)")
+	l.fs.Var(&svc.IdleTimeout, "idle-timeout", "http server keep-alive idle timeout (0 for none)")
+	l.fs.StringVar(&svc.TLSCertFile, "tls-cert", svc.TLSCertFile, "TLS certificate file, enables https with -tls-key")
+	l.fs.StringVar(&svc.TLSKeyFile, "tls-key", svc.TLSKeyFile, "TLS private key file, enables https with -tls-cert")
+	l.fs.StringVar(&svc.AuthFile, "auth", svc.AuthFile, "API keys and token secret file (.json, .yaml), enables authentication")
+}
+
+// sessionFlags binds the flags of server mode
+func (l *loader) sessionFlags() {
+	svc := &l.cfg.Service
+	l.fs.IntVar(&svc.MaxSessions, "max-sessions", svc.MaxSessions, "max number of concurrent simulations, the oldest finished one is deleted to make room for a new one")
+	l.fs.Var(&svc.SessionLifetime, "session-lifetime", "simulations lifetime (0 to keep them until deleted)")
+}
+
+// load merges the configuration sources and parses the arguments, it returns errPrintConfig
+// after printing the configuration if requested
+func (l *loader) load(args []string) (*model.Config, error) {
+	configFile := os.Getenv(envPrefix + "CONFIG")
+	if f, ok := scanFlag(args, "config"); ok {
+		configFile = f
+	}
+	if configFile != "" {
+		if err := readConfig(configFile, l.cfg); err != nil {
+			return nil, err
+		}
+	}
+	var err error
+	l.fs.VisitAll(func(f *flag.Flag) {
+		if err != nil || l.aliases[f.Name] || f.Name == "config" || f.Name == "print-config" {
+			return
+		}
+		name := envPrefix + strings.ToUpper(strings.ReplaceAll(f.Name, "-", "_"))
+		if value, ok := os.LookupEnv(name); ok {
+			if setErr := l.fs.Set(f.Name, value); setErr != nil {
+				err = fmt.Errorf("invalid %s environment variable: %w", name, setErr)
+			}
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := l.fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil, err
+		}
+		// the flag set already printed the error and the usage
+		return nil, errUsage
+	}
+	if l.printConfig {
+		if err := printConfig(os.Stdout, l.cfg); err != nil {
+			return nil, err
+		}
+		return nil, errPrintConfig
+	}
+	return l.cfg, nil
+}
+
+// usageError prints an error message and the usage
+func (l *loader) usageError(message string) error {
+	fmt.Fprintln(l.fs.Output(), message)
+	l.fs.Usage()
+	return errUsage
+}
+
+// args returns the positional arguments after load
+func (l *loader) args() []string {
+	return l.fs.Args()
+}
+
+// scanFlag looks for a flag value before parsing, to load the config file before other sources
+func scanFlag(args []string, name string) (string, bool) {
+	for i, arg := range args {
+		if arg == "--" {
+			return "", false
+		}
+		if !strings.HasPrefix(arg, "-") {
+			continue
+		}
+		arg = strings.TrimPrefix(strings.TrimPrefix(arg, "-"), "-")
+		if arg == name && i+1 < len(args) {
+			return args[i+1], true
+		}
+		if strings.HasPrefix(arg, name+"=") {
+			return strings.TrimPrefix(arg, name+"="), true
+		}
+	}
+	return "", false
+}
+
+// readConfig overrides cfg with the values of a config file, unknown keys are rejected
+func readConfig(filename string, cfg *model.Config) error {
+	file, err := os.Open(filename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".json":
+		dec := json.NewDecoder(file)
+		dec.DisallowUnknownFields()
+		err = dec.Decode(cfg)
+	case ".yaml", ".yml":
+		dec := yaml.NewDecoder(file)
+		dec.KnownFields(true)
+		err = dec.Decode(cfg)
+		if errors.Is(err, io.EOF) {
+			// empty file
+			err = nil
+		}
+	default:
+		return fmt.Errorf("invalid config file extension [%s] (should be .json, .yaml or .yml)", filepath.Ext(filename))
+	}
+	if err != nil {
+		return fmt.Errorf("parsing config %s : %w", filename, err)
+	}
+	return nil
+}
+
+// printConfig writes the configuration as YAML, it can be used as config file
+func printConfig(w io.Writer, cfg *model.Config) error {
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(cfg); err != nil {
+		return err
+	}
+	return enc.Close()
+}
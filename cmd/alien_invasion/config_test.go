@@ -0,0 +1,83 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/c-kuroki/alien_invasion/pkg/model"
+)
+
+type ConfigTestSuite struct {
+	suite.Suite
+	configFile string
+}
+
+func (suite *ConfigTestSuite) SetupTest() {
+	suite.configFile = filepath.Join(suite.T().TempDir(), "config.yaml")
+	err := os.WriteFile(suite.configFile, []byte(`
+map: file.map
+tick_interval: 10
+max_moves: 100
+service:
+  read_timeout: 5s
+`), 0o600)
+	suite.Require().NoError(err)
+}
+
+func (suite *ConfigTestSuite) load(args ...string) (*model.Config, error) {
+	l := newLoader("run", "run")
+	l.simulationFlags()
+	l.serviceFlags()
+	return l.load(args)
+}
+
+func (suite *ConfigTestSuite) TestDefaults() {
+	cfg, err := suite.load()
+	suite.Require().NoError(err)
+	suite.Assert().Equal(defaultConfig(), cfg)
+}
+
+func (suite *ConfigTestSuite) TestPriority() {
+	suite.T().Setenv("ALIEN_MAX_MOVES", "200")
+	suite.T().Setenv("ALIEN_TICK", "20")
+	suite.T().Setenv("ALIEN_IDLE_TIMEOUT", "1m")
+	cfg, err := suite.load("-config", suite.configFile, "-t", "30", "5")
+	suite.Require().NoError(err)
+	// file over defaults
+	suite.Assert().Equal("file.map", cfg.MapFilename)
+	suite.Assert().Equal(model.Duration(5*time.Second), cfg.Service.ReadTimeout)
+	// environment over file
+	suite.Assert().Equal(200, cfg.MaxMoves)
+	suite.Assert().Equal(model.Duration(time.Minute), cfg.Service.IdleTimeout)
+	// flags over environment
+	suite.Assert().Equal(30, cfg.TickInterval)
+	// untouched defaults
+	suite.Assert().Equal(":8080", cfg.Service.HTTPAddress)
+}
+
+func (suite *ConfigTestSuite) TestConfigFromEnvironment() {
+	suite.T().Setenv("ALIEN_CONFIG", suite.configFile)
+	cfg, err := suite.load()
+	suite.Require().NoError(err)
+	suite.Assert().Equal(100, cfg.MaxMoves)
+}
+
+func (suite *ConfigTestSuite) TestInvalid() {
+	err := os.WriteFile(suite.configFile, []byte("unknown: 1\n"), 0o600)
+	suite.Require().NoError(err)
+	_, err = suite.load("-config", suite.configFile)
+	suite.Assert().Error(err)
+
+	suite.T().Setenv("ALIEN_READ_TIMEOUT", "soon")
+	_, err = suite.load()
+	suite.Assert().ErrorContains(err, "ALIEN_READ_TIMEOUT")
+}
+
+// TestConfigTestSuite is the entry point of this test suite
+func TestConfigTestSuite(t *testing.T) {
+	suite.Run(t, new(ConfigTestSuite))
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"bufio"
+
+	"github.com/c-kuroki/alien_invasion/pkg/app"
+)
+
+// generateCommand writes a random map
+func generateCommand(args []string) (err error) {
+	l := newLoader("generate", "generate [OPTIONS]")
+	genCfg := app.GenerateConfig{Width: 10, Height: 10, Roads: 0.3}
+	l.fs.IntVar(&genCfg.Width, "width", genCfg.Width, "number of columns of the grid of cities")
+	l.fs.IntVar(&genCfg.Height, "height", genCfg.Height, "number of rows of the grid of cities")
+	l.fs.Float64Var(&genCfg.Roads, "roads", genCfg.Roads, "probability (0 to 1) of each road not needed to connect all cities")
+	l.fs.Int64Var(&l.cfg.Seed, "seed", l.cfg.Seed, "random seed (0 for current time)")
+	output := l.fs.String("o", "", "output map file (stdout if not set)")
+	cfg, err := l.load(args)
+	if err != nil {
+		return err
+	}
+	if len(l.args()) > 0 {
+		return l.usageError("too many arguments")
+	}
+	genCfg.Seed = cfg.Seed
+	f, err := createOutput(*output)
+	if err != nil {
+		return err
+	}
+	defer func() { err = closeOutput(f, err) }()
+	w := bufio.NewWriter(f)
+	if err := app.GenerateMap(genCfg, w); err != nil {
+		return err
+	}
+	return w.Flush()
+}
@@ -1,66 +1,171 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"os"
-	"strconv"
-
-	"go.uber.org/zap"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
 
 	"github.com/c-kuroki/alien_invasion/pkg/adapters/renderer"
-	"github.com/c-kuroki/alien_invasion/pkg/adapters/world"
-	"github.com/c-kuroki/alien_invasion/pkg/app"
-	"github.com/c-kuroki/alien_invasion/pkg/model"
-	"github.com/c-kuroki/alien_invasion/pkg/ports/http"
+	"github.com/c-kuroki/alien_invasion/pkg/auth"
+	"github.com/c-kuroki/alien_invasion/pkg/logger"
 )
 
+// time to wait for active requests when shutting down
+const shutdownTimeout = 10 * time.Second
+
+// errUsage is returned after printing the usage of a subcommand
+var errUsage = errors.New("invalid usage")
+
+type command struct {
+	name    string
+	summary string
+	run     func(args []string) error
+}
+
+var commands = []command{
+	{"run", "run a simulation, serving it over http and grpc (default)", runCommand},
+	{"serve", "host many simulations created through the http or grpc services", serveCommand},
+	{"validate", "validate map files", validateCommand},
+	{"generate", "generate a random map", generateCommand},
+	{"render", "render a map as SVG", renderCommand},
+	{"replay", "render the state of a recorded run at a tick", replayCommand},
+	{"batch", "run many headless simulations and merge their stats", batchCommand},
+	{"bench", "run a headless simulation reporting its speed and allocations", benchCommand},
+}
+
 func usage() {
-	fmt.Println(`Usage: alien_invasion [OPTIONS] <num aliens>
+	fmt.Println(`Usage: alien_invasion [<command>] [OPTIONS] [ARGS]
 
-OPTIONS
--------`)
-	flag.PrintDefaults()
-	os.Exit(1)
+Commands
+--------`)
+	for _, cmd := range commands {
+		fmt.Printf("  %-10s %s\n", cmd.name, cmd.summary)
+	}
+	fmt.Println(`
+Run "alien_invasion <command> -h" to list the options of a command.
+Options can also be set on a config file (-config) and by ALIEN_<OPTION> environment variables.`)
 }
 
 func main() {
-	filename := flag.String("f", "./examples/big.map", "map filename")
-	tickInterval := flag.Int("t", 1000, "tick interval")
-	maxMoves := flag.Int("m", 10000, "max number of moves")
-	httpServiceAddress := flag.String("a", ":8080", "http service address (-1 to disable http service)")
-	flag.Parse()
-	args := flag.Args()
-	if len(args) != 1 {
-		usage()
+	args := os.Args[1:]
+	name := "run"
+	if len(args) > 0 {
+		switch args[0] {
+		case "help", "-h", "-help", "--help":
+			usage()
+			return
+		case "-s":
+			// compatibility with the server mode flag
+			name, args = "serve", args[1:]
+		default:
+			for _, cmd := range commands {
+				if cmd.name == args[0] {
+					name, args = args[0], args[1:]
+				}
+			}
+		}
 	}
+	for _, cmd := range commands {
+		if cmd.name != name {
+			continue
+		}
+		err := cmd.run(args)
+		switch {
+		case err == nil, errors.Is(err, flag.ErrHelp), errors.Is(err, errPrintConfig):
+			return
+		case errors.Is(err, errUsage):
+			os.Exit(1)
+		default:
+			fmt.Fprintln(os.Stderr, err.Error())
+			os.Exit(1)
+		}
+	}
+}
 
-	numAliens, err := strconv.Atoi(args[0])
-	if err != nil {
-		fmt.Println("invalid number of aliens")
-		usage()
+// signals returns a channel receiving interrupt and termination signals
+func signals() <-chan os.Signal {
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, os.Interrupt, syscall.SIGTERM)
+	return ch
+}
+
+// setAuth enables authentication on a service if configured
+func setAuth(srv interface{ SetAuth(*auth.Authenticator) }, authenticator *auth.Authenticator) {
+	if authenticator != nil {
+		srv.SetAuth(authenticator)
 	}
-	if *tickInterval < 1 || *maxMoves < 1 || numAliens < 1 {
-		fmt.Println("invalid parameters : tick interval, max moves and num aliens should be greater than 0")
-		usage()
+}
+
+// loadAuth returns the authenticator of an auth file, nil if no file is set
+func loadAuth(filename string) (*auth.Authenticator, error) {
+	if filename == "" {
+		return nil, nil
 	}
-	cfg := &model.Config{
-		MapFilename:  *filename,
-		TickInterval: *tickInterval,
-		MaxMoves:     *maxMoves,
-		NumAliens:    numAliens,
+	authCfg, err := auth.LoadConfig(filename)
+	if err != nil {
+		return nil, err
 	}
+	return auth.NewAuthenticator(authCfg)
+}
+
+// service is a port serving requests until shut down
+type service interface {
+	Shutdown(ctx context.Context) error
+}
 
-	logger, _ := zap.NewProduction()
-	defer func() { _ = logger.Sync() }()
+// shutdown gracefully stops a service, waiting for active requests up to a timeout
+func shutdown(srv service, log logger.Logger) {
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(ctx); err != nil {
+		log.Warnw("shutting down service", "error", err.Error())
+	}
+}
 
-	state := world.NewInMemoryState(cfg.MapFilename)
+// newSVGRenderer returns a renderer using a preset theme or a theme file by default
+func newSVGRenderer(theme string) (*renderer.SVGRenderer, error) {
 	rnd := renderer.NewSVGRenderer()
+	if err := setTheme(rnd, theme); err != nil {
+		return nil, err
+	}
+	return rnd, nil
+}
 
-	invasion := app.NewAlienInvasionApp(cfg, state, rnd, logger.Sugar())
-	if *httpServiceAddress != "-1" {
-		srv := http.NewHTTPService(invasion, *httpServiceAddress)
-		go srv.Start()
+// setTheme sets the renderer default theme from a preset name or a theme file
+func setTheme(rnd *renderer.SVGRenderer, theme string) error {
+	switch filepath.Ext(theme) {
+	case ".json", ".yaml", ".yml":
+		t, err := renderer.LoadTheme(theme)
+		if err != nil {
+			return err
+		}
+		rnd.AddTheme(t)
+		return rnd.SetTheme(t.Name)
+	}
+	return rnd.SetTheme(theme)
+}
+
+// createOutput returns the file to write a command output, stdout if filename is empty or -
+func createOutput(filename string) (*os.File, error) {
+	if filename == "" || filename == "-" {
+		return os.Stdout, nil
+	}
+	return os.Create(filename)
+}
+
+// closeOutput closes an output file, keeping the first error
+func closeOutput(f *os.File, err error) error {
+	if f == os.Stdout {
+		return err
+	}
+	if closeErr := f.Close(); err == nil {
+		return closeErr
 	}
-	invasion.Start()
+	return err
 }
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"context"
+	"os"
+
+	"github.com/c-kuroki/alien_invasion/pkg/app"
+)
+
+// renderCommand renders a map as SVG
+func renderCommand(args []string) (err error) {
+	l := newLoader("render", "render [OPTIONS] [<map file>]")
+	l.fs.StringVar(&l.cfg.MapFilename, "map", l.cfg.MapFilename, "map filename, rendered if no file is passed as argument")
+	l.themeFlag()
+	output := l.fs.String("o", "", "output SVG file (stdout if not set)")
+	cfg, err := l.load(args)
+	if err != nil {
+		return err
+	}
+	switch len(l.args()) {
+	case 0:
+	case 1:
+		cfg.MapFilename = l.args()[0]
+	default:
+		return l.usageError("too many arguments")
+	}
+	rnd, err := newSVGRenderer(cfg.Theme)
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(cfg.MapFilename)
+	if err != nil {
+		return err
+	}
+	f, err := createOutput(*output)
+	if err != nil {
+		return err
+	}
+	defer func() { err = closeOutput(f, err) }()
+	// previews do not use the maps store
+	return app.NewMapsApp(nil, rnd).Preview(context.Background(), data, f)
+}
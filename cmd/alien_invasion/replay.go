@@ -0,0 +1,60 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"go.uber.org/zap"
+
+	"github.com/c-kuroki/alien_invasion/pkg/adapters/world"
+	"github.com/c-kuroki/alien_invasion/pkg/app"
+)
+
+// replayCommand renders the state of a run recorded by run -events at a tick
+func replayCommand(args []string) (err error) {
+	l := newLoader("replay", "replay [OPTIONS] <events file>")
+	l.fs.StringVar(&l.cfg.MapFilename, "map", l.cfg.MapFilename, "map filename of the recorded run")
+	l.alias("f", "map")
+	l.themeFlag()
+	tick := l.fs.Int("tick", -1, "tick to render (-1 for the end of the run)")
+	output := l.fs.String("o", "", "output SVG file (stdout if not set)")
+	cfg, err := l.load(args)
+	if err != nil {
+		return err
+	}
+	switch len(l.args()) {
+	case 0:
+		if cfg.EventsFile == "" {
+			return l.usageError("missing events file")
+		}
+	case 1:
+		cfg.EventsFile = l.args()[0]
+	default:
+		return l.usageError("too many arguments")
+	}
+	rnd, err := newSVGRenderer(cfg.Theme)
+	if err != nil {
+		return err
+	}
+	events, err := readEvents(cfg.EventsFile)
+	if err != nil {
+		return err
+	}
+
+	state := world.NewInMemoryState(cfg.MapFilename)
+	invasion := app.NewAlienInvasionApp(cfg, state, rnd, zap.NewNop().Sugar())
+	if err := invasion.Replay(events, *tick); err != nil {
+		return err
+	}
+	status := invasion.Status()
+	fmt.Fprintf(os.Stderr, "tick %d (%s): %d aliens alive, %d cities remaining, %d destroyed\n",
+		status.Tick, status.Status, status.AliveAliens, status.RemainingCities, status.DestroyedCities)
+
+	f, err := createOutput(*output)
+	if err != nil {
+		return err
+	}
+	defer func() { err = closeOutput(f, err) }()
+	return invasion.RenderMap(context.Background(), f)
+}
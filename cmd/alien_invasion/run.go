@@ -0,0 +1,260 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/c-kuroki/alien_invasion/pkg/adapters/renderer"
+	"github.com/c-kuroki/alien_invasion/pkg/adapters/world"
+	"github.com/c-kuroki/alien_invasion/pkg/app"
+	"github.com/c-kuroki/alien_invasion/pkg/metrics"
+	"github.com/c-kuroki/alien_invasion/pkg/model"
+	"github.com/c-kuroki/alien_invasion/pkg/ports/grpc"
+	"github.com/c-kuroki/alien_invasion/pkg/ports/http"
+)
+
+// runCommand runs a single simulation, serving it over http and grpc
+func runCommand(args []string) (err error) {
+	l := newLoader("run", "run [OPTIONS] [<num aliens>]")
+	l.simulationFlags()
+	l.outputFlags()
+	l.themeFlag()
+	l.serviceFlags()
+	l.fs.IntVar(&l.cfg.History, "history", l.cfg.History, "keep the world history with a snapshot every n ticks, to query past ticks (0 disables it)")
+	l.fs.IntVar(&l.cfg.HistoryTicks, "history-ticks", l.cfg.HistoryTicks, "keep the history of the last n ticks only, capping its memory (0 keeps every tick)")
+	l.fs.StringVar(&l.cfg.DBFile, "db", l.cfg.DBFile, "keep the world on a database file, resuming an interrupted run")
+	l.fs.StringVar(&l.cfg.CheckpointFile, "checkpoint", l.cfg.CheckpointFile, "write the simulation state to a checkpoint file, periodically and when stopped")
+	l.fs.IntVar(&l.cfg.CheckpointEvery, "checkpoint-every", l.cfg.CheckpointEvery, "ticks between checkpoints (0 to write it only when stopped)")
+	var resume string
+	l.fs.StringVar(&resume, "resume", "", "continue the simulation saved on a checkpoint file")
+	l.fs.StringVar(&l.cfg.EventsFile, "events", l.cfg.EventsFile, "record the events as JSON lines on a file (see replay)")
+	l.fs.IntVar(&l.cfg.EventTicks, "event-ticks", l.cfg.EventTicks, fmt.Sprintf("ticks of events kept to resume event streams (0 for %d, -1 for none)", app.DefaultEventTicks))
+	cfg, err := l.load(args)
+	if err != nil {
+		return err
+	}
+	switch len(l.args()) {
+	case 0:
+	case 1:
+		cfg.NumAliens, err = strconv.Atoi(l.args()[0])
+		if err != nil {
+			return l.usageError("invalid number of aliens")
+		}
+	default:
+		return l.usageError("too many arguments")
+	}
+	if cfg.History > 0 && (cfg.DBFile != "" || resume != "") {
+		return l.usageError("-history can not be used with -db or -resume")
+	}
+	var checkpoint *model.Checkpoint
+	if resume != "" {
+		if cfg.DBFile != "" {
+			return l.usageError("-resume and -db can not be used together")
+		}
+		checkpoint, err = app.ReadCheckpoint(resume)
+		if err != nil {
+			return err
+		}
+		// the simulation continues as configured when it was checkpointed
+		cfg.MapFilename = checkpoint.Config.MapFilename
+		cfg.MaxMoves = checkpoint.Config.MaxMoves
+		cfg.NumAliens = checkpoint.Config.NumAliens
+		cfg.Seed = checkpoint.Seed
+		if cfg.CheckpointFile == "" {
+			cfg.CheckpointFile = resume
+		}
+	}
+	if cfg.TickInterval < 0 || cfg.MaxMoves < 1 || cfg.NumAliens < 1 || cfg.CheckpointEvery < 0 || cfg.History < 0 || cfg.HistoryTicks < 0 || cfg.EventTicks < -1 {
+		return l.usageError("invalid parameters : max moves and num aliens should be greater than 0, tick interval can not be negative")
+	}
+	if err := app.ValidateOutput(cfg.Output); err != nil {
+		return l.usageError(err.Error())
+	}
+
+	logger, _ := zap.NewProduction()
+	defer func() { _ = logger.Sync() }()
+
+	rnd, reg, err := newServiceRenderer(cfg.Theme)
+	if err != nil {
+		return err
+	}
+	authenticator, err := loadAuth(cfg.Service.AuthFile)
+	if err != nil {
+		return err
+	}
+
+	var invasion *app.AlienInvasionApp
+	switch {
+	case checkpoint != nil:
+		invasion = app.NewAlienInvasionAppFromCheckpoint(cfg, checkpoint, rnd, logger.Sugar())
+	case cfg.DBFile != "":
+		db, err := world.NewBoltState(cfg.DBFile, cfg.MapFilename, logger.Sugar())
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+		invasion = app.NewAlienInvasionApp(cfg, db, rnd, logger.Sugar())
+	case cfg.History > 0:
+		state := world.NewEventSourcedState(world.NewInMemoryState(cfg.MapFilename), cfg.History)
+		state.SetMaxTicks(cfg.HistoryTicks)
+		invasion = app.NewAlienInvasionApp(cfg, state, rnd, logger.Sugar())
+	default:
+		invasion = app.NewAlienInvasionApp(cfg, world.NewInMemoryState(cfg.MapFilename), rnd, logger.Sugar())
+	}
+	if cfg.EventsFile != "" {
+		resumeTick := -1
+		if checkpoint != nil {
+			resumeTick = checkpoint.Tick
+		}
+		events, createErr := createEvents(cfg.EventsFile, resumeTick)
+		if createErr != nil {
+			return createErr
+		}
+		invasion.SetEventsWriter(events)
+		// the file is flushed every tick, it holds the whole run once finished or aborted
+		defer func() { err = events.Close(err) }()
+	}
+	// services run until the simulation is finished, the first one failing stops it
+	errs := make(chan error, 2)
+	if cfg.Service.HTTPAddress != "-1" {
+		srv := http.NewHTTPService(invasion, cfg.Service.HTTPAddress)
+		srv.SetMaps(app.NewMapsApp(newMapStore(cfg.Service), rnd))
+		srv.SetMetrics(reg)
+		srv.SetServerConfig(serverConfig(cfg.Service))
+		setAuth(srv, authenticator)
+		go func() { errs <- srv.Start() }()
+		defer shutdown(srv, logger.Sugar())
+	}
+	if cfg.Service.GRPCAddress != "-1" {
+		srv := grpc.NewGRPCService(invasion, cfg.Service.GRPCAddress)
+		setAuth(srv, authenticator)
+		go func() { errs <- srv.Start() }()
+		defer shutdown(srv, logger.Sugar())
+	}
+	// failed gets the error of a failed service, returned once the simulation is stopped so the
+	// deferred events file close and services shutdown run
+	failed := make(chan error, 1)
+	go func() {
+		select {
+		case err := <-errs:
+			if err == nil {
+				// services only return nil once shut down
+				return
+			}
+			logger.Sugar().Errorw("stopping simulation", "error", err.Error())
+			failed <- err
+		case sig := <-signals():
+			logger.Sugar().Infow("stopping simulation", "signal", sig.String())
+		}
+		invasion.Stop()
+	}()
+	if checkpoint != nil {
+		logger.Sugar().Infow("resuming invasion", "checkpoint", resume, "tick", strconv.Itoa(checkpoint.Tick))
+		invasion.MainLoop()
+	} else {
+		invasion.Start()
+	}
+	select {
+	case err := <-failed:
+		return err
+	default:
+		return invasion.Err()
+	}
+}
+
+// newServiceRenderer returns the renderer of services, measuring render durations on a new metrics registry
+func newServiceRenderer(theme string) (renderer.Adapter, *metrics.Registry, error) {
+	svgRenderer, err := newSVGRenderer(theme)
+	if err != nil {
+		return nil, nil, err
+	}
+	reg := metrics.NewRegistry()
+	renderDuration := reg.NewHistogram("alien_invasion_render_duration_seconds", "Duration of map renders by method.", nil, "method")
+	rnd := renderer.NewTimedRenderer(svgRenderer, func(method string, d time.Duration) {
+		renderDuration.Observe(d.Seconds(), method)
+	})
+	return rnd, reg, nil
+}
+
+func serverConfig(cfg model.ServiceConfig) http.ServerConfig {
+	return http.ServerConfig{
+		ReadTimeout:  time.Duration(cfg.ReadTimeout),
+		WriteTimeout: time.Duration(cfg.WriteTimeout),
+		IdleTimeout:  time.Duration(cfg.IdleTimeout),
+		TLSCertFile:  cfg.TLSCertFile,
+		TLSKeyFile:   cfg.TLSKeyFile,
+	}
+}
+
+// eventsFile records the events of a run as JSON lines
+type eventsFile struct {
+	*bufio.Writer
+	f *os.File
+}
+
+// createEvents creates an events file, a run resumed from a tick keeps the events recorded
+// up to it (-1 for new runs) but the end event of the interrupted run
+func createEvents(filename string, resumeTick int) (*eventsFile, error) {
+	var kept []model.Event
+	if resumeTick >= 0 {
+		recorded, err := readEvents(filename)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+		for _, event := range recorded {
+			if event.Tick > resumeTick {
+				break
+			}
+			if event.Type != model.EventEnd {
+				kept = append(kept, event)
+			}
+		}
+	}
+	f, err := os.Create(filename)
+	if err != nil {
+		return nil, err
+	}
+	events := &eventsFile{Writer: bufio.NewWriter(f), f: f}
+	enc := json.NewEncoder(events)
+	for _, event := range kept {
+		if err := enc.Encode(event); err != nil {
+			_ = f.Close()
+			return nil, err
+		}
+	}
+	return events, nil
+}
+
+// Close flushes and closes the file, returning err if not nil
+func (e *eventsFile) Close(err error) error {
+	if flushErr := e.Flush(); err == nil {
+		err = flushErr
+	}
+	return closeOutput(e.f, err)
+}
+
+// readEvents reads events written as JSON lines
+func readEvents(filename string) ([]model.Event, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var events []model.Event
+	dec := json.NewDecoder(bufio.NewReader(f))
+	for dec.More() {
+		var event model.Event
+		if err := dec.Decode(&event); err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
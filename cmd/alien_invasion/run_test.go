@@ -0,0 +1,42 @@
+package main
+
+import (
+	"net"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/c-kuroki/alien_invasion/pkg/model"
+)
+
+type RunTestSuite struct {
+	suite.Suite
+}
+
+func (suite *RunTestSuite) TestServiceFailure() {
+	// the http address is taken, so the service fails while the simulation runs
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	suite.Require().NoError(err)
+	defer lis.Close()
+	eventsFile := filepath.Join(suite.T().TempDir(), "events.jsonl")
+
+	err = runCommand([]string{
+		"-map", "../../examples/world.map", "-tick", "60000", "-max-moves", "100", "-no-final-map",
+		"-http", lis.Addr().String(), "-grpc", "-1", "-events", eventsFile, "2",
+	})
+	suite.Require().Error(err)
+	suite.Assert().Contains(err.Error(), "address already in use")
+
+	// the command returned, so the events file was flushed and closed
+	events, err := readEvents(eventsFile)
+	suite.Require().NoError(err)
+	suite.Require().NotEmpty(events)
+	suite.Assert().Equal(model.EventSpawn, events[0].Type)
+	suite.Assert().Equal(model.EventEnd, events[len(events)-1].Type)
+}
+
+// TestRunTestSuite is the entry point of this test suite
+func TestRunTestSuite(t *testing.T) {
+	suite.Run(t, new(RunTestSuite))
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/c-kuroki/alien_invasion/pkg/adapters/maps"
+	"github.com/c-kuroki/alien_invasion/pkg/app"
+	"github.com/c-kuroki/alien_invasion/pkg/model"
+	"github.com/c-kuroki/alien_invasion/pkg/ports/grpc"
+	"github.com/c-kuroki/alien_invasion/pkg/ports/http"
+)
+
+// serveCommand hosts many simulations created through the http or grpc services (server mode)
+func serveCommand(args []string) error {
+	l := newLoader("serve", "serve [OPTIONS]")
+	l.themeFlag()
+	l.serviceFlags()
+	l.sessionFlags()
+	cfg, err := l.load(args)
+	if err != nil {
+		return err
+	}
+	if len(l.args()) > 0 {
+		return l.usageError("too many arguments")
+	}
+	if cfg.Service.HTTPAddress == "-1" && cfg.Service.GRPCAddress == "-1" {
+		return l.usageError("server mode requires the http or the grpc service")
+	}
+
+	logger, _ := zap.NewProduction()
+	defer func() { _ = logger.Sync() }()
+
+	rnd, reg, err := newServiceRenderer(cfg.Theme)
+	if err != nil {
+		return err
+	}
+	authenticator, err := loadAuth(cfg.Service.AuthFile)
+	if err != nil {
+		return err
+	}
+
+	sessionsCfg := app.DefaultSessionsConfig()
+	sessionsCfg.MaxSessions = cfg.Service.MaxSessions
+	sessionsCfg.MaxLifetime = time.Duration(cfg.Service.SessionLifetime)
+	mapStore := newMapStore(cfg.Service)
+	sessions := app.NewSessionManager(sessionsCfg, mapStore, rnd, logger.Sugar())
+	defer sessions.Close()
+	var services []service
+	errs := make(chan error, 2)
+	if cfg.Service.HTTPAddress != "-1" {
+		srv := http.NewHTTPService(nil, cfg.Service.HTTPAddress)
+		srv.SetSessions(sessions)
+		srv.SetMaps(app.NewMapsApp(mapStore, rnd))
+		srv.SetMetrics(reg)
+		srv.SetServerConfig(serverConfig(cfg.Service))
+		setAuth(srv, authenticator)
+		services = append(services, srv)
+		go func() { errs <- srv.Start() }()
+	}
+	if cfg.Service.GRPCAddress != "-1" {
+		srv := grpc.NewGRPCService(nil, cfg.Service.GRPCAddress)
+		srv.SetSessions(sessions)
+		setAuth(srv, authenticator)
+		services = append(services, srv)
+		go func() { errs <- srv.Start() }()
+	}
+	select {
+	case err = <-errs:
+		// a service failed (returned by the command)
+	case sig := <-signals():
+		logger.Sugar().Infow("shutting down", "signal", sig.String())
+	}
+	for _, srv := range services {
+		shutdown(srv, logger.Sugar())
+	}
+	return err
+}
+
+// newMapStore returns the store of the maps managed by the services, with the read-only presets
+func newMapStore(cfg model.ServiceConfig) *maps.FileStore {
+	if cfg.MapPresets == "" {
+		return maps.NewFileStore(cfg.MapsDir)
+	}
+	return maps.NewFileStore(cfg.MapsDir, cfg.MapPresets)
+}
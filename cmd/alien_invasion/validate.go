@@ -0,0 +1,40 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/c-kuroki/alien_invasion/pkg/app"
+)
+
+// validateCommand validates map files, it fails if any map is invalid
+func validateCommand(args []string) error {
+	l := newLoader("validate", "validate [OPTIONS] [<map file>...]")
+	l.fs.StringVar(&l.cfg.MapFilename, "map", l.cfg.MapFilename, "map filename, validated if no file is passed as argument")
+	cfg, err := l.load(args)
+	if err != nil {
+		return err
+	}
+	files := l.args()
+	if len(files) == 0 {
+		files = []string{cfg.MapFilename}
+	}
+	invalid := 0
+	for _, filename := range files {
+		data, err := os.ReadFile(filename)
+		if err != nil {
+			return err
+		}
+		validation := app.ValidateMap(data)
+		if !validation.Valid {
+			invalid++
+			fmt.Printf("%s: invalid: %s\n", filename, validation.Error.Error())
+			continue
+		}
+		fmt.Printf("%s: valid (%d cities, %dx%d)\n", filename, validation.Cities, validation.Width, validation.Height)
+	}
+	if invalid > 0 {
+		return fmt.Errorf("%d of %d maps are invalid", invalid, len(files))
+	}
+	return nil
+}
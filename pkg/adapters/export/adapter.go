@@ -0,0 +1,37 @@
+package export
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/c-kuroki/alien_invasion/pkg/model"
+)
+
+//go:generate mockery --name Adapter
+
+// Export Adapter interface to write a world map in a file format
+type Adapter interface {
+	Export(w io.Writer, cities []*model.City, aliens []*model.Alien) error
+	// Extension returns the file extension of the format (without dot)
+	Extension() string
+}
+
+// Formats
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+	FormatDOT  = "dot"
+)
+
+// New returns the exporter of a format, text is used if format is empty
+func New(format string) (Adapter, error) {
+	switch format {
+	case FormatText, "":
+		return &TextExporter{}, nil
+	case FormatJSON:
+		return &JSONExporter{}, nil
+	case FormatDOT:
+		return &DOTExporter{}, nil
+	}
+	return nil, fmt.Errorf("invalid format [%s] (should be text, json or dot)", format)
+}
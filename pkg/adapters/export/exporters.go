@@ -0,0 +1,119 @@
+package export
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/c-kuroki/alien_invasion/pkg/model"
+)
+
+// check that interface is implemented
+var (
+	_ Adapter = (*TextExporter)(nil)
+	_ Adapter = (*JSONExporter)(nil)
+	_ Adapter = (*DOTExporter)(nil)
+)
+
+// TextExporter writes the map file format, which can be loaded again
+type TextExporter struct{}
+
+func (e *TextExporter) Extension() string {
+	return "map"
+}
+
+func (e *TextExporter) Export(w io.Writer, cities []*model.City, aliens []*model.Alien) error {
+	bw := bufio.NewWriter(w)
+	for _, city := range sortCities(cities) {
+		line := city.Name
+		roads := city.Roads()
+		for _, direction := range []string{model.North, model.East, model.South, model.West} {
+			if name, ok := roads[direction]; ok {
+				line += fmt.Sprintf(" %s=%s", direction, name)
+			}
+		}
+		if _, err := fmt.Fprintln(bw, line); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+// JSONExporter writes the cities and the aliens on them as JSON
+type JSONExporter struct{}
+
+func (e *JSONExporter) Extension() string {
+	return "json"
+}
+
+func (e *JSONExporter) Export(w io.Writer, cities []*model.City, aliens []*model.Alien) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(struct {
+		Cities []*model.City  `json:"cities"`
+		Aliens []*model.Alien `json:"aliens"`
+	}{
+		Cities: sortCities(cities),
+		Aliens: sortAliens(aliens),
+	})
+}
+
+// DOTExporter writes a Graphviz graph, cities are placed at their map coordinates (e.g. neato -n)
+type DOTExporter struct{}
+
+func (e *DOTExporter) Extension() string {
+	return "dot"
+}
+
+func (e *DOTExporter) Export(w io.Writer, cities []*model.City, aliens []*model.Alien) error {
+	aliensByCity := make(map[int]int)
+	for _, alien := range aliens {
+		aliensByCity[alien.City]++
+	}
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, "graph world {")
+	fmt.Fprintln(bw, "  node [shape=circle];")
+	sorted := sortCities(cities)
+	for _, city := range sorted {
+		label := city.Name
+		if n := aliensByCity[city.ID]; n > 0 {
+			label += fmt.Sprintf("\\n%d aliens", n)
+		}
+		fmt.Fprintf(bw, "  %s [label=%s, pos=\"%d,%d!\"];\n", dotQuote(city.Name), dotQuote(label), city.X, -city.Y)
+	}
+	// every road once
+	for _, city := range sorted {
+		if city.East != "" {
+			fmt.Fprintf(bw, "  %s -- %s;\n", dotQuote(city.Name), dotQuote(city.East))
+		}
+		if city.South != "" {
+			fmt.Fprintf(bw, "  %s -- %s;\n", dotQuote(city.Name), dotQuote(city.South))
+		}
+	}
+	fmt.Fprintln(bw, "}")
+	return bw.Flush()
+}
+
+// dotQuote returns a DOT quoted string, escape sequences as \n are kept
+func dotQuote(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, `\"`) + `"`
+}
+
+func sortCities(cities []*model.City) []*model.City {
+	sorted := append([]*model.City{}, cities...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].ID < sorted[j].ID
+	})
+	return sorted
+}
+
+func sortAliens(aliens []*model.Alien) []*model.Alien {
+	sorted := append([]*model.Alien{}, aliens...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].ID < sorted[j].ID
+	})
+	return sorted
+}
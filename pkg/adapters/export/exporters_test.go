@@ -0,0 +1,110 @@
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/c-kuroki/alien_invasion/pkg/model"
+)
+
+type ExportersTestSuite struct {
+	suite.Suite
+	cities []*model.City
+	aliens []*model.Alien
+}
+
+func (suite *ExportersTestSuite) SetupTest() {
+	suite.cities = []*model.City{
+		{ID: 1, Name: "Bar", West: "Foo", X: 1, Y: 0},
+		{ID: 0, Name: "Foo", East: "Bar", South: "Bee", X: 0, Y: 0},
+		{ID: 2, Name: "Bee", North: "Foo", X: 0, Y: 1},
+	}
+	suite.aliens = []*model.Alien{
+		{ID: 3, Name: "Zork", City: 2},
+		{ID: 1, Name: "Mork", City: 2},
+	}
+}
+
+func (suite *ExportersTestSuite) export(format string) string {
+	exporter, err := New(format)
+	suite.Require().NoError(err)
+	var b bytes.Buffer
+	suite.Require().NoError(exporter.Export(&b, suite.cities, suite.aliens))
+	return b.String()
+}
+
+func (suite *ExportersTestSuite) TestText() {
+	suite.Assert().Equal("Foo east=Bar south=Bee\nBar west=Foo\nBee north=Foo\n", suite.export(FormatText))
+}
+
+func (suite *ExportersTestSuite) TestJSON() {
+	var result struct {
+		Cities []*model.City  `json:"cities"`
+		Aliens []*model.Alien `json:"aliens"`
+	}
+	suite.Require().NoError(json.Unmarshal([]byte(suite.export(FormatJSON)), &result))
+	suite.Assert().Equal("Foo", result.Cities[0].Name)
+	suite.Assert().Equal(1, result.Aliens[0].ID)
+}
+
+func (suite *ExportersTestSuite) TestDOT() {
+	suite.Assert().Equal(`graph world {
+  node [shape=circle];
+  "Foo" [label="Foo", pos="0,0!"];
+  "Bar" [label="Bar", pos="1,0!"];
+  "Bee" [label="Bee\n2 aliens", pos="0,-1!"];
+  "Foo" -- "Bar";
+  "Foo" -- "Bee";
+}
+`, suite.export(FormatDOT))
+}
+
+func (suite *ExportersTestSuite) TestSummaryText() {
+	summary := &model.Summary{
+		Tick:   12,
+		Reason: "max moves reached",
+		Destroyed: []*model.Destruction{
+			{Tick: 4, CityID: 3, CityName: "Qux", Attackers: []*model.Alien{{ID: 0, Name: "Gork"}, {ID: 2, Name: "Dork"}}},
+		},
+		Cities:  []*model.City{{ID: 0, Name: "Foo", East: "Bar"}, {ID: 1, Name: "Bar", West: "Foo"}, {ID: 2, Name: "Bee"}},
+		Aliens:  []*model.Alien{{ID: 1, Name: "Mork", City: 0, Moves: 7}, {ID: 3, Name: "Zork", City: 2, Moves: 1}},
+		Trapped: []int{3},
+		Fragmentation: model.Fragmentation{
+			Components: 2,
+			Sizes:      []int{2, 1},
+			Isolated:   1,
+		},
+	}
+	var b bytes.Buffer
+	suite.Require().NoError(WriteSummary(&b, summary, FormatText))
+	suite.Assert().Equal(`Simulation ended at tick 12: max moves reached
+
+Destroyed cities (1)
+  tick 4: Qux destroyed by Gork (#0), Dork (#2)
+
+Surviving cities (3)
+  Foo: east=Bar
+  Bar: west=Foo
+  Bee: no roads
+
+Surviving aliens (2, 1 trapped)
+  Mork (#1) at Foo, 7 moves
+  Zork (#3) at Bee, 1 moves, trapped
+
+Fragmentation
+  2 groups of connected cities (sizes 2, 1), 1 isolated cities
+`, b.String())
+}
+
+func (suite *ExportersTestSuite) TestInvalidFormat() {
+	_, err := New("xml")
+	suite.Assert().Error(err)
+}
+
+// TestExportersTestSuite is the entry point of this test suite
+func TestExportersTestSuite(t *testing.T) {
+	suite.Run(t, new(ExportersTestSuite))
+}
@@ -0,0 +1,75 @@
+package export
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/c-kuroki/alien_invasion/pkg/model"
+)
+
+// WriteSummary writes a summary report as text or JSON
+func WriteSummary(w io.Writer, summary *model.Summary, format string) error {
+	switch format {
+	case FormatText, "":
+		return writeSummaryText(w, summary)
+	case FormatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(summary)
+	}
+	return fmt.Errorf("invalid summary format [%s] (should be text or json)", format)
+}
+
+func writeSummaryText(w io.Writer, summary *model.Summary) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "Simulation ended at tick %d: %s\n", summary.Tick, summary.Reason)
+
+	fmt.Fprintf(bw, "\nDestroyed cities (%d)\n", len(summary.Destroyed))
+	for _, d := range summary.Destroyed {
+		attackers := make([]string, 0, len(d.Attackers))
+		for _, alien := range d.Attackers {
+			attackers = append(attackers, fmt.Sprintf("%s (#%d)", alien.Name, alien.ID))
+		}
+		fmt.Fprintf(bw, "  tick %d: %s destroyed by %s\n", d.Tick, d.CityName, strings.Join(attackers, ", "))
+	}
+
+	fmt.Fprintf(bw, "\nSurviving cities (%d)\n", len(summary.Cities))
+	for _, city := range summary.Cities {
+		roads := city.Roads()
+		var exits []string
+		for _, direction := range []string{model.North, model.East, model.South, model.West} {
+			if name, ok := roads[direction]; ok {
+				exits = append(exits, direction+"="+name)
+			}
+		}
+		if len(exits) == 0 {
+			exits = append(exits, "no roads")
+		}
+		fmt.Fprintf(bw, "  %s: %s\n", city.Name, strings.Join(exits, " "))
+	}
+
+	trapped := make(map[int]bool, len(summary.Trapped))
+	for _, alienID := range summary.Trapped {
+		trapped[alienID] = true
+	}
+	fmt.Fprintf(bw, "\nSurviving aliens (%d, %d trapped)\n", len(summary.Aliens), len(summary.Trapped))
+	for _, alien := range summary.Aliens {
+		fmt.Fprintf(bw, "  %s (#%d) at %s, %d moves", alien.Name, alien.ID, summary.CityName(alien.City), alien.Moves)
+		if trapped[alien.ID] {
+			fmt.Fprint(bw, ", trapped")
+		}
+		fmt.Fprintln(bw)
+	}
+
+	frag := summary.Fragmentation
+	sizes := make([]string, 0, len(frag.Sizes))
+	for _, size := range frag.Sizes {
+		sizes = append(sizes, fmt.Sprint(size))
+	}
+	fmt.Fprintf(bw, "\nFragmentation\n  %d groups of connected cities (sizes %s), %d isolated cities\n",
+		frag.Components, strings.Join(sizes, ", "), frag.Isolated)
+	return bw.Flush()
+}
@@ -0,0 +1,17 @@
+package maps
+
+import (
+	"github.com/c-kuroki/alien_invasion/pkg/model"
+)
+
+//go:generate mockery --name Adapter
+
+// Maps Adapter interface to store world maps by name
+type Adapter interface {
+	List() ([]*model.MapInfo, error)
+	Get(name string) ([]byte, error)
+	Put(name string, data []byte) (*model.MapInfo, error)
+	Delete(name string) error
+	// Path returns the location of a map to be loaded by a world adapter
+	Path(name string) (string, error)
+}
@@ -0,0 +1,158 @@
+package maps
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/c-kuroki/alien_invasion/pkg/model"
+)
+
+// check that interface is implemented
+var _ Adapter = (*FileStore)(nil)
+
+const mapExt = ".map"
+
+var (
+	ErrNotFound    = errors.New("map not found")
+	ErrInvalidName = errors.New("invalid map name (only letters, digits, _ and - are allowed)")
+	ErrReadOnly    = errors.New("map is a read-only preset")
+)
+
+// valid map names, to avoid reading or writing files outside the store directory
+var validName = regexp.MustCompile(`^[A-Za-z0-9_-]{1,64}$`)
+
+// FileStore stores maps as <name>.map files on a local directory, maps of the presets directories
+// can be read but not replaced nor deleted
+type FileStore struct {
+	dir     string
+	presets []string
+}
+
+// NewFileStore stores maps on dir, presets are read-only directories searched after it
+func NewFileStore(dir string, presets ...string) *FileStore {
+	return &FileStore{
+		dir:     dir,
+		presets: presets,
+	}
+}
+
+func (fs *FileStore) List() ([]*model.MapInfo, error) {
+	maps := []*model.MapInfo{}
+	listed := make(map[string]bool)
+	for ix, dir := range append([]string{fs.dir}, fs.presets...) {
+		entries, err := os.ReadDir(dir)
+		if errors.Is(err, os.ErrNotExist) {
+			// the store directory is created on first upload
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range entries {
+			name := strings.TrimSuffix(entry.Name(), mapExt)
+			if entry.IsDir() || !strings.HasSuffix(entry.Name(), mapExt) || !validName.MatchString(name) || listed[name] {
+				continue
+			}
+			info, err := entry.Info()
+			if err != nil {
+				return nil, err
+			}
+			mi := mapInfo(name, info)
+			mi.ReadOnly = ix > 0
+			maps = append(maps, mi)
+			listed[name] = true
+		}
+	}
+	sort.Slice(maps, func(i, j int) bool {
+		return maps[i].Name < maps[j].Name
+	})
+	return maps, nil
+}
+
+func (fs *FileStore) Get(name string) ([]byte, error) {
+	path, err := fs.Path(name)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	return data, err
+}
+
+// Put creates or replaces a map, a map named as a preset hides it
+func (fs *FileStore) Put(name string, data []byte) (*model.MapInfo, error) {
+	if !validName.MatchString(name) {
+		return nil, ErrInvalidName
+	}
+	path := filepath.Join(fs.dir, name+mapExt)
+	if err := os.MkdirAll(fs.dir, 0o755); err != nil {
+		return nil, err
+	}
+	// write to a temporary file and rename it, so readers never see a partial map
+	tmp, err := os.CreateTemp(fs.dir, name+".*.tmp")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return nil, err
+	}
+	if err := tmp.Close(); err != nil {
+		return nil, err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return nil, err
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	return mapInfo(name, info), nil
+}
+
+func (fs *FileStore) Delete(name string) error {
+	if !validName.MatchString(name) {
+		return ErrInvalidName
+	}
+	err := os.Remove(filepath.Join(fs.dir, name+mapExt))
+	if !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	if path, _ := fs.Path(name); path != filepath.Join(fs.dir, name+mapExt) {
+		return ErrReadOnly
+	}
+	return ErrNotFound
+}
+
+// Path returns the map file on the store directory, or on the first preset directory holding it
+func (fs *FileStore) Path(name string) (string, error) {
+	if !validName.MatchString(name) {
+		return "", ErrInvalidName
+	}
+	path := filepath.Join(fs.dir, name+mapExt)
+	if _, err := os.Stat(path); err == nil {
+		return path, nil
+	}
+	for _, dir := range fs.presets {
+		preset := filepath.Join(dir, name+mapExt)
+		if _, err := os.Stat(preset); err == nil {
+			return preset, nil
+		}
+	}
+	return path, nil
+}
+
+func mapInfo(name string, info os.FileInfo) *model.MapInfo {
+	return &model.MapInfo{
+		Name:      name,
+		Size:      info.Size(),
+		UpdatedAt: info.ModTime(),
+	}
+}
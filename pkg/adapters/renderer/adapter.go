@@ -12,4 +12,5 @@ import (
 // Renderer Adapter interface to render world map
 type Adapter interface {
 	Render(ctx context.Context, cities []*model.City, aliens map[int]map[int]*model.Alien, w io.Writer) error
+	RenderOverlay(ctx context.Context, cities []*model.City, stats *model.Stats, overlay Overlay, w io.Writer) error
 }
@@ -0,0 +1,68 @@
+package renderer_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"testing"
+
+	"github.com/c-kuroki/alien_invasion/pkg/adapters/renderer"
+	"github.com/c-kuroki/alien_invasion/pkg/model"
+)
+
+// benchWorld returns a square grid of numCities cities with every road, and an alien on every
+// tenth city (two of them on every hundredth)
+func benchWorld(numCities int) ([]*model.City, map[int]map[int]*model.Alien) {
+	side := int(math.Sqrt(float64(numCities)))
+	name := func(x, y int) string {
+		return fmt.Sprintf("C%d-%d", x, y)
+	}
+	cities := make([]*model.City, 0, side*side)
+	aliens := make(map[int]map[int]*model.Alien)
+	for y := 0; y < side; y++ {
+		for x := 0; x < side; x++ {
+			city := &model.City{ID: len(cities), Name: name(x, y), X: x, Y: y}
+			if y > 0 {
+				city.North = name(x, y-1)
+			}
+			if x < side-1 {
+				city.East = name(x+1, y)
+			}
+			if y < side-1 {
+				city.South = name(x, y+1)
+			}
+			if x > 0 {
+				city.West = name(x-1, y)
+			}
+			cities = append(cities, city)
+			if city.ID%10 == 0 {
+				aliens[city.ID] = map[int]*model.Alien{city.ID: {ID: city.ID, Name: "Alien", City: city.ID}}
+			}
+			if city.ID%100 == 0 {
+				intruder := numCities + city.ID
+				aliens[city.ID][intruder] = &model.Alien{ID: intruder, Name: "Intruder", City: city.ID}
+			}
+		}
+	}
+	return cities, aliens
+}
+
+func BenchmarkRender(b *testing.B) {
+	rnd := renderer.NewSVGRenderer()
+	for _, numCities := range []int{100, 10_000, 1_000_000} {
+		b.Run(fmt.Sprintf("cities=%d", numCities), func(b *testing.B) {
+			if testing.Short() && numCities > 10_000 {
+				b.Skip("skipping large map on short mode")
+			}
+			cities, aliens := benchWorld(numCities)
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if err := rnd.Render(context.Background(), cities, aliens, io.Discard); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package renderer_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/rand"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/c-kuroki/alien_invasion/pkg/adapters/renderer"
+	"github.com/c-kuroki/alien_invasion/pkg/adapters/renderer/renderertest"
+	"github.com/c-kuroki/alien_invasion/pkg/adapters/world"
+	"github.com/c-kuroki/alien_invasion/pkg/model"
+)
+
+// ExampleMapsTestSuite renders the example maps at several states of a simulation and compares
+// them with the golden files on testdata/examples
+type ExampleMapsTestSuite struct {
+	suite.Suite
+	renderer *renderer.SVGRenderer
+}
+
+func (suite *ExampleMapsTestSuite) SetupTest() {
+	suite.renderer = renderer.NewSVGRenderer()
+}
+
+// load returns the world of an example map, with aliens placed on every other city (two of
+// them on the first one) when numAliens is not 0
+func (suite *ExampleMapsTestSuite) load(name string, numAliens int) world.Adapter {
+	st := world.NewInMemoryState(filepath.Join("..", "..", "..", "examples", name+".map"))
+	suite.Require().NoError(st.Load())
+	cities := st.GetAllCities()
+	for i := 0; i < numAliens; i++ {
+		cityID := (2 * i) % len(cities)
+		if i > 0 && cityID == 0 {
+			cityID = 1
+		}
+		alien := &model.Alien{ID: i, Name: fmt.Sprintf("Alien-%d", i), City: cityID, Origin: (cityID + 1) % len(cities), Moves: i}
+		suite.Require().NoError(st.AddAlien(alien))
+	}
+	if numAliens > 1 {
+		suite.Require().NoError(st.AddAlien(&model.Alien{ID: numAliens, Name: "Intruder", City: 0, Origin: 0}))
+	}
+	return st
+}
+
+// fight removes the cities with more than one alien as fights do, returning them to be rendered
+func (suite *ExampleMapsTestSuite) fight(st world.Adapter) []*model.City {
+	var cityIDs []int
+	for cityID, aliens := range st.GetAllAliensByCity() {
+		if len(aliens) > 1 {
+			cityIDs = append(cityIDs, cityID)
+		}
+	}
+	destroyed := make([]*model.City, 0, len(cityIDs))
+	for _, cityID := range cityIDs {
+		city, err := st.GetCityByID(cityID)
+		suite.Require().NoError(err)
+		c := *city
+		c.Destroyed = true
+		destroyed = append(destroyed, &c)
+		suite.Require().NoError(st.RemoveCity(cityID))
+	}
+	return destroyed
+}
+
+// assertRender renders cities in a shuffled order, so the golden file also checks the render order
+func (suite *ExampleMapsTestSuite) assertRender(name string, cities []*model.City, aliens map[int]map[int]*model.Alien) {
+	rnd := rand.New(rand.NewSource(int64(len(name))))
+	rnd.Shuffle(len(cities), func(i, j int) {
+		cities[i], cities[j] = cities[j], cities[i]
+	})
+	var b bytes.Buffer
+	suite.Require().NoError(suite.renderer.Render(context.Background(), cities, aliens, &b))
+	renderertest.AssertGolden(suite.T(), filepath.Join("testdata", "examples", name+".svg"), b.Bytes())
+}
+
+func (suite *ExampleMapsTestSuite) TestRender() {
+	testCases := []struct {
+		name      string
+		numAliens int
+		fight     bool
+	}{
+		{name: "fresh"},
+		{name: "aliens", numAliens: 6},
+		{name: "destructions", numAliens: 6, fight: true},
+	}
+	for _, example := range []string{"world", "big"} {
+		for _, tc := range testCases {
+			suite.Run(example+"_"+tc.name, func() {
+				st := suite.load(example, tc.numAliens)
+				cities := st.GetAllCities()
+				if tc.fight {
+					destroyed := suite.fight(st)
+					suite.Require().NotEmpty(destroyed)
+					cities = append(st.GetAllCities(), destroyed...)
+				}
+				suite.assertRender(example+"_"+tc.name, cities, st.GetAllAliensByCity())
+			})
+		}
+	}
+}
+
+// TestExampleMapsTestSuite is the entry point of this test suite
+func TestExampleMapsTestSuite(t *testing.T) {
+	suite.Run(t, new(ExampleMapsTestSuite))
+}
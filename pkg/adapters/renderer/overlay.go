@@ -0,0 +1,97 @@
+package renderer
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/c-kuroki/alien_invasion/pkg/model"
+)
+
+// Overlay selects a per city metric used to colour the map
+type Overlay string
+
+const (
+	OverlayNone                   Overlay = ""
+	OverlayVisits                 Overlay = "visits"
+	OverlayFights                 Overlay = "fights"
+	OverlayTimeToDestruction      Overlay = "time_to_destruction"
+	OverlayDestructionProbability Overlay = "destruction_probability"
+)
+
+var validOverlay map[Overlay]bool = map[Overlay]bool{
+	OverlayVisits:                 true,
+	OverlayFights:                 true,
+	OverlayTimeToDestruction:      true,
+	OverlayDestructionProbability: true,
+}
+
+func IsValidOverlay(overlay Overlay) bool {
+	return validOverlay[overlay]
+}
+
+// value returns the metric of a city for the overlay, false if the city has none (e.g. the time
+// to destruction of a city never destroyed)
+func (o Overlay) value(stats *model.Stats, cityID int) (float64, bool) {
+	cs, ok := stats.Cities[cityID]
+	if !ok {
+		cs = &model.CityStats{CityID: cityID}
+	}
+	switch o {
+	case OverlayVisits:
+		return float64(cs.Visits), true
+	case OverlayFights:
+		return float64(cs.Fights), true
+	case OverlayTimeToDestruction:
+		return cs.TimeToDestruction()
+	case OverlayDestructionProbability:
+		return stats.DestructionProbability(cityID), true
+	}
+	return 0, false
+}
+
+// label formats a metric value to be displayed on a city
+func (o Overlay) label(value float64, ok bool) string {
+	if !ok {
+		return "-"
+	}
+	switch o {
+	case OverlayDestructionProbability:
+		return fmt.Sprintf("%.0f%%", value*100)
+	case OverlayTimeToDestruction:
+		return fmt.Sprintf("%.1f", value)
+	}
+	return fmt.Sprintf("%.0f", value)
+}
+
+// heatColor interpolates between a cold and a hot hex color, ratio goes from 0 to 1
+func heatColor(cold, hot string, ratio float64) string {
+	if ratio < 0 {
+		ratio = 0
+	}
+	if ratio > 1 {
+		ratio = 1
+	}
+	// theme colors are validated, invalid ones are drawn as black
+	cr, cg, cb, _ := parseHexColor(cold)
+	hr, hg, hb, _ := parseHexColor(hot)
+	r := int(float64(cr) + ratio*float64(hr-cr))
+	g := int(float64(cg) + ratio*float64(hg-cg))
+	b := int(float64(cb) + ratio*float64(hb-cb))
+	return fmt.Sprintf("#%02x%02x%02x", r, g, b)
+}
+
+// hexColorRe matches #rrggbb colors
+var hexColorRe = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+// parseHexColor parses a #rrggbb color
+func parseHexColor(color string) (int, int, int, error) {
+	if !hexColorRe.MatchString(color) {
+		return 0, 0, 0, fmt.Errorf("invalid color [%s] (should be #rrggbb)", color)
+	}
+	rgb, err := strconv.ParseUint(color[1:], 16, 32)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	return int(rgb >> 16), int(rgb >> 8 & 0xff), int(rgb & 0xff), nil
+}
@@ -0,0 +1,73 @@
+package renderer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/c-kuroki/alien_invasion/pkg/model"
+)
+
+type OverlayTestSuite struct {
+	suite.Suite
+	stats *model.Stats
+}
+
+func (suite *OverlayTestSuite) SetupTest() {
+	// two runs, city 1 destroyed on both by 2 and 3 aliens, city 2 only visited
+	suite.stats = model.NewStats()
+	suite.stats.Runs = 2
+	suite.stats.Visit(1)
+	suite.stats.Fight(1, 4, 2)
+	suite.stats.Fight(1, 8, 3)
+	suite.stats.Visit(2)
+}
+
+func (suite *OverlayTestSuite) TestValue() {
+	testCases := []struct {
+		overlay Overlay
+		cityID  int
+		value   float64
+		ok      bool
+		label   string
+	}{
+		{OverlayVisits, 1, 1, true, "1"},
+		{OverlayVisits, 3, 0, true, "0"},
+		{OverlayFights, 1, 5, true, "5"},
+		{OverlayFights, 2, 0, true, "0"},
+		{OverlayTimeToDestruction, 1, 6, true, "6.0"},
+		// never destroyed cities have no time to destruction
+		{OverlayTimeToDestruction, 2, 0, false, "-"},
+		{OverlayTimeToDestruction, 3, 0, false, "-"},
+		{OverlayDestructionProbability, 1, 1, true, "100%"},
+		{OverlayDestructionProbability, 2, 0, true, "0%"},
+	}
+	for _, tc := range testCases {
+		value, ok := tc.overlay.value(suite.stats, tc.cityID)
+		suite.Assert().Equal(tc.value, value, "%s of city %d", tc.overlay, tc.cityID)
+		suite.Assert().Equal(tc.ok, ok, "%s of city %d", tc.overlay, tc.cityID)
+		suite.Assert().Equal(tc.label, tc.overlay.label(value, ok), "%s of city %d", tc.overlay, tc.cityID)
+	}
+}
+
+func (suite *OverlayTestSuite) TestIsValidOverlay() {
+	for _, overlay := range []Overlay{OverlayVisits, OverlayFights, OverlayTimeToDestruction, OverlayDestructionProbability} {
+		suite.Assert().True(IsValidOverlay(overlay), string(overlay))
+	}
+	suite.Assert().False(IsValidOverlay(OverlayNone))
+	suite.Assert().False(IsValidOverlay("moves"))
+}
+
+func (suite *OverlayTestSuite) TestHeatColor() {
+	suite.Assert().Equal("#000000", heatColor("#000000", "#ff8040", 0))
+	suite.Assert().Equal("#ff8040", heatColor("#000000", "#ff8040", 1))
+	suite.Assert().Equal("#7f4020", heatColor("#000000", "#ff8040", 0.5))
+	// ratios are clamped
+	suite.Assert().Equal("#000000", heatColor("#000000", "#ff8040", -1))
+	suite.Assert().Equal("#ff8040", heatColor("#000000", "#ff8040", 2))
+}
+
+// TestOverlayTestSuite is the entry point of this test suite
+func TestOverlayTestSuite(t *testing.T) {
+	suite.Run(t, new(OverlayTestSuite))
+}
@@ -0,0 +1,150 @@
+// Package renderertest is a golden file test suite for renderer.Adapter implementations
+package renderertest
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/c-kuroki/alien_invasion/pkg/adapters/renderer"
+	"github.com/c-kuroki/alien_invasion/pkg/model"
+)
+
+// Update rewrites the golden files with the current output (go test -args -update)
+var Update = flag.Bool("update", false, "update golden files")
+
+// Run renders the suite fixtures with rnd and compares them with the golden files on dir
+func Run(t *testing.T, rnd renderer.Adapter, dir string) {
+	suite.Run(t, &Suite{Renderer: rnd, Dir: dir})
+}
+
+// Suite checks that a renderer output is valid XML and matches its golden files
+type Suite struct {
+	suite.Suite
+	Renderer renderer.Adapter
+	// Dir holds a golden file per fixture, named after it
+	Dir string
+}
+
+// Cities returns the fixture world, the example map with Qu-ux destroyed
+func Cities() []*model.City {
+	return []*model.City{
+		{ID: 0, Name: "Foo", North: "Bar", West: "Baz", X: 1, Y: 1},
+		{ID: 1, Name: "Bar", South: "Foo", West: "Bee", X: 1, Y: 0},
+		{ID: 2, Name: "Qu-ux", X: 1, Y: 2, Destroyed: true},
+		{ID: 3, Name: "Baz", East: "Foo", X: 0, Y: 1},
+		{ID: 4, Name: "Bee", East: "Bar", X: 0, Y: 0},
+	}
+}
+
+// Aliens returns the fixture aliens by city
+func Aliens() map[int]map[int]*model.Alien {
+	return map[int]map[int]*model.Alien{
+		0: {
+			3: {ID: 3, Name: "Zork", City: 0, Origin: 1, Moves: 4},
+			5: {ID: 5, Name: "Mork", City: 0, Origin: 0},
+		},
+		4: {
+			1: {ID: 1, Name: "Gork", City: 4, Origin: 3, Moves: 12},
+		},
+		// cities left without aliens
+		1: {},
+	}
+}
+
+// Stats returns the fixture metrics of two runs
+func Stats() *model.Stats {
+	stats := model.NewStats()
+	stats.Runs = 2
+	for cityID, visits := range []int{6, 3, 2, 1, 0} {
+		stats.City(cityID).Visits = visits
+	}
+	stats.Fight(2, 3, 2)
+	stats.Fight(2, 7, 3)
+	stats.Fight(1, 9, 2)
+	return stats
+}
+
+func (suite *Suite) TestRender() {
+	testCases := []struct {
+		name   string
+		cities []*model.City
+		aliens map[int]map[int]*model.Alien
+	}{
+		{name: "world", cities: Cities(), aliens: Aliens()},
+		{name: "no_aliens", cities: Cities(), aliens: map[int]map[int]*model.Alien{}},
+		{name: "empty", cities: []*model.City{}, aliens: map[int]map[int]*model.Alien{}},
+	}
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			var b bytes.Buffer
+			suite.Require().NoError(suite.Renderer.Render(context.Background(), tc.cities, tc.aliens, &b))
+			suite.assertGolden(tc.name, b.Bytes())
+		})
+	}
+}
+
+func (suite *Suite) TestRenderOverlay() {
+	overlays := []renderer.Overlay{
+		renderer.OverlayVisits,
+		renderer.OverlayFights,
+		renderer.OverlayTimeToDestruction,
+		renderer.OverlayDestructionProbability,
+	}
+	// overlays are drawn over the initial map
+	cities := Cities()
+	cities[0].South = "Qu-ux"
+	cities[2].North = "Foo"
+	cities[2].Destroyed = false
+	for _, overlay := range overlays {
+		suite.Run(string(overlay), func() {
+			var b bytes.Buffer
+			suite.Require().NoError(suite.Renderer.RenderOverlay(context.Background(), cities, Stats(), overlay, &b))
+			suite.assertGolden("overlay_"+string(overlay), b.Bytes())
+		})
+	}
+}
+
+func (suite *Suite) TestUnknownTheme() {
+	ctx := renderer.WithTheme(context.Background(), "unknown")
+	err := suite.Renderer.Render(ctx, Cities(), Aliens(), io.Discard)
+	suite.Assert().True(errors.Is(err, renderer.ErrUnknownTheme), "render error %v", err)
+	err = suite.Renderer.RenderOverlay(ctx, Cities(), Stats(), renderer.OverlayVisits, io.Discard)
+	suite.Assert().True(errors.Is(err, renderer.ErrUnknownTheme), "render overlay error %v", err)
+}
+
+// assertGolden checks that output is valid XML equal to the golden file, or rewrites it with -update
+func (suite *Suite) assertGolden(name string, output []byte) {
+	AssertGolden(suite.T(), filepath.Join(suite.Dir, name+".svg"), output)
+}
+
+// AssertGolden checks that output is valid XML equal to a golden file, or rewrites the file with -update
+func AssertGolden(t *testing.T, filename string, output []byte) {
+	t.Helper()
+	dec := xml.NewDecoder(bytes.NewReader(output))
+	for {
+		_, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err, "invalid XML")
+	}
+	if *Update {
+		require.NoError(t, os.MkdirAll(filepath.Dir(filename), 0o755))
+		require.NoError(t, os.WriteFile(filename, output, 0o644))
+		return
+	}
+	golden, err := os.ReadFile(filename)
+	require.NoError(t, err, "missing golden file, run the tests with -args -update to write it")
+	assert.Equal(t, string(golden), string(output), "output differs from %s", filename)
+}
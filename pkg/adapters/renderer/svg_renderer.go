@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"math"
+	"sort"
 
 	svg "github.com/ajstarks/svgo"
 	"github.com/c-kuroki/alien_invasion/pkg/model"
@@ -12,67 +14,242 @@ import (
 // check that interface is implemented
 var _ Adapter = (*SVGRenderer)(nil)
 
-const (
-	cityColor  = "fill:#283f93"
-	alienColor = "fill:#19e822"
-	fightColor = "fill:#e81922"
-)
-
 // SVGRenderer render an invasion map on a SVG image
 type SVGRenderer struct {
-	width      int
-	height     int
-	citySize   int
-	cityWidth  int
-	connSize   int
-	alienWidth int
+	// default theme, used when no theme is selected on context
+	theme *Theme
+	// available themes by name
+	themes map[string]*Theme
 }
 
 func NewSVGRenderer() *SVGRenderer {
-	return &SVGRenderer{
-		width:      800,
-		height:     800,
-		citySize:   120,
-		cityWidth:  40,
-		connSize:   10,
-		alienWidth: 12,
+	r := &SVGRenderer{
+		themes: make(map[string]*Theme),
+	}
+	r.AddTheme(NewLightTheme())
+	r.AddTheme(NewDarkTheme())
+	r.AddTheme(NewHighContrastTheme())
+	r.theme = r.themes[ThemeLight]
+	return r
+}
+
+// AddTheme makes a theme selectable by name, replacing any theme with the same name
+func (r *SVGRenderer) AddTheme(theme *Theme) {
+	r.themes[theme.Name] = theme
+}
+
+// SetTheme sets the default theme by name
+func (r *SVGRenderer) SetTheme(name string) error {
+	theme, ok := r.themes[name]
+	if !ok {
+		return fmt.Errorf("%w [%s]", ErrUnknownTheme, name)
 	}
+	r.theme = theme
+	return nil
+}
+
+// getTheme returns the theme selected on context or the default one
+func (r *SVGRenderer) getTheme(ctx context.Context) (*Theme, error) {
+	name := themeFromContext(ctx)
+	if name == "" {
+		return r.theme, nil
+	}
+	theme, ok := r.themes[name]
+	if !ok {
+		return nil, fmt.Errorf("%w [%s]", ErrUnknownTheme, name)
+	}
+	return theme, nil
 }
 
 func (r *SVGRenderer) Render(ctx context.Context, cities []*model.City, aliens map[int]map[int]*model.Alien, w io.Writer) error {
+	t, err := r.getTheme(ctx)
+	if err != nil {
+		return err
+	}
+	cities = sortedCities(cities)
+	// index city names to show aliens origin
+	names := make(map[int]string, len(cities))
+	for _, city := range cities {
+		names[city.ID] = city.Name
+	}
+	citySize := t.Sizes.City
 	canvas := svg.New(w)
-	canvas.Start(r.width, r.height)
+	canvas.Start(t.Sizes.Width, t.Sizes.Height)
+	canvas.Rect(0, 0, t.Sizes.Width, t.Sizes.Height, fill(t.Palette.Background))
 	for _, city := range cities {
-		x := r.citySize * city.X
-		y := r.citySize * city.Y
+		x := citySize * city.X
+		y := citySize * city.Y
+		// destroyed cities have no roads nor aliens
+		if city.Destroyed {
+			canvas.Group()
+			canvas.Title(fmt.Sprintf("%s (destroyed)", city.Name))
+			canvas.Circle(x+citySize/2, y+citySize/2, t.Sizes.CityRadius, fill(t.Palette.Destroyed))
+			if t.Labels.CityNames {
+				canvas.Text(x+citySize/2, y+citySize/2, city.Name, t.font(t.Fonts.CityLabel, t.Palette.CityLabel, "middle"))
+			}
+			canvas.Gend()
+			continue
+		}
 		// render city
 		// connections
-		if city.North != "" {
-			canvas.Rect(x+r.citySize/2-r.connSize/2, y, r.connSize, r.citySize/2, cityColor)
+		renderConnections(canvas, t, city, x, y)
+		// name
+		canvas.Circle(x+citySize/2, y+citySize/2, t.Sizes.CityRadius, fill(t.Palette.City))
+		if t.Labels.CityNames {
+			canvas.Text(x+citySize/2, y+citySize/2, city.Name, t.font(t.Fonts.CityLabel, t.Palette.CityLabel, "middle"))
 		}
-		if city.South != "" {
-			canvas.Rect(x+r.citySize/2-r.connSize/2, y+r.citySize/2, r.connSize, r.citySize/2, cityColor)
+		// aliens
+		renderAliens(canvas, t, aliens[city.ID], names, x+citySize/2, y+citySize/2)
+	}
+	if t.Labels.Legend {
+		renderLegend(canvas, t)
+	}
+	canvas.End()
+	return nil
+}
+
+// renderAliens draws a marker per alien, laid out around the city center (cx,cy)
+func renderAliens(canvas *svg.SVG, t *Theme, aliens map[int]*model.Alien, names map[int]string, cx, cy int) {
+	numAliens := len(aliens)
+	if numAliens == 0 {
+		return
+	}
+	color := t.Palette.Alien
+	if numAliens > 1 {
+		color = t.Palette.Fight
+	}
+	// sort aliens by ID so markers do not swap places between renders
+	ids := make([]int, 0, numAliens)
+	for id := range aliens {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	// shrink markers when the city is crowded
+	size := t.Sizes.AlienRadius
+	if numAliens > 2 {
+		size = t.Sizes.AlienRadius * 2 / numAliens
+		if size < 4 {
+			size = 4
 		}
-		if city.East != "" {
-			canvas.Rect(x+r.citySize/2, y+r.citySize/2-r.connSize/2, r.citySize/2, r.connSize, cityColor)
+	}
+	for ix, id := range ids {
+		alien := aliens[id]
+		ax, ay := cx, cy+t.Sizes.City/8
+		if numAliens > 1 {
+			angle := 2 * math.Pi * float64(ix) / float64(numAliens)
+			radius := float64(t.Sizes.CityRadius) * 3 / 4
+			ax = cx + int(radius*math.Sin(angle))
+			ay = cy + int(radius*math.Cos(angle))
 		}
-		if city.West != "" {
-			canvas.Rect(x, y+r.citySize/2-r.connSize/2, r.citySize/2, r.connSize, cityColor)
+		origin, ok := names[alien.Origin]
+		if !ok {
+			origin = fmt.Sprintf("#%d", alien.Origin)
 		}
-		// name
-		canvas.Circle(x+r.citySize/2, y+r.citySize/2, r.cityWidth, cityColor)
-		canvas.Text(x+r.citySize/2, y+r.citySize/2, city.Name, "text-anchor:middle;font-size:16px;font-family:helvetica;fill:white")
-		// aliens
-		numAliens := len(aliens[city.ID])
-		if numAliens > 0 {
-			if numAliens == 1 {
-				canvas.Circle(x+r.citySize/2, y+r.citySize/2+r.citySize/8, r.alienWidth, alienColor)
-			} else {
-				canvas.Circle(x+r.citySize/2, y+r.citySize/2+r.citySize/8, r.alienWidth, fightColor)
+		canvas.Group()
+		canvas.Title(fmt.Sprintf("ID: %d\nName: %s\nMoves: %d\nOrigin: %s", alien.ID, alien.Name, alien.Moves, origin))
+		canvas.Circle(ax, ay, size, fill(color))
+		if t.Labels.AlienIDs {
+			fontSize := t.Fonts.AlienLabel
+			if fontSize > size {
+				fontSize = size
+			}
+			canvas.Text(ax, ay+fontSize/3, fmt.Sprintf("%d", alien.ID), t.font(fontSize, t.Palette.AlienLabel, "middle"))
+		}
+		canvas.Gend()
+	}
+}
+
+// renderLegend explains marker colors
+func renderLegend(canvas *svg.SVG, t *Theme) {
+	entries := []struct {
+		label string
+		color string
+	}{
+		{"single alien", t.Palette.Alien},
+		{"contested (fight)", t.Palette.Fight},
+		{"destroyed city", t.Palette.Destroyed},
+	}
+	radius := t.Sizes.AlienRadius
+	x := 10
+	y := t.Sizes.Height - len(entries)*(radius*2+4)
+	for _, entry := range entries {
+		canvas.Circle(x+radius, y+radius, radius, fill(entry.color))
+		canvas.Text(x+radius*3, y+radius+radius/2, entry.label, t.font(t.Fonts.Legend, t.Palette.Legend, ""))
+		y += radius*2 + 4
+	}
+}
+
+// RenderOverlay renders a map coloring each city by the overlay metric
+func (r *SVGRenderer) RenderOverlay(ctx context.Context, cities []*model.City, stats *model.Stats, overlay Overlay, w io.Writer) error {
+	if !IsValidOverlay(overlay) {
+		return fmt.Errorf("invalid overlay [%s]", overlay)
+	}
+	t, err := r.getTheme(ctx)
+	if err != nil {
+		return err
+	}
+	cities = sortedCities(cities)
+	// get max value to normalize colors
+	var max float64
+	for _, city := range cities {
+		if v, _ := overlay.value(stats, city.ID); v > max {
+			max = v
+		}
+	}
+	citySize := t.Sizes.City
+	canvas := svg.New(w)
+	canvas.Start(t.Sizes.Width, t.Sizes.Height)
+	canvas.Rect(0, 0, t.Sizes.Width, t.Sizes.Height, fill(t.Palette.Background))
+	for _, city := range cities {
+		x := citySize * city.X
+		y := citySize * city.Y
+		renderConnections(canvas, t, city, x, y)
+		value, ok := overlay.value(stats, city.ID)
+		color := t.Palette.HeatNone
+		if ok {
+			var ratio float64
+			if max > 0 {
+				ratio = value / max
 			}
-			canvas.Text(x+r.citySize/2, y+r.citySize/2+r.citySize/8+r.alienWidth/3, fmt.Sprintf("%d", numAliens), "text-anchor:middle;font-size:10px;font-family:helvetica;fill:black")
+			color = heatColor(t.Palette.HeatCold, t.Palette.HeatHot, ratio)
 		}
+		canvas.Circle(x+citySize/2, y+citySize/2, t.Sizes.CityRadius, fill(color))
+		if t.Labels.CityNames {
+			canvas.Text(x+citySize/2, y+citySize/2, city.Name, t.font(t.Fonts.CityLabel, t.Palette.CityLabel, "middle"))
+		}
+		canvas.Text(x+citySize/2, y+citySize/2+citySize/8, overlay.label(value, ok), t.font(t.Fonts.AlienLabel, t.Palette.CityLabel, "middle"))
+	}
+	if t.Labels.Legend {
+		canvas.Text(10, t.Sizes.Height-10, fmt.Sprintf("%s (runs: %d)", overlay, stats.Runs), t.font(t.Fonts.Legend, t.Palette.Legend, ""))
 	}
 	canvas.End()
 	return nil
 }
+
+// sortedCities returns a copy of cities in ID order, so the same world is always drawn the same way
+func sortedCities(cities []*model.City) []*model.City {
+	sorted := append([]*model.City(nil), cities...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].ID < sorted[j].ID
+	})
+	return sorted
+}
+
+// renderConnections draws the roads of a city
+func renderConnections(canvas *svg.SVG, t *Theme, city *model.City, x, y int) {
+	citySize := t.Sizes.City
+	connSize := t.Sizes.Road
+	style := fill(t.Palette.Road)
+	if city.North != "" {
+		canvas.Rect(x+citySize/2-connSize/2, y, connSize, citySize/2, style)
+	}
+	if city.South != "" {
+		canvas.Rect(x+citySize/2-connSize/2, y+citySize/2, connSize, citySize/2, style)
+	}
+	if city.East != "" {
+		canvas.Rect(x+citySize/2, y+citySize/2-connSize/2, citySize/2, connSize, style)
+	}
+	if city.West != "" {
+		canvas.Rect(x, y+citySize/2-connSize/2, citySize/2, connSize, style)
+	}
+}
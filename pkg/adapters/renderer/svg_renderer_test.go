@@ -0,0 +1,24 @@
+package renderer_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/c-kuroki/alien_invasion/pkg/adapters/renderer"
+	"github.com/c-kuroki/alien_invasion/pkg/adapters/renderer/renderertest"
+)
+
+// TestSVGRenderer runs the golden file suite against the SVG renderer
+func TestSVGRenderer(t *testing.T) {
+	renderertest.Run(t, renderer.NewSVGRenderer(), "testdata")
+}
+
+// TestTimedRenderer checks that measuring renders does not change their output
+func TestTimedRenderer(t *testing.T) {
+	var observed int
+	rnd := renderer.NewTimedRenderer(renderer.NewSVGRenderer(), func(string, time.Duration) { observed++ })
+	renderertest.Run(t, rnd, "testdata")
+	if observed == 0 {
+		t.Error("renders were not observed")
+	}
+}
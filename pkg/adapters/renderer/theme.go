@@ -0,0 +1,271 @@
+package renderer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+var ErrUnknownTheme = errors.New("unknown theme")
+
+// Theme presets
+const (
+	ThemeLight        = "light"
+	ThemeDark         = "dark"
+	ThemeHighContrast = "high-contrast"
+)
+
+// Palette holds renderer colors as hex strings (e.g. #283f93)
+type Palette struct {
+	Background string `json:"background" yaml:"background"`
+	City       string `json:"city" yaml:"city"`
+	Road       string `json:"road" yaml:"road"`
+	CityLabel  string `json:"city_label" yaml:"city_label"`
+	Alien      string `json:"alien" yaml:"alien"`
+	Fight      string `json:"fight" yaml:"fight"`
+	AlienLabel string `json:"alien_label" yaml:"alien_label"`
+	Destroyed  string `json:"destroyed" yaml:"destroyed"`
+	Legend     string `json:"legend" yaml:"legend"`
+	// heatmap overlays interpolate from HeatCold to HeatHot, cities without a value (e.g. never
+	// destroyed on time_to_destruction) are HeatNone
+	HeatCold string `json:"heat_cold" yaml:"heat_cold"`
+	HeatHot  string `json:"heat_hot" yaml:"heat_hot"`
+	HeatNone string `json:"heat_none" yaml:"heat_none"`
+}
+
+// Fonts holds font family and sizes in pixels
+type Fonts struct {
+	Family     string `json:"family" yaml:"family"`
+	CityLabel  int    `json:"city_label" yaml:"city_label"`
+	AlienLabel int    `json:"alien_label" yaml:"alien_label"`
+	Legend     int    `json:"legend" yaml:"legend"`
+}
+
+// Sizes holds canvas and elements sizes in pixels
+type Sizes struct {
+	Width  int `json:"width" yaml:"width"`
+	Height int `json:"height" yaml:"height"`
+	// City is the size of the cell used by each city
+	City int `json:"city" yaml:"city"`
+	// CityRadius is the radius of the circle drawn for each city
+	CityRadius  int `json:"city_radius" yaml:"city_radius"`
+	Road        int `json:"road" yaml:"road"`
+	AlienRadius int `json:"alien_radius" yaml:"alien_radius"`
+}
+
+// Labels selects which labels are displayed
+type Labels struct {
+	CityNames bool `json:"city_names" yaml:"city_names"`
+	AlienIDs  bool `json:"alien_ids" yaml:"alien_ids"`
+	Legend    bool `json:"legend" yaml:"legend"`
+}
+
+// Theme defines the look of rendered maps
+type Theme struct {
+	Name    string  `json:"name" yaml:"name"`
+	Palette Palette `json:"palette" yaml:"palette"`
+	Fonts   Fonts   `json:"fonts" yaml:"fonts"`
+	Sizes   Sizes   `json:"sizes" yaml:"sizes"`
+	Labels  Labels  `json:"labels" yaml:"labels"`
+}
+
+func NewLightTheme() *Theme {
+	return &Theme{
+		Name: ThemeLight,
+		Palette: Palette{
+			Background: "#ffffff",
+			City:       "#283f93",
+			Road:       "#283f93",
+			CityLabel:  "#ffffff",
+			Alien:      "#19e822",
+			Fight:      "#e81922",
+			AlienLabel: "#000000",
+			Destroyed:  "#9a9a9a",
+			Legend:     "#000000",
+			HeatCold:   "#283f93",
+			HeatHot:    "#e81922",
+			HeatNone:   "#8c8c8c",
+		},
+		Fonts: Fonts{
+			Family:     "helvetica",
+			CityLabel:  16,
+			AlienLabel: 12,
+			Legend:     12,
+		},
+		Sizes: Sizes{
+			Width:       800,
+			Height:      800,
+			City:        120,
+			CityRadius:  40,
+			Road:        10,
+			AlienRadius: 12,
+		},
+		Labels: Labels{
+			CityNames: true,
+			AlienIDs:  true,
+			Legend:    true,
+		},
+	}
+}
+
+func NewDarkTheme() *Theme {
+	theme := NewLightTheme()
+	theme.Name = ThemeDark
+	theme.Palette = Palette{
+		Background: "#1e1e2e",
+		City:       "#5b6ee1",
+		Road:       "#45475a",
+		CityLabel:  "#f5f5f5",
+		Alien:      "#a6e3a1",
+		Fight:      "#f38ba8",
+		AlienLabel: "#11111b",
+		Destroyed:  "#585b70",
+		Legend:     "#f5f5f5",
+		HeatCold:   "#313244",
+		HeatHot:    "#fab387",
+		HeatNone:   "#6c7086",
+	}
+	return theme
+}
+
+// NewHighContrastTheme returns a theme using a colour-blind safe palette (Okabe-Ito) and bigger labels
+func NewHighContrastTheme() *Theme {
+	theme := NewLightTheme()
+	theme.Name = ThemeHighContrast
+	theme.Palette = Palette{
+		Background: "#ffffff",
+		City:       "#0072b2",
+		Road:       "#000000",
+		CityLabel:  "#ffffff",
+		Alien:      "#e69f00",
+		Fight:      "#d55e00",
+		AlienLabel: "#000000",
+		Destroyed:  "#000000",
+		Legend:     "#000000",
+		HeatCold:   "#56b4e9",
+		HeatHot:    "#d55e00",
+		HeatNone:   "#999999",
+	}
+	theme.Fonts.CityLabel = 18
+	theme.Fonts.AlienLabel = 14
+	theme.Fonts.Legend = 14
+	return theme
+}
+
+// LoadTheme loads a theme from a JSON or YAML file (by extension),
+// missing values are taken from the light theme
+func LoadTheme(filename string) (*Theme, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	theme := NewLightTheme()
+	// the name is not a default, themes without one are named after their file
+	theme.Name = ""
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".json":
+		err = json.Unmarshal(data, theme)
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, theme)
+	default:
+		return nil, fmt.Errorf("invalid theme file extension [%s] (should be .json, .yaml or .yml)", filepath.Ext(filename))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parsing theme %s : %w", filename, err)
+	}
+	if theme.Name == "" {
+		theme.Name = strings.TrimSuffix(filepath.Base(filename), filepath.Ext(filename))
+	}
+	if err := theme.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid theme %s : %w", filename, err)
+	}
+	return theme, nil
+}
+
+// fontFamilyRe matches font family lists safe to write on style attributes (e.g. "dejavu sans, arial")
+var fontFamilyRe = regexp.MustCompile(`^[A-Za-z0-9 ,-]+$`)
+
+// Validate checks that a loaded theme can be rendered, its values are written as they are on
+// SVG style attributes. Preset names can not be used, as loaded themes would replace them
+func (t *Theme) Validate() error {
+	switch t.Name {
+	case ThemeLight, ThemeDark, ThemeHighContrast:
+		return fmt.Errorf("name [%s] is a preset theme", t.Name)
+	}
+	colors := []struct{ name, value string }{
+		{"background", t.Palette.Background},
+		{"city", t.Palette.City},
+		{"road", t.Palette.Road},
+		{"city_label", t.Palette.CityLabel},
+		{"alien", t.Palette.Alien},
+		{"fight", t.Palette.Fight},
+		{"alien_label", t.Palette.AlienLabel},
+		{"destroyed", t.Palette.Destroyed},
+		{"legend", t.Palette.Legend},
+		{"heat_cold", t.Palette.HeatCold},
+		{"heat_hot", t.Palette.HeatHot},
+		{"heat_none", t.Palette.HeatNone},
+	}
+	for _, color := range colors {
+		if _, _, _, err := parseHexColor(color.value); err != nil {
+			return fmt.Errorf("palette %s: %w", color.name, err)
+		}
+	}
+	if !fontFamilyRe.MatchString(t.Fonts.Family) {
+		return fmt.Errorf("invalid font family [%s] (letters, digits, spaces, commas and hyphens only)", t.Fonts.Family)
+	}
+	sizes := []struct {
+		name  string
+		value int
+	}{
+		{"fonts city_label", t.Fonts.CityLabel},
+		{"fonts alien_label", t.Fonts.AlienLabel},
+		{"fonts legend", t.Fonts.Legend},
+		{"sizes width", t.Sizes.Width},
+		{"sizes height", t.Sizes.Height},
+		{"sizes city", t.Sizes.City},
+		{"sizes city_radius", t.Sizes.CityRadius},
+		{"sizes road", t.Sizes.Road},
+		{"sizes alien_radius", t.Sizes.AlienRadius},
+	}
+	for _, size := range sizes {
+		if size.value <= 0 {
+			return fmt.Errorf("%s should be positive, got %d", size.name, size.value)
+		}
+	}
+	return nil
+}
+
+// fill returns a SVG fill style for a color
+func fill(color string) string {
+	return "fill:" + color
+}
+
+// font returns a SVG text style
+func (t *Theme) font(size int, color string, anchor string) string {
+	style := fmt.Sprintf("font-size:%dpx;font-family:%s;fill:%s", size, t.Fonts.Family, color)
+	if anchor != "" {
+		style = "text-anchor:" + anchor + ";" + style
+	}
+	return style
+}
+
+type themeKey struct{}
+
+// WithTheme returns a context that selects a theme by name for a render call
+func WithTheme(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, themeKey{}, name)
+}
+
+// themeFromContext returns the theme name selected on context, if any
+func themeFromContext(ctx context.Context) string {
+	name, _ := ctx.Value(themeKey{}).(string)
+	return name
+}
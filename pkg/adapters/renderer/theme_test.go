@@ -0,0 +1,105 @@
+package renderer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ThemeTestSuite struct {
+	suite.Suite
+	dir string
+}
+
+func (suite *ThemeTestSuite) SetupTest() {
+	suite.dir = suite.T().TempDir()
+}
+
+// load writes a theme file and loads it
+func (suite *ThemeTestSuite) load(filename, content string) (*Theme, error) {
+	filename = filepath.Join(suite.dir, filename)
+	suite.Require().NoError(os.WriteFile(filename, []byte(content), 0o600))
+	return LoadTheme(filename)
+}
+
+func (suite *ThemeTestSuite) TestLoadJSON() {
+	theme, err := suite.load("ocean.json", `{"palette": {"background": "#001f3f", "city": "#7FDBFF"}, "fonts": {"family": "dejavu sans, arial"}}`)
+	suite.Require().NoError(err)
+	suite.Assert().Equal("ocean", theme.Name)
+	suite.Assert().Equal("#001f3f", theme.Palette.Background)
+	suite.Assert().Equal("#7FDBFF", theme.Palette.City)
+	suite.Assert().Equal("dejavu sans, arial", theme.Fonts.Family)
+	// missing values are taken from the light theme
+	light := NewLightTheme()
+	suite.Assert().Equal(light.Palette.Road, theme.Palette.Road)
+	suite.Assert().Equal(light.Sizes, theme.Sizes)
+	suite.Assert().Equal(light.Labels, theme.Labels)
+}
+
+func (suite *ThemeTestSuite) TestLoadYAML() {
+	content := `name: print
+palette:
+  background: "#fafafa"
+sizes:
+  width: 1024
+labels:
+  alien_ids: false
+`
+	for _, filename := range []string{"print.yaml", "print.yml"} {
+		theme, err := suite.load(filename, content)
+		suite.Require().NoError(err, filename)
+		suite.Assert().Equal("print", theme.Name)
+		suite.Assert().Equal("#fafafa", theme.Palette.Background)
+		suite.Assert().Equal(1024, theme.Sizes.Width)
+		suite.Assert().Equal(NewLightTheme().Sizes.Height, theme.Sizes.Height)
+		suite.Assert().False(theme.Labels.AlienIDs)
+		suite.Assert().True(theme.Labels.CityNames)
+	}
+}
+
+func (suite *ThemeTestSuite) TestPresets() {
+	for _, theme := range []*Theme{NewLightTheme(), NewDarkTheme(), NewHighContrastTheme()} {
+		// presets are valid but for their reserved names
+		suite.Assert().EqualError(theme.Validate(), "name ["+theme.Name+"] is a preset theme")
+		theme.Name = "custom"
+		suite.Assert().NoError(theme.Validate())
+	}
+}
+
+func (suite *ThemeTestSuite) TestErrors() {
+	testCases := []struct {
+		name     string
+		filename string
+		content  string
+		err      string
+	}{
+		{"extension", "theme.toml", ``, "invalid theme file extension"},
+		{"json syntax", "theme.json", `{"palette": `, "parsing theme"},
+		{"yaml syntax", "theme.yaml", "palette: [", "parsing theme"},
+		{"preset file name", "light.yaml", "palette:\n  city: \"#000000\"\n", "name [light] is a preset theme"},
+		{"preset name", "theme.json", `{"name": "dark"}`, "name [dark] is a preset theme"},
+		{"short color", "theme.json", `{"palette": {"city": "#fff"}}`, "palette city: invalid color [#fff]"},
+		{"named color", "theme.json", `{"palette": {"road": "red"}}`, "palette road: invalid color [red]"},
+		{"color injection", "theme.json", `{"palette": {"background": "#ffffff;stroke:red"}}`, "palette background: invalid color"},
+		{"invalid hex", "theme.json", `{"palette": {"heat_hot": "#12345g"}}`, "palette heat_hot: invalid color"},
+		{"font injection", "theme.json", `{"fonts": {"family": "arial\" onload=\"alert(1)"}}`, "invalid font family"},
+		{"font style", "theme.json", `{"fonts": {"family": "arial;fill:red"}}`, "invalid font family"},
+		{"empty font", "theme.json", `{"fonts": {"family": ""}}`, "invalid font family"},
+		{"zero font size", "theme.json", `{"fonts": {"legend": 0}}`, "fonts legend should be positive, got 0"},
+		{"negative size", "theme.json", `{"sizes": {"city_radius": -4}}`, "sizes city_radius should be positive, got -4"},
+	}
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			_, err := suite.load(tc.filename, tc.content)
+			suite.Require().Error(err)
+			suite.Assert().Contains(err.Error(), tc.err)
+		})
+	}
+}
+
+// TestThemeTestSuite is the entry point of this test suite
+func TestThemeTestSuite(t *testing.T) {
+	suite.Run(t, new(ThemeTestSuite))
+}
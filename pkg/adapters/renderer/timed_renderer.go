@@ -0,0 +1,41 @@
+package renderer
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/c-kuroki/alien_invasion/pkg/model"
+)
+
+// Rendered methods reported by TimedRenderer
+const (
+	MethodRender        = "render"
+	MethodRenderOverlay = "render_overlay"
+)
+
+// TimedRenderer wraps a renderer reporting how long each render takes (e.g. to a metrics histogram)
+type TimedRenderer struct {
+	next    Adapter
+	observe func(method string, d time.Duration)
+}
+
+var _ Adapter = (*TimedRenderer)(nil)
+
+func NewTimedRenderer(next Adapter, observe func(method string, d time.Duration)) *TimedRenderer {
+	return &TimedRenderer{next: next, observe: observe}
+}
+
+func (r *TimedRenderer) Render(ctx context.Context, cities []*model.City, aliens map[int]map[int]*model.Alien, w io.Writer) error {
+	defer r.timed(MethodRender, time.Now())
+	return r.next.Render(ctx, cities, aliens, w)
+}
+
+func (r *TimedRenderer) RenderOverlay(ctx context.Context, cities []*model.City, stats *model.Stats, overlay Overlay, w io.Writer) error {
+	defer r.timed(MethodRenderOverlay, time.Now())
+	return r.next.RenderOverlay(ctx, cities, stats, overlay, w)
+}
+
+func (r *TimedRenderer) timed(method string, start time.Time) {
+	r.observe(method, time.Since(start))
+}
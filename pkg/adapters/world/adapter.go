@@ -1,6 +1,8 @@
 package world
 
 import (
+	"errors"
+
 	"github.com/c-kuroki/alien_invasion/pkg/model"
 )
 
@@ -13,6 +15,10 @@ type Adapter interface {
 	GetHeight() int
 	GetAllCities() []*model.City
 	GetAliens() map[int]*model.Alien
+	GetNumAliens() int
+	// ForEachAlien calls fn with every alien in ID order, stopping on the first error. fn may
+	// move the alien it is called with
+	ForEachAlien(fn func(*model.Alien) error) error
 	GetCityByID(ID int) (*model.City, error)
 	GetCityByName(name string) (*model.City, error)
 	GetExits(cityID int) ([]int, error)
@@ -26,3 +32,22 @@ type Adapter interface {
 	Load() error
 	Save(string) error
 }
+
+// Committer is implemented by persistent world adapters, the simulation commits the state after
+// each tick so it can be resumed from the last committed tick
+type Committer interface {
+	Commit(tick int) error
+	// Committed returns the last committed tick, false if none
+	Committed() (int, bool)
+}
+
+// TimeTraveler is implemented by world adapters that keep their history, so the world can be
+// inspected as it was after any committed tick
+type TimeTraveler interface {
+	StateAt(tick int) (Adapter, error)
+}
+
+// IsNotFound reports whether an error of an adapter means the city or alien does not exist
+func IsNotFound(err error) bool {
+	return errors.Is(err, notFoundErr)
+}
@@ -0,0 +1,49 @@
+package world_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"go.uber.org/zap"
+
+	"github.com/c-kuroki/alien_invasion/pkg/adapters/world"
+	"github.com/c-kuroki/alien_invasion/pkg/adapters/world/worldtest"
+)
+
+// TestInMemoryState runs the conformance suite against the in memory adapter
+func TestInMemoryState(t *testing.T) {
+	worldtest.Run(t, func(t *testing.T, mapFile string) world.Adapter {
+		return world.NewInMemoryState(mapFile)
+	})
+}
+
+// TestBoltState runs the conformance suite against the bbolt adapter
+func TestBoltState(t *testing.T) {
+	worldtest.Run(t, func(t *testing.T, mapFile string) world.Adapter {
+		st, err := world.NewBoltState(filepath.Join(t.TempDir(), "world.db"), mapFile, zap.NewNop().Sugar())
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { st.Close() })
+		return st
+	})
+}
+
+// TestEventSourcedState runs the conformance suite against the event sourced adapter
+func TestEventSourcedState(t *testing.T) {
+	worldtest.Run(t, func(t *testing.T, mapFile string) world.Adapter {
+		return world.NewEventSourcedState(world.NewInMemoryState(mapFile), 2)
+	})
+}
+
+// TestExampleMap checks that the conformance suite map is the example map
+func TestExampleMap(t *testing.T) {
+	content, err := os.ReadFile("../../../examples/world.map")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(content) != worldtest.ExampleMap {
+		t.Errorf("worldtest.ExampleMap differs from examples/world.map")
+	}
+}
@@ -0,0 +1,126 @@
+package world
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+)
+
+// benchSizes are the number of cities of the benchmark maps, square grids with every road
+var benchSizes = []int{100, 10_000, 1_000_000}
+
+// benchMaps caches the generated maps by number of cities
+var benchMaps = map[int][]byte{}
+
+// benchMap returns a square grid map of numCities cities, every city connected to its neighbours
+func benchMap(numCities int) []byte {
+	if content, ok := benchMaps[numCities]; ok {
+		return content
+	}
+	side := int(math.Sqrt(float64(numCities)))
+	name := func(x, y int) string {
+		return fmt.Sprintf("C%d-%d", x, y)
+	}
+	var b strings.Builder
+	for y := 0; y < side; y++ {
+		for x := 0; x < side; x++ {
+			b.WriteString(name(x, y))
+			if y > 0 {
+				b.WriteString(" north=" + name(x, y-1))
+			}
+			if x < side-1 {
+				b.WriteString(" east=" + name(x+1, y))
+			}
+			if y < side-1 {
+				b.WriteString(" south=" + name(x, y+1))
+			}
+			if x > 0 {
+				b.WriteString(" west=" + name(x-1, y))
+			}
+			b.WriteString("\n")
+		}
+	}
+	benchMaps[numCities] = []byte(b.String())
+	return benchMaps[numCities]
+}
+
+// benchRun runs a benchmark per map size, the largest ones are skipped with -short
+func benchRun(b *testing.B, bench func(b *testing.B, numCities int)) {
+	for _, numCities := range benchSizes {
+		b.Run(fmt.Sprintf("cities=%d", numCities), func(b *testing.B) {
+			if testing.Short() && numCities > 10_000 {
+				b.Skip("skipping large map on short mode")
+			}
+			bench(b, numCities)
+		})
+	}
+}
+
+func benchLoad(b *testing.B, numCities int) *InMemoryState {
+	st := NewInMemoryStateFromReader(bytes.NewReader(benchMap(numCities)))
+	if err := st.Load(); err != nil {
+		b.Fatal(err)
+	}
+	return st
+}
+
+func BenchmarkLoad(b *testing.B) {
+	benchRun(b, func(b *testing.B, numCities int) {
+		content := benchMap(numCities)
+		b.SetBytes(int64(len(content)))
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			if err := NewInMemoryStateFromReader(bytes.NewReader(content)).Load(); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkSetCoordinates(b *testing.B) {
+	benchRun(b, func(b *testing.B, numCities int) {
+		st := benchLoad(b, numCities)
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			if err := st.setCoordinates(); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkGetExits(b *testing.B) {
+	benchRun(b, func(b *testing.B, numCities int) {
+		st := benchLoad(b, numCities)
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			if _, err := st.GetExits(i % numCities); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkRemoveCity(b *testing.B) {
+	benchRun(b, func(b *testing.B, numCities int) {
+		st := benchLoad(b, numCities)
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			// the map is loaded again once every city is removed
+			if i > 0 && i%numCities == 0 {
+				b.StopTimer()
+				st = benchLoad(b, numCities)
+				b.StartTimer()
+			}
+			if err := st.RemoveCity(i % numCities); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
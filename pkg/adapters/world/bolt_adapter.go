@@ -0,0 +1,890 @@
+package world
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+
+	"github.com/c-kuroki/alien_invasion/pkg/logger"
+	"github.com/c-kuroki/alien_invasion/pkg/model"
+)
+
+// check that interfaces are implemented
+var (
+	_ Adapter   = (*BoltState)(nil)
+	_ Committer = (*BoltState)(nil)
+)
+
+// buckets
+var (
+	citiesBucket = []byte("cities")
+	// city name to ID
+	namesBucket  = []byte("names")
+	aliensBucket = []byte("aliens")
+	// a nested bucket per city with the IDs of its aliens
+	cityAliensBucket = []byte("city_aliens")
+	metaBucket       = []byte("meta")
+	// relative positions of the cities while loading, by city ID and by position
+	positionsBucket = []byte("positions")
+	placesBucket    = []byte("places")
+)
+
+// loadBatch is the number of cities written by each transaction while loading a map, and the
+// number of aliens read at once by ForEachAlien
+const loadBatch = 10000
+
+var (
+	widthKey  = []byte("width")
+	heightKey = []byte("height")
+	tickKey   = []byte("tick")
+	// number of cities and aliens, so they are not counted on every tick
+	numCitiesKey = []byte("num_cities")
+	numAliensKey = []byte("num_aliens")
+	// sha256 of the loaded map file
+	mapHashKey = []byte("map_hash")
+)
+
+// mapMismatchErr is returned by Load when the database holds a world built from another map file
+var mapMismatchErr = errors.New("database holds the world of another map file")
+
+// BoltState stores the world state on a bbolt database file. Changes are made on a write
+// transaction which is committed once per tick (see Commit), so after a crash the world is
+// found as it was at the last committed tick
+type BoltState struct {
+	db *bolt.DB
+	// map file loaded when the database holds no world
+	mapFilename string
+	// mu protects tx, bolt transactions can not be used concurrently
+	mu sync.Mutex
+	// tx is the write transaction of uncommitted changes, nil if there are none
+	tx  *bolt.Tx
+	log logger.Logger
+}
+
+// NewBoltState opens (or creates) a database file, the map file is loaded on Load unless the
+// database already holds a world. Errors of the methods returning none are logged
+func NewBoltState(dbFilename, mapFilename string, log logger.Logger) (*BoltState, error) {
+	db, err := bolt.Open(dbFilename, 0o600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, err
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		return createBuckets(tx)
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &BoltState{db: db, mapFilename: mapFilename, log: log}, nil
+}
+
+// Close discards uncommitted changes and closes the database
+func (st *BoltState) Close() error {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	if st.tx != nil {
+		_ = st.tx.Rollback()
+		st.tx = nil
+	}
+	return st.db.Close()
+}
+
+// Commit persists the changes made since the last commit as the state of a tick
+func (st *BoltState) Commit(tick int) error {
+	err := st.update(func(tx *bolt.Tx) error {
+		return tx.Bucket(metaBucket).Put(tickKey, itob(tick))
+	})
+	if err != nil {
+		return err
+	}
+	return st.commit()
+}
+
+// commit commits the pending write transaction, if any
+func (st *BoltState) commit() error {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	if st.tx == nil {
+		return nil
+	}
+	err := st.tx.Commit()
+	st.tx = nil
+	return err
+}
+
+// Committed returns the last committed tick, false if no tick was committed
+func (st *BoltState) Committed() (int, bool) {
+	var tick int
+	var ok bool
+	st.logError("getting committed tick", st.view(func(tx *bolt.Tx) error {
+		if v := tx.Bucket(metaBucket).Get(tickKey); v != nil {
+			tick, ok = btoi(v), true
+		}
+		return nil
+	}))
+	return tick, ok
+}
+
+// Load streams the map file into the database if it holds no world yet. Cities are validated
+// and placed on the database, loadBatch cities per transaction, so the map is never held in memory.
+// A world already held must have been built from the same map file
+func (st *BoltState) Load() error {
+	// the size of the map is written last, without it the database holds no world or a partial one
+	if st.GetWidth() > 0 {
+		return st.checkMap()
+	}
+	if err := st.reset(); err != nil {
+		return err
+	}
+	if err := st.load(); err != nil {
+		// a failed load leaves no partial world behind
+		st.logError("removing partial world", st.reset())
+		return err
+	}
+	return nil
+}
+
+// checkMap returns an error if the world held was not built from the map file
+func (st *BoltState) checkMap() error {
+	hash, err := hashFile(st.mapFilename)
+	if err != nil {
+		return err
+	}
+	var stored []byte
+	err = st.view(func(tx *bolt.Tx) error {
+		stored = append(stored, tx.Bucket(metaBucket).Get(mapHashKey)...)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(stored, hash) {
+		return fmt.Errorf("%w: %s", mapMismatchErr, st.mapFilename)
+	}
+	return nil
+}
+
+// hashFile returns the sha256 of a file
+func hashFile(filename string) ([]byte, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return nil, err
+	}
+	return hash.Sum(nil), nil
+}
+
+func (st *BoltState) load() error {
+	file, err := os.Open(st.mapFilename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	// scan line by line, as the in memory state does, hashing the map as it is read
+	var lineNum uint64
+	var numCities int
+	hash := sha256.New()
+	scanner := bufio.NewScanner(io.TeeReader(file, hash))
+	for scanner.Scan() {
+		lineNum++
+		// blank lines are ignored
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+		fields, err := parseLine(lineNum, scanner.Text())
+		if err != nil {
+			return &LoadError{Line: lineNum, Err: err}
+		}
+		if err := st.AddCity(fields...); err != nil {
+			return &LoadError{Line: lineNum, Err: err}
+		}
+		numCities++
+		if numCities%loadBatch == 0 {
+			if err := st.commit(); err != nil {
+				return err
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return &LoadError{Line: lineNum + 1, Err: err}
+	}
+	err = st.update(func(tx *bolt.Tx) error {
+		return tx.Bucket(metaBucket).Put(mapHashKey, hash.Sum(nil))
+	})
+	if err != nil {
+		return err
+	}
+	if err := st.commit(); err != nil {
+		return err
+	}
+	err = st.eachCity(func(tx *bolt.Tx, city *model.City) error {
+		return validateRoads(city, func(name string) (*model.City, error) {
+			return getCityByName(tx, name)
+		})
+	})
+	if err != nil {
+		return &LoadError{Err: err}
+	}
+	if err := st.setCoordinates(numCities); err != nil {
+		return &LoadError{Err: err}
+	}
+	return nil
+}
+
+// setCoordinates walks the world breadth first from the first city, as the in memory state does,
+// keeping the relative positions on temporary buckets until the size of the map is known
+func (st *BoltState) setCoordinates(numCities int) error {
+	err := st.update(func(tx *bolt.Tx) error {
+		for _, name := range [][]byte{positionsBucket, placesBucket} {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return err
+			}
+		}
+		if numCities == 0 {
+			return nil
+		}
+		first, err := getCity(tx, 0)
+		if err != nil {
+			return err
+		}
+		return placeCity(tx, first, 0, 0)
+	})
+	if err != nil {
+		return err
+	}
+	var minX, maxX, minY, maxY int
+	placed, walked := 1, 0
+	queue := []int{0}
+	if numCities == 0 {
+		placed, queue = 0, nil
+	}
+	for len(queue) > 0 {
+		cityID := queue[0]
+		queue = queue[1:]
+		err := st.update(func(tx *bolt.Tx) error {
+			city, err := getCity(tx, cityID)
+			if err != nil {
+				return err
+			}
+			x, y := getPosition(tx, cityID)
+			for direction := 0; direction < 4; direction++ {
+				name := roadTo(city, direction)
+				if name == "" {
+					continue
+				}
+				next, err := getCityByName(tx, name)
+				if err != nil {
+					return err
+				}
+				nextX, nextY := x, y
+				switch direction {
+				case 0:
+					nextY--
+				case 1:
+					nextX++
+				case 2:
+					nextY++
+				case 3:
+					nextX--
+				}
+				if tx.Bucket(positionsBucket).Get(itob(next.ID)) != nil {
+					// loops of roads must lead back to the same place
+					if px, py := getPosition(tx, next.ID); px != nextX || py != nextY {
+						return fmt.Errorf("%w: roads from %s to %s do not match their positions", invalidMapErr, city.Name, next.Name)
+					}
+					continue
+				}
+				if err := placeCity(tx, next, nextX, nextY); err != nil {
+					return err
+				}
+				placed++
+				queue = append(queue, next.ID)
+				if nextX < minX {
+					minX = nextX
+				}
+				if nextX > maxX {
+					maxX = nextX
+				}
+				if nextY < minY {
+					minY = nextY
+				}
+				if nextY > maxY {
+					maxY = nextY
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		walked++
+		if walked%loadBatch == 0 {
+			if err := st.commit(); err != nil {
+				return err
+			}
+		}
+	}
+	// unreachable (isolated) cities make the map invalid
+	if placed != numCities {
+		return invalidMapErr
+	}
+	// set absolute positions, then the size of the map
+	err = st.eachCity(func(tx *bolt.Tx, city *model.City) error {
+		x, y := getPosition(tx, city.ID)
+		city.X, city.Y = x-minX, y-minY
+		return putCity(tx, city)
+	})
+	if err != nil {
+		return err
+	}
+	err = st.update(func(tx *bolt.Tx) error {
+		for _, name := range [][]byte{positionsBucket, placesBucket} {
+			if err := tx.DeleteBucket(name); err != nil {
+				return err
+			}
+		}
+		meta := tx.Bucket(metaBucket)
+		if err := meta.Put(widthKey, itob(maxX-minX+1)); err != nil {
+			return err
+		}
+		return meta.Put(heightKey, itob(maxY-minY+1))
+	})
+	if err != nil {
+		return err
+	}
+	// the loaded world is kept even if no tick is committed
+	return st.commit()
+}
+
+// eachCity calls fn with every city in ID order, loadBatch cities per committed transaction, fn
+// may change the city on the transaction it is called with
+func (st *BoltState) eachCity(fn func(*bolt.Tx, *model.City) error) error {
+	next := itob(0)
+	for next != nil {
+		var cities []*model.City
+		err := st.view(func(tx *bolt.Tx) error {
+			c := tx.Bucket(citiesBucket).Cursor()
+			k, v := c.Seek(next)
+			next = nil
+			for ; k != nil; k, v = c.Next() {
+				if len(cities) == loadBatch {
+					// keys are only valid during the transaction
+					next = append([]byte(nil), k...)
+					return nil
+				}
+				city := &model.City{}
+				if err := json.Unmarshal(v, city); err != nil {
+					return err
+				}
+				cities = append(cities, city)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		err = st.update(func(tx *bolt.Tx) error {
+			for _, city := range cities {
+				if err := fn(tx, city); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		if err := st.commit(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reset empties the database, discarding uncommitted changes
+func (st *BoltState) reset() error {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	if st.tx != nil {
+		_ = st.tx.Rollback()
+		st.tx = nil
+	}
+	return st.db.Update(func(tx *bolt.Tx) error {
+		for _, name := range [][]byte{citiesBucket, namesBucket, aliensBucket, cityAliensBucket, metaBucket, positionsBucket, placesBucket} {
+			if tx.Bucket(name) == nil {
+				continue
+			}
+			if err := tx.DeleteBucket(name); err != nil {
+				return err
+			}
+		}
+		return createBuckets(tx)
+	})
+}
+
+func (st *BoltState) Save(filename string) error {
+	return saveCities(filename, st.GetAllCities())
+}
+
+func (st *BoltState) GetNumCities() int {
+	return st.getMeta("counting cities", numCitiesKey)
+}
+
+func (st *BoltState) GetNumAliens() int {
+	return st.getMeta("counting aliens", numAliensKey)
+}
+
+func (st *BoltState) GetWidth() int {
+	return st.getMeta("getting map size", widthKey)
+}
+
+func (st *BoltState) GetHeight() int {
+	return st.getMeta("getting map size", heightKey)
+}
+
+func (st *BoltState) getMeta(msg string, key []byte) int {
+	var v int
+	st.logError(msg, st.view(func(tx *bolt.Tx) error {
+		if b := tx.Bucket(metaBucket).Get(key); b != nil {
+			v = btoi(b)
+		}
+		return nil
+	}))
+	return v
+}
+
+func (st *BoltState) GetAllCities() []*model.City {
+	var cities []*model.City
+	st.logError("getting cities", st.view(func(tx *bolt.Tx) error {
+		return tx.Bucket(citiesBucket).ForEach(func(k, v []byte) error {
+			city := &model.City{}
+			if err := json.Unmarshal(v, city); err != nil {
+				return err
+			}
+			cities = append(cities, city)
+			return nil
+		})
+	}))
+	return cities
+}
+
+func (st *BoltState) GetAliens() map[int]*model.Alien {
+	aliens := make(map[int]*model.Alien)
+	st.logError("getting aliens", st.ForEachAlien(func(alien *model.Alien) error {
+		aliens[alien.ID] = alien
+		return nil
+	}))
+	return aliens
+}
+
+// ForEachAlien reads loadBatch aliens at a time with a cursor, so fn is called without holding a
+// transaction and can change the world
+func (st *BoltState) ForEachAlien(fn func(*model.Alien) error) error {
+	next := itob(0)
+	for next != nil {
+		var aliens []*model.Alien
+		err := st.view(func(tx *bolt.Tx) error {
+			c := tx.Bucket(aliensBucket).Cursor()
+			k, v := c.Seek(next)
+			next = nil
+			for ; k != nil; k, v = c.Next() {
+				if len(aliens) == loadBatch {
+					// keys are only valid during the transaction
+					next = append([]byte(nil), k...)
+					return nil
+				}
+				alien := &model.Alien{}
+				if err := json.Unmarshal(v, alien); err != nil {
+					return err
+				}
+				aliens = append(aliens, alien)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		for _, alien := range aliens {
+			if err := fn(alien); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (st *BoltState) GetAllAliensByCity() map[int]map[int]*model.Alien {
+	aliensByCity := make(map[int]map[int]*model.Alien)
+	st.logError("getting aliens by city", st.view(func(tx *bolt.Tx) error {
+		return tx.Bucket(cityAliensBucket).ForEach(func(k, _ []byte) error {
+			aliens, err := getCityAliens(tx, btoi(k))
+			if err != nil {
+				return err
+			}
+			aliensByCity[btoi(k)] = aliens
+			return nil
+		})
+	}))
+	return aliensByCity
+}
+
+func (st *BoltState) GetCityByID(cityID int) (*model.City, error) {
+	var city *model.City
+	err := st.view(func(tx *bolt.Tx) error {
+		var err error
+		city, err = getCity(tx, cityID)
+		return err
+	})
+	return city, err
+}
+
+func (st *BoltState) GetCityByName(name string) (*model.City, error) {
+	var city *model.City
+	err := st.view(func(tx *bolt.Tx) error {
+		var err error
+		city, err = getCityByName(tx, name)
+		return err
+	})
+	return city, err
+}
+
+func (st *BoltState) GetAlienByID(alienID int) (*model.Alien, error) {
+	var alien *model.Alien
+	err := st.view(func(tx *bolt.Tx) error {
+		var err error
+		alien, err = getAlien(tx, alienID)
+		return err
+	})
+	return alien, err
+}
+
+func (st *BoltState) GetAliensByCity(cityID int) (map[int]*model.Alien, error) {
+	var aliens map[int]*model.Alien
+	err := st.view(func(tx *bolt.Tx) error {
+		var err error
+		aliens, err = getCityAliens(tx, cityID)
+		return err
+	})
+	return aliens, err
+}
+
+func (st *BoltState) GetExits(cityID int) ([]int, error) {
+	var exits []int
+	err := st.view(func(tx *bolt.Tx) error {
+		city, err := getCity(tx, cityID)
+		if err != nil {
+			return err
+		}
+		for _, name := range []string{city.North, city.East, city.South, city.West} {
+			if name == "" {
+				continue
+			}
+			exit, err := getCityByName(tx, name)
+			if err != nil {
+				return err
+			}
+			exits = append(exits, exit.ID)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if exits == nil {
+		exits = []int{}
+	}
+	return exits, nil
+}
+
+func (st *BoltState) AddAlien(alien *model.Alien) error {
+	return st.update(func(tx *bolt.Tx) error {
+		if tx.Bucket(aliensBucket).Get(itob(alien.ID)) == nil {
+			if err := addMeta(tx, numAliensKey, 1); err != nil {
+				return err
+			}
+		}
+		return putAlien(tx, alien)
+	})
+}
+
+func (st *BoltState) MoveAlien(alienID, cityID int) error {
+	return st.update(func(tx *bolt.Tx) error {
+		alien, err := getAlien(tx, alienID)
+		if err != nil {
+			return err
+		}
+		if source := tx.Bucket(cityAliensBucket).Bucket(itob(alien.City)); source != nil {
+			if err := source.Delete(itob(alienID)); err != nil {
+				return err
+			}
+		}
+		alien.City = cityID
+		alien.Moves++
+		return putAlien(tx, alien)
+	})
+}
+
+func (st *BoltState) AddCity(args ...string) error {
+	if len(args) != 5 {
+		return invalidCityErr
+	}
+	return st.update(func(tx *bolt.Tx) error {
+		names := tx.Bucket(namesBucket)
+		if names.Get([]byte(args[0])) != nil {
+			return duplicatedErr
+		}
+		cities := tx.Bucket(citiesBucket)
+		id, err := cities.NextSequence()
+		if err != nil {
+			return err
+		}
+		city := &model.City{
+			// sequences start at 1
+			ID:    int(id) - 1,
+			Name:  args[0],
+			North: args[1],
+			East:  args[2],
+			South: args[3],
+			West:  args[4],
+		}
+		if err := names.Put([]byte(city.Name), itob(city.ID)); err != nil {
+			return err
+		}
+		if err := addMeta(tx, numCitiesKey, 1); err != nil {
+			return err
+		}
+		return putCity(tx, city)
+	})
+}
+
+func (st *BoltState) RemoveCity(cityID int) error {
+	return st.update(func(tx *bolt.Tx) error {
+		city, err := getCity(tx, cityID)
+		if err != nil {
+			return err
+		}
+		// remove connections from other cities
+		roads := city.Roads()
+		for direction, opposite := range map[string]string{model.North: model.South, model.East: model.West, model.South: model.North, model.West: model.East} {
+			name, ok := roads[direction]
+			if !ok {
+				continue
+			}
+			neighbour, err := getCityByName(tx, name)
+			if err != nil {
+				return err
+			}
+			switch opposite {
+			case model.North:
+				neighbour.North = ""
+			case model.East:
+				neighbour.East = ""
+			case model.South:
+				neighbour.South = ""
+			case model.West:
+				neighbour.West = ""
+			}
+			if err := putCity(tx, neighbour); err != nil {
+				return err
+			}
+		}
+		// remove aliens at the city
+		cityAliens := tx.Bucket(cityAliensBucket)
+		if b := cityAliens.Bucket(itob(cityID)); b != nil {
+			var killed int
+			err := b.ForEach(func(k, _ []byte) error {
+				killed++
+				return tx.Bucket(aliensBucket).Delete(k)
+			})
+			if err != nil {
+				return err
+			}
+			if err := addMeta(tx, numAliensKey, -killed); err != nil {
+				return err
+			}
+			if err := cityAliens.DeleteBucket(itob(cityID)); err != nil {
+				return err
+			}
+		}
+		// remove city
+		if err := tx.Bucket(namesBucket).Delete([]byte(city.Name)); err != nil {
+			return err
+		}
+		if err := addMeta(tx, numCitiesKey, -1); err != nil {
+			return err
+		}
+		return tx.Bucket(citiesBucket).Delete(itob(cityID))
+	})
+}
+
+// view runs fn on the pending write transaction, or on a read transaction if there is none
+func (st *BoltState) view(fn func(*bolt.Tx) error) error {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	if st.tx != nil {
+		return fn(st.tx)
+	}
+	return st.db.View(fn)
+}
+
+// update runs fn on the pending write transaction, beginning it if needed. Changes made by a
+// failed fn are kept, as the in memory state does with partial changes
+func (st *BoltState) update(fn func(*bolt.Tx) error) error {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	if st.tx == nil {
+		tx, err := st.db.Begin(true)
+		if err != nil {
+			return err
+		}
+		st.tx = tx
+	}
+	return fn(st.tx)
+}
+
+// logError logs the error of a method returning none, if any
+func (st *BoltState) logError(msg string, err error) {
+	if err != nil {
+		st.log.Errorw(msg, "error", err.Error())
+	}
+}
+
+// createBuckets creates the buckets of the world if they do not exist
+func createBuckets(tx *bolt.Tx) error {
+	for _, name := range [][]byte{citiesBucket, namesBucket, aliensBucket, cityAliensBucket, metaBucket} {
+		if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addMeta adds delta to a counter of the meta bucket
+func addMeta(tx *bolt.Tx, key []byte, delta int) error {
+	meta := tx.Bucket(metaBucket)
+	return meta.Put(key, itob(btoi(meta.Get(key))+delta))
+}
+
+func getCity(tx *bolt.Tx, cityID int) (*model.City, error) {
+	v := tx.Bucket(citiesBucket).Get(itob(cityID))
+	if v == nil {
+		return nil, notFoundErr
+	}
+	city := &model.City{}
+	return city, json.Unmarshal(v, city)
+}
+
+func getCityByName(tx *bolt.Tx, name string) (*model.City, error) {
+	id := tx.Bucket(namesBucket).Get([]byte(name))
+	if id == nil {
+		return nil, notFoundErr
+	}
+	return getCity(tx, btoi(id))
+}
+
+func putCity(tx *bolt.Tx, city *model.City) error {
+	v, err := json.Marshal(city)
+	if err != nil {
+		return err
+	}
+	return tx.Bucket(citiesBucket).Put(itob(city.ID), v)
+}
+
+func getAlien(tx *bolt.Tx, alienID int) (*model.Alien, error) {
+	v := tx.Bucket(aliensBucket).Get(itob(alienID))
+	if v == nil {
+		return nil, notFoundErr
+	}
+	alien := &model.Alien{}
+	return alien, json.Unmarshal(v, alien)
+}
+
+// putAlien stores an alien and adds it to the aliens of its city
+func putAlien(tx *bolt.Tx, alien *model.Alien) error {
+	v, err := json.Marshal(alien)
+	if err != nil {
+		return err
+	}
+	if err := tx.Bucket(aliensBucket).Put(itob(alien.ID), v); err != nil {
+		return err
+	}
+	cityAliens, err := tx.Bucket(cityAliensBucket).CreateBucketIfNotExists(itob(alien.City))
+	if err != nil {
+		return err
+	}
+	return cityAliens.Put(itob(alien.ID), nil)
+}
+
+// placeCity keeps the relative position of a city while loading, two cities can not be at the same place
+func placeCity(tx *bolt.Tx, city *model.City, x, y int) error {
+	position := append(itob(x), itob(y)...)
+	places := tx.Bucket(placesBucket)
+	if id := places.Get(position); id != nil {
+		other, err := getCity(tx, btoi(id))
+		if err != nil {
+			return err
+		}
+		return fmt.Errorf("%w: %s and %s are at the same position", invalidMapErr, other.Name, city.Name)
+	}
+	if err := places.Put(position, itob(city.ID)); err != nil {
+		return err
+	}
+	return tx.Bucket(positionsBucket).Put(itob(city.ID), position)
+}
+
+// getPosition returns the relative position of a placed city
+func getPosition(tx *bolt.Tx, cityID int) (int, int) {
+	position := tx.Bucket(positionsBucket).Get(itob(cityID))
+	if len(position) != 16 {
+		return 0, 0
+	}
+	return btoi(position[:8]), btoi(position[8:])
+}
+
+// getCityAliens returns the aliens of a city, not found if the city never had aliens
+func getCityAliens(tx *bolt.Tx, cityID int) (map[int]*model.Alien, error) {
+	b := tx.Bucket(cityAliensBucket).Bucket(itob(cityID))
+	if b == nil {
+		return nil, notFoundErr
+	}
+	aliens := make(map[int]*model.Alien)
+	err := b.ForEach(func(k, _ []byte) error {
+		alien, err := getAlien(tx, btoi(k))
+		if err != nil {
+			return err
+		}
+		aliens[alien.ID] = alien
+		return nil
+	})
+	return aliens, err
+}
+
+// itob encodes an int as a big endian key, keeping keys sorted by value
+func itob(v int) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, uint64(v))
+	return b
+}
+
+func btoi(b []byte) int {
+	if len(b) != 8 {
+		return 0
+	}
+	return int(binary.BigEndian.Uint64(b))
+}
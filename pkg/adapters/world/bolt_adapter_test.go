@@ -0,0 +1,171 @@
+package world
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	bolt "go.etcd.io/bbolt"
+	"go.uber.org/zap"
+
+	"github.com/c-kuroki/alien_invasion/pkg/model"
+)
+
+type BoltStateTestSuite struct {
+	suite.Suite
+	dbFile string
+}
+
+func (suite *BoltStateTestSuite) SetupTest() {
+	suite.dbFile = filepath.Join(suite.T().TempDir(), "world.db")
+}
+
+func (suite *BoltStateTestSuite) open() *BoltState {
+	st, err := NewBoltState(suite.dbFile, exampleMapFile, zap.NewNop().Sugar())
+	suite.Require().NoError(err)
+	suite.Require().NoError(st.Load())
+	return st
+}
+
+func (suite *BoltStateTestSuite) TestResume() {
+	st := suite.open()
+	_, ok := st.Committed()
+	suite.Assert().False(ok)
+	for _, alien := range getAliens() {
+		suite.Require().NoError(st.AddAlien(alien))
+	}
+	suite.Require().NoError(st.Commit(0))
+
+	// changes after the last commit are lost when the database is closed
+	suite.Require().NoError(st.RemoveCity(0))
+	suite.Require().NoError(st.Commit(1))
+	suite.Require().NoError(st.MoveAlien(1, 1))
+	suite.Require().NoError(st.Close())
+
+	st = suite.open()
+	defer st.Close()
+	tick, ok := st.Committed()
+	suite.Assert().True(ok)
+	suite.Assert().Equal(1, tick)
+	_, err := st.GetCityByID(0)
+	suite.Assert().Equal(notFoundErr, err)
+	suite.Assert().Equal(4, st.GetNumCities())
+	alien, err := st.GetAlienByID(1)
+	suite.Require().NoError(err)
+	suite.Assert().Equal(&model.Alien{ID: 1, Name: "Zork", City: 2}, alien)
+	// alien 2 was destroyed with its city
+	suite.Assert().Equal(2, len(st.GetAliens()))
+}
+
+func (suite *BoltStateTestSuite) TestAddCity() {
+	st := suite.open()
+	defer st.Close()
+	suite.Require().NoError(st.AddCity("Qux", "", "", "", ""))
+	city, err := st.GetCityByName("Qux")
+	suite.Require().NoError(err)
+	suite.Assert().Equal(5, city.ID)
+	suite.Assert().Equal(duplicatedErr, st.AddCity("Qux", "", "", "", ""))
+}
+
+func (suite *BoltStateTestSuite) TestLoadBatches() {
+	// a grid bigger than a load batch, cities are named by position
+	const width, height = 120, 100
+	var b strings.Builder
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			fmt.Fprintf(&b, "C%d-%d", x, y)
+			if y > 0 {
+				fmt.Fprintf(&b, " north=C%d-%d", x, y-1)
+			}
+			if x < width-1 {
+				fmt.Fprintf(&b, " east=C%d-%d", x+1, y)
+			}
+			if y < height-1 {
+				fmt.Fprintf(&b, " south=C%d-%d", x, y+1)
+			}
+			if x > 0 {
+				fmt.Fprintf(&b, " west=C%d-%d", x-1, y)
+			}
+			b.WriteString("\n")
+		}
+	}
+	suite.Require().Greater(width*height, loadBatch)
+	mapFile := filepath.Join(suite.T().TempDir(), "grid.map")
+	suite.Require().NoError(os.WriteFile(mapFile, []byte(b.String()), 0o600))
+	st, err := NewBoltState(suite.dbFile, mapFile, zap.NewNop().Sugar())
+	suite.Require().NoError(err)
+	defer st.Close()
+	suite.Require().NoError(st.Load())
+
+	suite.Assert().Equal(width*height, st.GetNumCities())
+	suite.Assert().Equal(width, st.GetWidth())
+	suite.Assert().Equal(height, st.GetHeight())
+	city, err := st.GetCityByName("C37-81")
+	suite.Require().NoError(err)
+	suite.Assert().Equal(37, city.X)
+	suite.Assert().Equal(81, city.Y)
+	// the positions are not kept once loaded
+	suite.Require().NoError(st.view(func(tx *bolt.Tx) error {
+		suite.Assert().Nil(tx.Bucket(positionsBucket))
+		suite.Assert().Nil(tx.Bucket(placesBucket))
+		return nil
+	}))
+}
+
+func (suite *BoltStateTestSuite) TestFailedLoad() {
+	mapFile := filepath.Join(suite.T().TempDir(), "world.map")
+	suite.Require().NoError(os.WriteFile(mapFile, []byte("Foo north=Bar\nBar\n"), 0o600))
+	st, err := NewBoltState(suite.dbFile, mapFile, zap.NewNop().Sugar())
+	suite.Require().NoError(err)
+	defer st.Close()
+	suite.Require().Error(st.Load())
+	// no partial world is left, the map is loaded again once fixed
+	suite.Assert().Zero(st.GetNumCities())
+	suite.Require().NoError(os.WriteFile(mapFile, []byte("Foo north=Bar\nBar south=Foo\n"), 0o600))
+	suite.Require().NoError(st.Load())
+	suite.Assert().Equal(2, st.GetNumCities())
+	suite.Assert().Equal(2, st.GetHeight())
+}
+
+func (suite *BoltStateTestSuite) TestCounters() {
+	st := suite.open()
+	suite.Assert().Equal(5, st.GetNumCities())
+	suite.Assert().Zero(st.GetNumAliens())
+	for _, alien := range getAliens() {
+		suite.Require().NoError(st.AddAlien(alien))
+	}
+	suite.Assert().Equal(3, st.GetNumAliens())
+	// moves do not change the counters, a destroyed city takes its aliens
+	suite.Require().NoError(st.MoveAlien(1, 1))
+	suite.Assert().Equal(3, st.GetNumAliens())
+	suite.Require().NoError(st.RemoveCity(0))
+	suite.Assert().Equal(4, st.GetNumCities())
+	suite.Assert().Equal(len(st.GetAliens()), st.GetNumAliens())
+	suite.Require().NoError(st.AddCity("Qux", "", "", "", ""))
+	suite.Assert().Equal(5, st.GetNumCities())
+	suite.Require().NoError(st.Commit(1))
+	suite.Require().NoError(st.Close())
+
+	st = suite.open()
+	defer st.Close()
+	suite.Assert().Equal(len(st.GetAllCities()), st.GetNumCities())
+	suite.Assert().Equal(len(st.GetAliens()), st.GetNumAliens())
+}
+
+func (suite *BoltStateTestSuite) TestOtherMap() {
+	suite.Require().NoError(suite.open().Close())
+	mapFile := filepath.Join(suite.T().TempDir(), "world.map")
+	suite.Require().NoError(os.WriteFile(mapFile, []byte("Foo north=Bar\nBar south=Foo\n"), 0o600))
+	st, err := NewBoltState(suite.dbFile, mapFile, zap.NewNop().Sugar())
+	suite.Require().NoError(err)
+	defer st.Close()
+	suite.Assert().ErrorIs(st.Load(), mapMismatchErr)
+}
+
+// TestBoltStateTestSuite is the entry point of this test suite
+func TestBoltStateTestSuite(t *testing.T) {
+	suite.Run(t, new(BoltStateTestSuite))
+}
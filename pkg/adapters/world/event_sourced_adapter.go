@@ -0,0 +1,235 @@
+package world
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/c-kuroki/alien_invasion/pkg/model"
+)
+
+// check that interfaces are implemented
+var (
+	_ Adapter      = (*EventSourcedState)(nil)
+	_ Committer    = (*EventSourcedState)(nil)
+	_ TimeTraveler = (*EventSourcedState)(nil)
+)
+
+// mutation types
+const (
+	mutationAddCity    = "add_city"
+	mutationAddAlien   = "add_alien"
+	mutationMoveAlien  = "move_alien"
+	mutationRemoveCity = "remove_city"
+)
+
+// mutation is a change of the world made at a tick
+type mutation struct {
+	tick  int
+	kind  string
+	args  []string
+	alien model.Alien
+	// alien and city IDs of moves and removals
+	alienID int
+	cityID  int
+}
+
+// worldSnapshot is a copy of the world after the mutations of a tick
+type worldSnapshot struct {
+	tick int
+	// position of the first mutation after the snapshot
+	next   int
+	cities []model.City
+	aliens []model.Alien
+}
+
+// EventSourcedState keeps an in memory world and an append-only log of its mutations, with a
+// snapshot every few ticks, so the world can be rebuilt as it was at any committed tick.
+// The history grows with every mutation (each spawn, move and destruction) and a copy of the
+// world every few ticks, SetMaxTicks keeps only the last ticks
+type EventSourcedState struct {
+	*InMemoryState
+	// snapshotEvery is the number of ticks between snapshots
+	snapshotEvery int
+	// maxTicks is the number of last committed ticks kept (0 keeps them all)
+	maxTicks  int
+	mutations []mutation
+	snapshots []*worldSnapshot
+	// first is the oldest tick kept
+	first int
+	// tick of the mutations being made, the one after the last commit
+	tick      int
+	committed bool
+}
+
+// NewEventSourcedState records the mutations of a state, taking a snapshot every snapshotEvery ticks
+func NewEventSourcedState(st *InMemoryState, snapshotEvery int) *EventSourcedState {
+	if snapshotEvery < 1 {
+		snapshotEvery = 1
+	}
+	return &EventSourcedState{InMemoryState: st, snapshotEvery: snapshotEvery}
+}
+
+// SetMaxTicks keeps only the history of the last committed ticks (0 keeps every tick), older
+// ticks can not be rebuilt anymore
+func (st *EventSourcedState) SetMaxTicks(ticks int) {
+	st.maxTicks = ticks
+}
+
+// Load loads the world and takes the initial snapshot, previous to any tick
+func (st *EventSourcedState) Load() error {
+	if err := st.InMemoryState.Load(); err != nil {
+		return err
+	}
+	st.mutations = nil
+	st.snapshots = []*worldSnapshot{st.snapshot(-1)}
+	st.first = 0
+	return nil
+}
+
+func (st *EventSourcedState) AddCity(args ...string) error {
+	if err := st.InMemoryState.AddCity(args...); err != nil {
+		return err
+	}
+	st.record(mutation{kind: mutationAddCity, args: append([]string(nil), args...)})
+	return nil
+}
+
+func (st *EventSourcedState) AddAlien(alien *model.Alien) error {
+	if err := st.InMemoryState.AddAlien(alien); err != nil {
+		return err
+	}
+	st.record(mutation{kind: mutationAddAlien, alien: *alien})
+	return nil
+}
+
+func (st *EventSourcedState) MoveAlien(alienID, cityID int) error {
+	if err := st.InMemoryState.MoveAlien(alienID, cityID); err != nil {
+		return err
+	}
+	st.record(mutation{kind: mutationMoveAlien, alienID: alienID, cityID: cityID})
+	return nil
+}
+
+func (st *EventSourcedState) RemoveCity(cityID int) error {
+	if err := st.InMemoryState.RemoveCity(cityID); err != nil {
+		return err
+	}
+	st.record(mutation{kind: mutationRemoveCity, cityID: cityID})
+	return nil
+}
+
+// Commit ends a tick, the next mutations belong to the following tick
+func (st *EventSourcedState) Commit(tick int) error {
+	if tick < st.tick-1 {
+		return fmt.Errorf("tick %d already committed", tick)
+	}
+	if tick%st.snapshotEvery == 0 {
+		st.snapshots = append(st.snapshots, st.snapshot(tick))
+	}
+	st.tick = tick + 1
+	st.committed = true
+	st.prune()
+	return nil
+}
+
+// prune drops the history of the ticks before the last maxTicks, keeping the snapshot the
+// oldest kept tick is rebuilt from and the mutations after it
+func (st *EventSourcedState) prune() {
+	if st.maxTicks < 1 {
+		return
+	}
+	last, _ := st.Committed()
+	if last-st.maxTicks+1 <= st.first {
+		return
+	}
+	st.first = last - st.maxTicks + 1
+	ix := sort.Search(len(st.snapshots), func(i int) bool {
+		return st.snapshots[i].tick > st.first
+	}) - 1
+	if ix < 1 {
+		return
+	}
+	// copied, so the dropped mutations and snapshots are freed
+	dropped := st.snapshots[ix].next
+	st.mutations = append([]mutation(nil), st.mutations[dropped:]...)
+	st.snapshots = append([]*worldSnapshot(nil), st.snapshots[ix:]...)
+	for _, snapshot := range st.snapshots {
+		snapshot.next -= dropped
+	}
+}
+
+// Committed returns the last committed tick, false if no tick was committed
+func (st *EventSourcedState) Committed() (int, bool) {
+	return st.tick - 1, st.committed
+}
+
+// StateAt rebuilds the world as it was after a committed tick, from the closest previous snapshot
+func (st *EventSourcedState) StateAt(tick int) (Adapter, error) {
+	if len(st.snapshots) == 0 {
+		return nil, fmt.Errorf("tick %d not found: world not loaded", tick)
+	}
+	if last, _ := st.Committed(); tick < st.first || !st.committed || tick > last {
+		return nil, fmt.Errorf("tick %d not found: kept ticks are %d to %d", tick, st.first, last)
+	}
+	ix := sort.Search(len(st.snapshots), func(i int) bool {
+		return st.snapshots[i].tick > tick
+	}) - 1
+	snapshot := st.snapshots[ix]
+	cities := make([]*model.City, len(snapshot.cities))
+	for i := range snapshot.cities {
+		city := snapshot.cities[i]
+		cities[i] = &city
+	}
+	aliens := make([]*model.Alien, len(snapshot.aliens))
+	for i := range snapshot.aliens {
+		alien := snapshot.aliens[i]
+		aliens[i] = &alien
+	}
+	state := NewInMemoryStateFromCities(st.GetWidth(), st.GetHeight(), cities, aliens)
+	for _, m := range st.mutations[snapshot.next:] {
+		if m.tick > tick {
+			break
+		}
+		if err := apply(state, m); err != nil {
+			return nil, fmt.Errorf("replaying %s of tick %d: %w", m.kind, m.tick, err)
+		}
+	}
+	return state, nil
+}
+
+func (st *EventSourcedState) record(m mutation) {
+	// mutations made while loading are part of the initial snapshot
+	if st.snapshots == nil {
+		return
+	}
+	m.tick = st.tick
+	st.mutations = append(st.mutations, m)
+}
+
+// snapshot copies the current world
+func (st *EventSourcedState) snapshot(tick int) *worldSnapshot {
+	snapshot := &worldSnapshot{tick: tick, next: len(st.mutations)}
+	for _, city := range st.GetAllCities() {
+		snapshot.cities = append(snapshot.cities, *city)
+	}
+	for _, alien := range st.GetAliens() {
+		snapshot.aliens = append(snapshot.aliens, *alien)
+	}
+	return snapshot
+}
+
+// apply makes a recorded mutation on a state
+func apply(state Adapter, m mutation) error {
+	switch m.kind {
+	case mutationAddCity:
+		return state.AddCity(m.args...)
+	case mutationAddAlien:
+		alien := m.alien
+		return state.AddAlien(&alien)
+	case mutationMoveAlien:
+		return state.MoveAlien(m.alienID, m.cityID)
+	case mutationRemoveCity:
+		return state.RemoveCity(m.cityID)
+	}
+	return fmt.Errorf("invalid mutation %s", m.kind)
+}
@@ -0,0 +1,115 @@
+package world
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/c-kuroki/alien_invasion/pkg/model"
+)
+
+type EventSourcedStateTestSuite struct {
+	suite.Suite
+	st *EventSourcedState
+}
+
+func (suite *EventSourcedStateTestSuite) SetupTest() {
+	suite.st = NewEventSourcedState(NewInMemoryState(exampleMapFile), 2)
+	suite.Require().NoError(suite.st.Load())
+	// tick 0: aliens spawn
+	for _, alien := range getAliens() {
+		suite.Require().NoError(suite.st.AddAlien(alien))
+	}
+	suite.Require().NoError(suite.st.Commit(0))
+	// tick 1: Mork moves to Qu-ux and fights
+	suite.Require().NoError(suite.st.MoveAlien(2, 2))
+	suite.Require().NoError(suite.st.RemoveCity(2))
+	suite.Require().NoError(suite.st.Commit(1))
+	// tick 2: nothing happens, tick 3: a city is added
+	suite.Require().NoError(suite.st.Commit(2))
+	suite.Require().NoError(suite.st.AddCity("Qux", "", "", "", ""))
+	suite.Require().NoError(suite.st.Commit(3))
+}
+
+func (suite *EventSourcedStateTestSuite) TestStateAt() {
+	state, err := suite.st.StateAt(0)
+	suite.Require().NoError(err)
+	suite.Assert().Equal(5, state.GetNumCities())
+	suite.Assert().Equal(3, len(state.GetAliens()))
+	alien, err := state.GetAlienByID(2)
+	suite.Require().NoError(err)
+	suite.Assert().Equal(0, alien.City)
+
+	for _, tick := range []int{1, 2} {
+		state, err = suite.st.StateAt(tick)
+		suite.Require().NoError(err)
+		suite.Assert().Equal(4, state.GetNumCities())
+		suite.Assert().Equal(0, len(state.GetAliens()))
+		city, err := state.GetCityByName("Foo")
+		suite.Require().NoError(err)
+		suite.Assert().Equal("", city.South)
+	}
+
+	state, err = suite.st.StateAt(3)
+	suite.Require().NoError(err)
+	suite.Assert().Equal(suite.st.GetNumCities(), state.GetNumCities())
+	city, err := state.GetCityByName("Qux")
+	suite.Require().NoError(err)
+	suite.Assert().Equal(&model.City{ID: 5, Name: "Qux"}, city)
+}
+
+func (suite *EventSourcedStateTestSuite) TestStateAtIsACopy() {
+	state, err := suite.st.StateAt(0)
+	suite.Require().NoError(err)
+	suite.Require().NoError(state.RemoveCity(0))
+	state, err = suite.st.StateAt(0)
+	suite.Require().NoError(err)
+	suite.Assert().Equal(5, state.GetNumCities())
+	_, err = suite.st.GetCityByName("Foo")
+	suite.Assert().NoError(err)
+}
+
+func (suite *EventSourcedStateTestSuite) TestInvalidTick() {
+	_, err := suite.st.StateAt(4)
+	suite.Assert().Error(err)
+	_, err = suite.st.StateAt(-1)
+	suite.Assert().Error(err)
+	tick, ok := suite.st.Committed()
+	suite.Assert().True(ok)
+	suite.Assert().Equal(3, tick)
+}
+
+func (suite *EventSourcedStateTestSuite) TestMaxTicks() {
+	capped := NewEventSourcedState(NewInMemoryState(exampleMapFile), 2)
+	capped.SetMaxTicks(5)
+	uncapped := NewEventSourcedState(NewInMemoryState(exampleMapFile), 2)
+	for _, st := range []*EventSourcedState{capped, uncapped} {
+		suite.Require().NoError(st.Load())
+		suite.Require().NoError(st.AddAlien(&model.Alien{ID: 1, Name: "Zork", City: 0}))
+		suite.Require().NoError(st.Commit(0))
+		// the alien goes back and forth between Foo and Bar
+		for tick := 1; tick <= 20; tick++ {
+			suite.Require().NoError(st.MoveAlien(1, tick%2))
+			suite.Require().NoError(st.Commit(tick))
+		}
+	}
+	// the history of older ticks is dropped
+	suite.Assert().LessOrEqual(len(capped.snapshots), 4)
+	suite.Assert().LessOrEqual(len(capped.mutations), 7)
+	for _, tick := range []int{0, 10, 15} {
+		_, err := capped.StateAt(tick)
+		suite.Assert().Error(err, "tick %d", tick)
+	}
+	for tick := 16; tick <= 20; tick++ {
+		state, err := capped.StateAt(tick)
+		suite.Require().NoError(err, "tick %d", tick)
+		expected, err := uncapped.StateAt(tick)
+		suite.Require().NoError(err)
+		suite.Assert().Equal(expected.GetAliens(), state.GetAliens(), "tick %d", tick)
+	}
+}
+
+// TestEventSourcedStateTestSuite is the entry point of this test suite
+func TestEventSourcedStateTestSuite(t *testing.T) {
+	suite.Run(t, new(EventSourcedStateTestSuite))
+}
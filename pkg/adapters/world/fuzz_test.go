@@ -0,0 +1,156 @@
+package world
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/c-kuroki/alien_invasion/pkg/model"
+)
+
+// fuzzSeeds are inputs added to the fuzz corpus of both targets
+var fuzzSeeds = []string{
+	"",
+	"=",
+	"Foo",
+	"Foo north=",
+	"Foo north==Bar",
+	"Foo =Bar",
+	"Foo north=Bar=Baz",
+	"Foo up=Bar",
+	"Foo north=Bar north=Baz",
+	"Foo north=Foo",
+	"Foo north=Foo south=Foo",
+	"Foo north=Bar\nBar south=Foo north=Foo",
+	"Foo north=Bar south=Bar\nBar south=Foo north=Foo",
+	"Foo east=Bar\nBar west=Foo\n\n\nBaz",
+	"Foo\tnorth=Bar\r\nBar  south=Foo\r\n",
+}
+
+func FuzzParseLine(f *testing.F) {
+	for _, seed := range fuzzSeeds {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, line string) {
+		fields, err := parseLine(1, line)
+		if err != nil {
+			return
+		}
+		if len(fields) != 5 {
+			t.Fatalf("%d fields parsed from %q", len(fields), line)
+		}
+		// a line written from the fields is parsed the same way
+		if fields[0] == "" {
+			return
+		}
+		written := fields[0]
+		for ix, direction := range []string{model.North, model.East, model.South, model.West} {
+			if fields[ix+1] != "" {
+				written += " " + direction + "=" + fields[ix+1]
+			}
+		}
+		reparsed, err := parseLine(1, written)
+		if err != nil {
+			t.Fatalf("parsing %q written from %q: %s", written, line, err)
+		}
+		if strings.Join(reparsed, "|") != strings.Join(fields, "|") {
+			t.Fatalf("%q parsed as %q, written as %q and parsed again as %q", line, fields, written, reparsed)
+		}
+	})
+}
+
+func FuzzLoad(f *testing.F) {
+	for _, seed := range fuzzSeeds {
+		f.Add(seed)
+	}
+	content, err := os.ReadFile(exampleMapFile)
+	if err != nil {
+		f.Fatal(err)
+	}
+	f.Add(string(content))
+	f.Fuzz(func(t *testing.T, content string) {
+		st := NewInMemoryStateFromReader(strings.NewReader(content))
+		err := st.Load()
+		if err != nil {
+			var loadErr *LoadError
+			if !errors.As(err, &loadErr) {
+				t.Fatalf("load error %T is not a LoadError: %s", err, err)
+			}
+			return
+		}
+		checkInvariants(t, st)
+
+		// saved maps load again as the same world
+		filename := filepath.Join(t.TempDir(), "saved.map")
+		if err := st.Save(filename); err != nil {
+			t.Fatal(err)
+		}
+		saved := NewInMemoryState(filename)
+		if err := saved.Load(); err != nil {
+			t.Fatalf("loading saved map: %s", err)
+		}
+		expected, result := sortedCities(st), sortedCities(saved)
+		if len(expected) != len(result) {
+			t.Fatalf("%d cities saved, %d loaded", len(expected), len(result))
+		}
+		for ix := range expected {
+			if *expected[ix] != *result[ix] {
+				t.Fatalf("city %s saved, %s loaded", expected[ix], result[ix])
+			}
+		}
+	})
+}
+
+// TestLoadLongLine checks that lines longer than the scanner buffer (64K) are load errors, they
+// are not part of the fuzz corpus as large inputs slow down fuzzing
+func TestLoadLongLine(t *testing.T) {
+	for _, content := range []string{
+		strings.Repeat("a", 70*1024),
+		"Foo east=Bar\nBar west=Foo north=" + strings.Repeat("b", 70*1024),
+	} {
+		err := NewInMemoryStateFromReader(strings.NewReader(content)).Load()
+		var loadErr *LoadError
+		if !errors.As(err, &loadErr) {
+			t.Fatalf("expected a load error, got %v", err)
+		}
+	}
+}
+
+// checkInvariants checks road symmetry and that cities are at unique positions matching their roads
+func checkInvariants(t *testing.T, st Adapter) {
+	opposite := map[string]string{model.North: model.South, model.East: model.West, model.South: model.North, model.West: model.East}
+	offset := map[string][2]int{model.North: {0, -1}, model.East: {1, 0}, model.South: {0, 1}, model.West: {-1, 0}}
+	placed := make(map[[2]int]string)
+	for _, city := range st.GetAllCities() {
+		if city.X < 0 || city.X >= st.GetWidth() || city.Y < 0 || city.Y >= st.GetHeight() {
+			t.Fatalf("%s at (%d,%d) is out of the %dx%d map", city.Name, city.X, city.Y, st.GetWidth(), st.GetHeight())
+		}
+		if other, ok := placed[[2]int{city.X, city.Y}]; ok {
+			t.Fatalf("%s and %s are both at (%d,%d)", other, city.Name, city.X, city.Y)
+		}
+		placed[[2]int{city.X, city.Y}] = city.Name
+		for direction, name := range city.Roads() {
+			other, err := st.GetCityByName(name)
+			if err != nil {
+				t.Fatalf("%s %s road leads to unknown city %s", city.Name, direction, name)
+			}
+			if other.Roads()[opposite[direction]] != city.Name {
+				t.Fatalf("%s %s road to %s has no road back", city.Name, direction, name)
+			}
+			if other.X != city.X+offset[direction][0] || other.Y != city.Y+offset[direction][1] {
+				t.Fatalf("%s %s road leads to %s at (%d,%d)", city.Name, direction, name, other.X, other.Y)
+			}
+		}
+	}
+}
+
+func sortedCities(st Adapter) []*model.City {
+	cities := st.GetAllCities()
+	sort.Slice(cities, func(i, j int) bool {
+		return cities[i].ID < cities[j].ID
+	})
+	return cities
+}
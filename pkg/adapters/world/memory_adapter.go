@@ -4,8 +4,10 @@ import (
 	"bufio"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"sort"
+	"strings"
 
 	"github.com/c-kuroki/alien_invasion/pkg/model"
 )
@@ -22,9 +24,27 @@ var (
 	dupConnErr          = errors.New("duplicated connection")
 )
 
+// LoadError is returned when a map can not be loaded, Line is 0 for errors not related to a single line
+type LoadError struct {
+	Line uint64
+	Err  error
+}
+
+func (e *LoadError) Error() string {
+	if e.Line == 0 {
+		return e.Err.Error()
+	}
+	return fmt.Sprintf("line %d: %s", e.Line, e.Err.Error())
+}
+
+func (e *LoadError) Unwrap() error {
+	return e.Err
+}
+
 // InMemoryState loads and save worlds from/to files storing state in memory
 type InMemoryState struct {
-	filename     string
+	// open returns the map to be loaded
+	open         func() (io.ReadCloser, error)
 	nextID       int
 	citiesByName map[string]*model.City
 	citiesByID   map[int]*model.City
@@ -35,8 +55,46 @@ type InMemoryState struct {
 }
 
 func NewInMemoryState(filename string) *InMemoryState {
+	st := newInMemoryState()
+	st.open = func() (io.ReadCloser, error) {
+		return os.Open(filename)
+	}
+	return st
+}
+
+// NewInMemoryStateFromReader returns a state that loads the world map from a reader (e.g. an uploaded map)
+func NewInMemoryStateFromReader(r io.Reader) *InMemoryState {
+	st := newInMemoryState()
+	st.open = func() (io.ReadCloser, error) {
+		return io.NopCloser(r), nil
+	}
+	return st
+}
+
+// NewInMemoryStateFromCities returns an already loaded state with passed cities and aliens
+// (e.g. restored from a checkpoint), it can not be loaded again
+func NewInMemoryStateFromCities(width, height int, cities []*model.City, aliens []*model.Alien) *InMemoryState {
+	st := newInMemoryState()
+	st.open = func() (io.ReadCloser, error) {
+		return nil, errors.New("state has no map file")
+	}
+	for _, city := range cities {
+		st.citiesByName[city.Name] = city
+		st.citiesByID[city.ID] = city
+		if city.ID >= st.nextID {
+			st.nextID = city.ID + 1
+		}
+	}
+	for _, alien := range aliens {
+		_ = st.AddAlien(alien)
+	}
+	st.mapWidth = width
+	st.mapHeight = height
+	return st
+}
+
+func newInMemoryState() *InMemoryState {
 	return &InMemoryState{
-		filename:     filename,
 		citiesByName: make(map[string]*model.City),
 		citiesByID:   make(map[int]*model.City),
 		aliensByCity: make(map[int]map[int]*model.Alien),
@@ -46,7 +104,7 @@ func NewInMemoryState(filename string) *InMemoryState {
 
 // Load loads a world map from a file (max line size of 64K)
 func (st *InMemoryState) Load() error {
-	file, err := os.Open(st.filename)
+	file, err := st.open()
 	if err != nil {
 		return err
 	}
@@ -57,77 +115,34 @@ func (st *InMemoryState) Load() error {
 	scanner := bufio.NewScanner(file)
 	for scanner.Scan() {
 		lineNum++
+		// blank lines are ignored
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
 		fields, err := parseLine(lineNum, scanner.Text())
 		if err != nil {
-			return err
+			return &LoadError{Line: lineNum, Err: err}
 		}
 		if err := st.AddCity(fields...); err != nil {
-			return err
+			return &LoadError{Line: lineNum, Err: err}
 		}
 	}
 	if err := scanner.Err(); err != nil {
-		return err
+		return &LoadError{Line: lineNum + 1, Err: err}
 	}
 	err = st.validateCities()
 	if err != nil {
-		return err
+		return &LoadError{Err: err}
 	}
 	err = st.setCoordinates()
 	if err != nil {
-		return err
+		return &LoadError{Err: err}
 	}
 	return nil
 }
 
 func (st *InMemoryState) Save(filename string) error {
-	file, err := os.Create(filename)
-	if err != nil {
-		return err
-	}
-	defer file.Close()
-
-	cities := st.GetAllCities()
-	// sort result
-	sort.Slice(cities, func(i, j int) bool {
-		return cities[i].ID < cities[j].ID
-	})
-	for _, city := range cities {
-		_, err = file.WriteString(city.Name)
-		if err != nil {
-			return err
-		}
-		if city.North != "" {
-			_, err = file.WriteString(fmt.Sprintf(" north=%s", city.North))
-			if err != nil {
-				return err
-			}
-		}
-		if city.East != "" {
-			_, err = file.WriteString(fmt.Sprintf(" east=%s", city.East))
-			if err != nil {
-				return err
-			}
-		}
-		if city.South != "" {
-			_, err = file.WriteString(fmt.Sprintf(" south=%s", city.South))
-			if err != nil {
-				return err
-			}
-		}
-		if city.West != "" {
-			_, err = file.WriteString(fmt.Sprintf(" west=%s", city.West))
-			if err != nil {
-				return err
-			}
-		}
-		_, err = file.WriteString("\n")
-		if err != nil {
-			return err
-		}
-
-	}
-	_ = file.Sync()
-	return nil
+	return saveCities(filename, st.GetAllCities())
 }
 
 func (st *InMemoryState) GetNumCities() int {
@@ -154,6 +169,28 @@ func (st *InMemoryState) GetAliens() map[int]*model.Alien {
 	return st.aliensByID
 }
 
+func (st *InMemoryState) GetNumAliens() int {
+	return len(st.aliensByID)
+}
+
+func (st *InMemoryState) ForEachAlien(fn func(*model.Alien) error) error {
+	alienIDs := make([]int, 0, len(st.aliensByID))
+	for alienID := range st.aliensByID {
+		alienIDs = append(alienIDs, alienID)
+	}
+	sort.Ints(alienIDs)
+	for _, alienID := range alienIDs {
+		alien, ok := st.aliensByID[alienID]
+		if !ok {
+			continue
+		}
+		if err := fn(alien); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (st *InMemoryState) GetAllAliensByCity() map[int]map[int]*model.Alien {
 	return st.aliensByCity
 }
@@ -216,6 +253,7 @@ func (st *InMemoryState) MoveAlien(alienID, cityID int) error {
 		delete(source, alienID)
 	}
 	alien.City = cityID
+	alien.Moves++
 	return st.addAlienToCity(alien, cityID)
 }
 
@@ -249,80 +287,65 @@ func (st *InMemoryState) AddCity(args ...string) error {
 }
 
 func (st *InMemoryState) validateCities() error {
-	cities := st.GetAllCities()
-	for _, city := range cities {
-		// check north
-		if city.North != "" {
-			n, err := st.GetCityByName(city.North)
-			if err != nil {
-				return fmt.Errorf("%s city : error %s", city.North, err.Error())
-			}
-			if n.South != city.Name {
-				return fmt.Errorf("invalid connection: %s north connection (%s) doesnt match %s south connection (%s)", city.Name, city.North, n.Name, n.South)
-			}
-		}
-		// check east
-		if city.East != "" {
-			e, err := st.GetCityByName(city.East)
-			if err != nil {
-				return fmt.Errorf("%s city : error %s", city.East, err.Error())
-			}
-			if e.West != city.Name {
-				return fmt.Errorf("invalid connection: %s east connection (%s) doesnt match %s west connection (%s)", city.Name, city.East, e.Name, e.West)
-			}
-		}
-		// check south
-		if city.South != "" {
-			s, err := st.GetCityByName(city.South)
-			if err != nil {
-				return fmt.Errorf("%s city : error %s", city.South, err.Error())
-			}
-			if s.North != city.Name {
-				return fmt.Errorf("invalid connection: %s south connection (%s) doesnt match %s north connection (%s)", city.Name, city.South, s.Name, s.North)
-			}
-		}
-		// check west
-		if city.West != "" {
-			w, err := st.GetCityByName(city.West)
-			if err != nil {
-				return fmt.Errorf("%s city : error %s", city.West, err.Error())
-			}
-			if w.East != city.Name {
-				return fmt.Errorf("invalid connection: %s west connection (%s) doesnt match %s east connection (%s)", city.Name, city.West, w.Name, w.East)
-			}
+	for _, city := range st.GetAllCities() {
+		if err := validateRoads(city, st.GetCityByName); err != nil {
+			return err
 		}
 	}
 	return nil
 }
 
 func (st *InMemoryState) setCoordinates() error {
-	// first traverse world and set relative coordinates , using (0,0) from first city
+	// first walk the world breadth first and set relative coordinates, using (0,0) from first city
+	// (unlike Traverse, it also reaches cities enclosed by loops of roads)
 	visited := make(map[int]bool)
-	var x, y int
-	y = 1 // initialize y coord to 1 because first call is direction north
-	numCities := st.GetNumCities()
-	err := st.Traverse(numCities, visited, 0, 0, func(c *model.City, direction, action int) error {
-		// if the action is just rotation and no move, not modify position
-		if action == 4 {
-			return nil
+	if st.GetNumCities() > 0 {
+		first, err := st.GetCityByID(0)
+		if err != nil {
+			return err
 		}
-		// tracks coordinates while traversing
-		switch direction {
-		case 0:
-			y = y - 1
-		case 1:
-			x = x + 1
-		case 2:
-			y = y + 1
-		case 3:
-			x = x - 1
+		first.X, first.Y = 0, 0
+		visited[first.ID] = true
+		queue := []*model.City{first}
+		for len(queue) > 0 {
+			city := queue[0]
+			queue = queue[1:]
+			for direction := 0; direction < 4; direction++ {
+				if ok, _ := frontConnectionExists(city, direction); !ok {
+					continue
+				}
+				nextCityID, err := st.forward(city, direction)
+				if err != nil {
+					return err
+				}
+				next, err := st.GetCityByID(nextCityID)
+				if err != nil {
+					return err
+				}
+				// tracks coordinates while walking
+				x, y := city.X, city.Y
+				switch direction {
+				case 0:
+					y--
+				case 1:
+					x++
+				case 2:
+					y++
+				case 3:
+					x--
+				}
+				if visited[nextCityID] {
+					// loops of roads must lead back to the same place
+					if next.X != x || next.Y != y {
+						return fmt.Errorf("%w: roads from %s to %s do not match their positions", invalidMapErr, city.Name, next.Name)
+					}
+					continue
+				}
+				next.X, next.Y = x, y
+				visited[nextCityID] = true
+				queue = append(queue, next)
+			}
 		}
-		c.X = x
-		c.Y = y
-		return nil
-	}, 0)
-	if err != nil {
-		return err
 	}
 
 	cities := st.GetAllCities()
@@ -330,6 +353,15 @@ func (st *InMemoryState) setCoordinates() error {
 	if len(visited) != len(cities) {
 		return invalidMapErr
 	}
+	// two cities can not be at the same place
+	placed := make(map[model.Coord]*model.City, len(cities))
+	for _, city := range cities {
+		coord := *model.NewCoord(city.X, city.Y)
+		if other, ok := placed[coord]; ok {
+			return fmt.Errorf("%w: %s and %s are at the same position", invalidMapErr, other.Name, city.Name)
+		}
+		placed[coord] = city
+	}
 	// reindex with absolute values
 	// get min and max X and Y
 	var minX, maxX, minY, maxY int
@@ -446,18 +478,7 @@ func (st *InMemoryState) Traverse(numCities int, visited map[int]bool, direction
 
 // forward moves forward in passed direction, returning new city id
 func (st *InMemoryState) forward(city *model.City, direction int) (int, error) {
-	var name string
-	switch direction {
-	case 0: // north
-		name = city.North
-	case 1: // east
-		name = city.East
-	case 2: // south
-		name = city.South
-	case 3: // west
-		name = city.West
-	}
-	newCity, err := st.GetCityByName(name)
+	newCity, err := st.GetCityByName(roadTo(city, direction))
 	if err != nil {
 		return 0, err
 	}
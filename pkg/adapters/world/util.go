@@ -2,6 +2,8 @@ package world
 
 import (
 	"fmt"
+	"os"
+	"sort"
 	"strings"
 
 	"github.com/c-kuroki/alien_invasion/pkg/model"
@@ -56,6 +58,71 @@ func parseLine(num uint64, line string) ([]string, error) {
 	return []string{name, north, east, south, west}, nil
 }
 
+// validateRoads checks that the roads of a city lead to other cities with a road back
+func validateRoads(city *model.City, getCityByName func(string) (*model.City, error)) error {
+	for direction, name := range city.Roads() {
+		if name == city.Name {
+			return fmt.Errorf("invalid connection: %s %s connection leads to itself", city.Name, direction)
+		}
+	}
+	// check north
+	if city.North != "" {
+		n, err := getCityByName(city.North)
+		if err != nil {
+			return fmt.Errorf("%s city : error %s", city.North, err.Error())
+		}
+		if n.South != city.Name {
+			return fmt.Errorf("invalid connection: %s north connection (%s) doesnt match %s south connection (%s)", city.Name, city.North, n.Name, n.South)
+		}
+	}
+	// check east
+	if city.East != "" {
+		e, err := getCityByName(city.East)
+		if err != nil {
+			return fmt.Errorf("%s city : error %s", city.East, err.Error())
+		}
+		if e.West != city.Name {
+			return fmt.Errorf("invalid connection: %s east connection (%s) doesnt match %s west connection (%s)", city.Name, city.East, e.Name, e.West)
+		}
+	}
+	// check south
+	if city.South != "" {
+		s, err := getCityByName(city.South)
+		if err != nil {
+			return fmt.Errorf("%s city : error %s", city.South, err.Error())
+		}
+		if s.North != city.Name {
+			return fmt.Errorf("invalid connection: %s south connection (%s) doesnt match %s north connection (%s)", city.Name, city.South, s.Name, s.North)
+		}
+	}
+	// check west
+	if city.West != "" {
+		w, err := getCityByName(city.West)
+		if err != nil {
+			return fmt.Errorf("%s city : error %s", city.West, err.Error())
+		}
+		if w.East != city.Name {
+			return fmt.Errorf("invalid connection: %s west connection (%s) doesnt match %s east connection (%s)", city.Name, city.West, w.Name, w.East)
+		}
+	}
+	return nil
+}
+
+// roadTo returns the name of the city connected in a direction, empty if there is no road
+func roadTo(city *model.City, direction int) string {
+	switch direction {
+	case 0: // north
+		return city.North
+	case 1: // east
+		return city.East
+	case 2: // south
+		return city.South
+	case 3: // west
+		return city.West
+	}
+	return ""
+}
+
 // rightConnectionExists returns true if there is connection to other city to the right side of current facing position
 func rightConnectionExists(city *model.City, direction int) (bool, error) {
 	switch direction {
@@ -103,3 +170,54 @@ func rotate(direction int, clockwise bool) int {
 	}
 	return direction
 }
+
+// saveCities writes cities sorted by ID on the map file format
+func saveCities(filename string, cities []*model.City) error {
+	file, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	// sort result
+	sort.Slice(cities, func(i, j int) bool {
+		return cities[i].ID < cities[j].ID
+	})
+	for _, city := range cities {
+		_, err = file.WriteString(city.Name)
+		if err != nil {
+			return err
+		}
+		if city.North != "" {
+			_, err = file.WriteString(fmt.Sprintf(" north=%s", city.North))
+			if err != nil {
+				return err
+			}
+		}
+		if city.East != "" {
+			_, err = file.WriteString(fmt.Sprintf(" east=%s", city.East))
+			if err != nil {
+				return err
+			}
+		}
+		if city.South != "" {
+			_, err = file.WriteString(fmt.Sprintf(" south=%s", city.South))
+			if err != nil {
+				return err
+			}
+		}
+		if city.West != "" {
+			_, err = file.WriteString(fmt.Sprintf(" west=%s", city.West))
+			if err != nil {
+				return err
+			}
+		}
+		_, err = file.WriteString("\n")
+		if err != nil {
+			return err
+		}
+
+	}
+	_ = file.Sync()
+	return nil
+}
@@ -0,0 +1,396 @@
+// Package worldtest is a conformance test suite for world.Adapter implementations
+package worldtest
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/c-kuroki/alien_invasion/pkg/adapters/world"
+	"github.com/c-kuroki/alien_invasion/pkg/model"
+)
+
+// ExampleMap is the map used by most tests, world.map on the examples directory
+const ExampleMap = `Foo north=Bar south=Qu-ux west=Baz
+Bar south=Foo west=Bee
+Qu-ux north=Foo
+Baz east=Foo
+Bee east=Bar
+`
+
+// city IDs of ExampleMap
+const (
+	foo = iota
+	bar
+	quux
+	baz
+	bee
+)
+
+// errStop is returned by the function passed to ForEachAlien to stop the iteration
+var errStop = errors.New("stop")
+
+// NewState returns the adapter under test for a map file, called once per test
+type NewState func(t *testing.T, mapFile string) world.Adapter
+
+// Run runs the conformance suite against the adapters returned by newState
+func Run(t *testing.T, newState NewState) {
+	suite.Run(t, &Suite{NewState: newState})
+}
+
+// Suite checks the behaviour every world.Adapter must have
+type Suite struct {
+	suite.Suite
+	NewState NewState
+}
+
+// load writes a map to a temporary file and returns a loaded adapter
+func (suite *Suite) load(content string) world.Adapter {
+	st, err := suite.tryLoad(content)
+	suite.Require().NoError(err)
+	return st
+}
+
+func (suite *Suite) tryLoad(content string) (world.Adapter, error) {
+	filename := filepath.Join(suite.T().TempDir(), "world.map")
+	suite.Require().NoError(os.WriteFile(filename, []byte(content), 0o600))
+	st := suite.NewState(suite.T(), filename)
+	return st, st.Load()
+}
+
+func (suite *Suite) TestLoad() {
+	testCases := []struct {
+		name          string
+		content       string
+		cities        []*model.City
+		width, height int
+		err           string
+	}{
+		{
+			name:    "example map",
+			content: ExampleMap,
+			cities: []*model.City{
+				{ID: foo, Name: "Foo", North: "Bar", South: "Qu-ux", West: "Baz", X: 1, Y: 1},
+				{ID: bar, Name: "Bar", South: "Foo", West: "Bee", X: 1, Y: 0},
+				{ID: quux, Name: "Qu-ux", North: "Foo", X: 1, Y: 2},
+				{ID: baz, Name: "Baz", East: "Foo", X: 0, Y: 1},
+				{ID: bee, Name: "Bee", East: "Bar", X: 0, Y: 0},
+			},
+			width:  2,
+			height: 3,
+		},
+		{
+			name: "roads loop",
+			content: `Foo east=Bar south=Baz
+Bar south=Bee west=Foo
+Baz north=Foo east=Bee
+Bee north=Bar west=Baz south=Qu-ux
+Qu-ux north=Bee`,
+			cities: []*model.City{
+				{ID: 0, Name: "Foo", East: "Bar", South: "Baz", X: 0, Y: 0},
+				{ID: 1, Name: "Bar", South: "Bee", West: "Foo", X: 1, Y: 0},
+				{ID: 2, Name: "Baz", North: "Foo", East: "Bee", X: 0, Y: 1},
+				{ID: 3, Name: "Bee", North: "Bar", South: "Qu-ux", West: "Baz", X: 1, Y: 1},
+				{ID: 4, Name: "Qu-ux", North: "Bee", X: 1, Y: 2},
+			},
+			width:  2,
+			height: 3,
+		},
+		{
+			name:    "single city",
+			content: "Foo\n",
+			cities:  []*model.City{{ID: 0, Name: "Foo"}},
+			width:   1,
+			height:  1,
+		},
+		{
+			name: "invalid connection",
+			content: `Foo south=Qu-ux west=Bar
+Qu-ux north=Bar
+Bar east=Foo`,
+			err: "invalid connection",
+		},
+		{
+			name: "duplicated connection",
+			content: `Foo south=Bar south=Qu-ux
+Qu-ux north=Foo`,
+			err: "duplicated connection",
+		},
+		{
+			name:    "duplicated city",
+			content: "Foo\nFoo\n",
+			err:     "duplicated city",
+		},
+		{
+			name:    "invalid direction",
+			content: "Foo up=Bar\nBar\n",
+			err:     "invalid card",
+		},
+		{
+			name:    "unknown city",
+			content: "Foo north=Bar\n",
+			err:     "not found",
+		},
+		{
+			name: "isolated cities",
+			content: `Foo south=Qu-ux
+Qu-ux north=Foo
+Bar east=Bee
+Bee west=Bar`,
+			err: "invalid map",
+		},
+	}
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			st, err := suite.tryLoad(tc.content)
+			if tc.err != "" {
+				suite.Require().Error(err)
+				suite.Assert().Contains(err.Error(), tc.err)
+				return
+			}
+			suite.Require().NoError(err)
+			suite.Assert().Equal(len(tc.cities), st.GetNumCities())
+			suite.Assert().Equal(len(tc.cities), len(st.GetAllCities()))
+			suite.Assert().Equal(tc.width, st.GetWidth())
+			suite.Assert().Equal(tc.height, st.GetHeight())
+			for _, expected := range tc.cities {
+				city, err := st.GetCityByID(expected.ID)
+				suite.Require().NoError(err)
+				suite.Assert().Equal(expected, city)
+				city, err = st.GetCityByName(expected.Name)
+				suite.Require().NoError(err)
+				suite.Assert().Equal(expected, city)
+			}
+			suite.Assert().Empty(st.GetAliens())
+		})
+	}
+}
+
+func (suite *Suite) TestSave() {
+	st := suite.load(ExampleMap)
+	filename := filepath.Join(suite.T().TempDir(), "saved.map")
+	suite.Require().NoError(st.Save(filename))
+	saved, err := os.ReadFile(filename)
+	suite.Require().NoError(err)
+	suite.Assert().Equal(ExampleMap, string(saved))
+
+	// saved maps load again, without removed cities
+	suite.Require().NoError(st.RemoveCity(quux))
+	suite.Require().NoError(st.Save(filename))
+	saved, err = os.ReadFile(filename)
+	suite.Require().NoError(err)
+	suite.Assert().Equal("Foo north=Bar west=Baz\nBar south=Foo west=Bee\nBaz east=Foo\nBee east=Bar\n", string(saved))
+}
+
+func (suite *Suite) TestExits() {
+	st := suite.load(ExampleMap)
+	testCases := []struct {
+		city  int
+		exits []int
+	}{
+		// exits are sorted north, east, south, west
+		{city: foo, exits: []int{bar, quux, baz}},
+		{city: bar, exits: []int{foo, bee}},
+		{city: quux, exits: []int{foo}},
+		{city: baz, exits: []int{foo}},
+		{city: bee, exits: []int{bar}},
+	}
+	for _, tc := range testCases {
+		exits, err := st.GetExits(tc.city)
+		suite.Require().NoError(err)
+		suite.Assert().Equal(tc.exits, exits, "city %d", tc.city)
+	}
+	// cities left without roads have no exits
+	suite.Require().NoError(st.RemoveCity(foo))
+	exits, err := st.GetExits(quux)
+	suite.Require().NoError(err)
+	suite.Assert().Empty(exits)
+}
+
+func (suite *Suite) TestRemoveCity() {
+	testCases := []struct {
+		name    string
+		removed []int
+		// roads expected for the remaining cities, by name
+		roads map[string]map[string]string
+	}{
+		{
+			name:    "hub",
+			removed: []int{foo},
+			roads: map[string]map[string]string{
+				"Bar":   {model.West: "Bee"},
+				"Qu-ux": {},
+				"Baz":   {},
+				"Bee":   {model.East: "Bar"},
+			},
+		},
+		{
+			name:    "leaf",
+			removed: []int{bee},
+			roads: map[string]map[string]string{
+				"Foo":   {model.North: "Bar", model.South: "Qu-ux", model.West: "Baz"},
+				"Bar":   {model.South: "Foo"},
+				"Qu-ux": {model.North: "Foo"},
+				"Baz":   {model.East: "Foo"},
+			},
+		},
+		{
+			name:    "several",
+			removed: []int{bar, quux, baz},
+			roads: map[string]map[string]string{
+				"Foo": {},
+				"Bee": {},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			st := suite.load(ExampleMap)
+			for _, cityID := range tc.removed {
+				city, err := st.GetCityByID(cityID)
+				suite.Require().NoError(err)
+				suite.Require().NoError(st.RemoveCity(cityID))
+				_, err = st.GetCityByID(cityID)
+				suite.Assert().Error(err)
+				_, err = st.GetCityByName(city.Name)
+				suite.Assert().Error(err)
+			}
+			suite.Assert().Equal(len(tc.roads), st.GetNumCities())
+			for _, city := range st.GetAllCities() {
+				suite.Assert().Equal(tc.roads[city.Name], city.Roads(), city.Name)
+			}
+			suite.assertSymmetric(st)
+		})
+	}
+}
+
+// assertSymmetric checks that every road leads to a city with the opposite road back
+func (suite *Suite) assertSymmetric(st world.Adapter) {
+	opposite := map[string]string{model.North: model.South, model.East: model.West, model.South: model.North, model.West: model.East}
+	for _, city := range st.GetAllCities() {
+		for direction, name := range city.Roads() {
+			other, err := st.GetCityByName(name)
+			if suite.Assert().NoError(err, "%s %s road", city.Name, direction) {
+				suite.Assert().Equal(city.Name, other.Roads()[opposite[direction]], "%s %s road", city.Name, direction)
+			}
+		}
+	}
+}
+
+func (suite *Suite) TestAliens() {
+	st := suite.load(ExampleMap)
+	aliens := []*model.Alien{
+		{ID: 1, Name: "Zork", City: quux, Origin: quux},
+		{ID: 2, Name: "Mork", City: foo, Origin: foo},
+		{ID: 3, Name: "Gork", City: quux, Origin: quux},
+	}
+	for _, alien := range aliens {
+		a := *alien
+		suite.Require().NoError(st.AddAlien(&a))
+	}
+	suite.Assert().Equal(3, len(st.GetAliens()))
+	suite.Assert().Equal(3, st.GetNumAliens())
+	for _, expected := range aliens {
+		alien, err := st.GetAlienByID(expected.ID)
+		suite.Require().NoError(err)
+		suite.Assert().Equal(expected, alien)
+	}
+	suite.assertAliensAt(st, quux, 1, 3)
+	suite.assertAliensAt(st, foo, 2)
+	// cities never visited have no aliens
+	_, err := st.GetAliensByCity(bar)
+	suite.Assert().Error(err)
+
+	// moves update the city indexes and the alien counter
+	suite.Require().NoError(st.MoveAlien(2, quux))
+	suite.assertAliensAt(st, quux, 1, 2, 3)
+	suite.assertAliensAt(st, foo)
+	suite.Require().NoError(st.MoveAlien(2, bar))
+	suite.Require().NoError(st.MoveAlien(2, bee))
+	alien, err := st.GetAlienByID(2)
+	suite.Require().NoError(err)
+	suite.Assert().Equal(&model.Alien{ID: 2, Name: "Mork", City: bee, Origin: foo, Moves: 3}, alien)
+	suite.assertAliensAt(st, bee, 2)
+	suite.Assert().Equal(3, len(st.GetAliens()))
+
+	// aliens are iterated in ID order and can be moved meanwhile
+	var ids []int
+	suite.Require().NoError(st.ForEachAlien(func(alien *model.Alien) error {
+		ids = append(ids, alien.ID)
+		return st.MoveAlien(alien.ID, alien.City)
+	}))
+	suite.Assert().Equal([]int{1, 2, 3}, ids)
+	suite.assertAliensAt(st, bee, 2)
+	alien, err = st.GetAlienByID(2)
+	suite.Require().NoError(err)
+	suite.Assert().Equal(4, alien.Moves)
+	suite.Assert().Equal(errStop, st.ForEachAlien(func(alien *model.Alien) error { return errStop }))
+
+	byCity := st.GetAllAliensByCity()
+	suite.Assert().Equal(2, len(byCity[quux]))
+	suite.Assert().Equal(1, len(byCity[bee]))
+	suite.Assert().Equal(0, len(byCity[foo]))
+
+	// removing a city kills its aliens
+	suite.Require().NoError(st.RemoveCity(quux))
+	suite.Assert().Equal(1, len(st.GetAliens()))
+	_, err = st.GetAlienByID(1)
+	suite.Assert().Error(err)
+	_, err = st.GetAliensByCity(quux)
+	suite.Assert().Error(err)
+	_, ok := st.GetAllAliensByCity()[quux]
+	suite.Assert().False(ok)
+}
+
+// assertAliensAt checks the IDs of the aliens at a city
+func (suite *Suite) assertAliensAt(st world.Adapter, cityID int, expected ...int) {
+	aliens, err := st.GetAliensByCity(cityID)
+	suite.Require().NoError(err)
+	ids := []int{}
+	for id, alien := range aliens {
+		suite.Assert().Equal(id, alien.ID)
+		suite.Assert().Equal(cityID, alien.City)
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	if expected == nil {
+		expected = []int{}
+	}
+	suite.Assert().Equal(expected, ids, "aliens at city %d", cityID)
+}
+
+func (suite *Suite) TestAddCity() {
+	st := suite.load(ExampleMap)
+	suite.Require().NoError(st.AddCity("Qux", "", "", "", ""))
+	city, err := st.GetCityByName("Qux")
+	suite.Require().NoError(err)
+	suite.Assert().Equal(&model.City{ID: 5, Name: "Qux"}, city)
+	suite.Assert().Equal(6, st.GetNumCities())
+}
+
+func (suite *Suite) TestErrors() {
+	st := suite.load(ExampleMap)
+	testCases := []struct {
+		name string
+		call func() error
+	}{
+		{"city by ID", func() error { _, err := st.GetCityByID(100); return err }},
+		{"city by name", func() error { _, err := st.GetCityByName("Qux"); return err }},
+		{"alien", func() error { _, err := st.GetAlienByID(100); return err }},
+		{"exits", func() error { _, err := st.GetExits(100); return err }},
+		{"move", func() error { return st.MoveAlien(100, foo) }},
+		{"remove", func() error { return st.RemoveCity(100) }},
+		{"duplicated city", func() error { return st.AddCity("Foo", "", "", "", "") }},
+		{"invalid city", func() error { return st.AddCity("Qux") }},
+	}
+	for _, tc := range testCases {
+		suite.Assert().Error(tc.call(), tc.name)
+	}
+	// failed calls do not change the world
+	suite.Assert().Equal(5, st.GetNumCities())
+	suite.Assert().Empty(st.GetAliens())
+}
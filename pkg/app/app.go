@@ -2,121 +2,508 @@ package app
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"math/rand"
+	"os"
+	"sort"
+	"sync"
 	"time"
 
 	"github.com/c-kuroki/alien_invasion/pkg/adapters/renderer"
 	"github.com/c-kuroki/alien_invasion/pkg/adapters/world"
+	"github.com/c-kuroki/alien_invasion/pkg/clock"
 	"github.com/c-kuroki/alien_invasion/pkg/logger"
 	"github.com/c-kuroki/alien_invasion/pkg/model"
 )
 
+var finishedErr = errors.New("simulation finished")
+
+// noDelay is always ready, it replaces the ticker when the tick interval is 0
+var noDelay = func() <-chan time.Time {
+	ch := make(chan time.Time)
+	close(ch)
+	return ch
+}()
+
+// time to wait for a resume or step while paused without tick interval
+const pausedPoll = 10 * time.Millisecond
+
 type AlienInvasionApp struct {
 	state    world.Adapter
 	renderer renderer.Adapter
 	cfg      *model.Config
 	log      logger.Logger
+	// clock times ticks, checkpoints and output filenames
+	clock clock.Clock
+	rnd   *rand.Rand
+	// src is the source of rnd, its state is checkpointed
+	src *splitMix
+	// seed of rnd, set from current time if not configured
+	seed int64
+	// stdout receives the final map and the summary report if configured
+	stdout io.Writer
+	// stderr receives the summary report when the final map is written to stdout
+	stderr io.Writer
+	// stop ends the main loop
+	stop     chan struct{}
+	stopOnce sync.Once
+	// mu protects state and stats between main loop and readers
+	mu     sync.RWMutex
+	tick   int
+	status string
+	// playback control
+	paused bool
+	steps  int
+	// initial cities (before any destruction) used by overlays
+	initialCities []*model.City
+	// cities destroyed by fights, kept to be rendered
+	destroyedCities []*model.City
+	// destructions in order, with their attackers
+	destructions []*model.Destruction
+	stats        *model.Stats
+	// counters exported as metrics
+	moves          int
+	fights         int
+	tickFights     int
+	movesPerSecond float64
+	// events of the last ticks, live subscribers and events recording
+	events      *eventLog
+	subscribers map[*subscriber]bool
+	// end is the end event once published, for subscribers coming after it
+	end       *model.Event
+	eventsOut io.Writer
+	eventsEnc *json.Encoder
+	// err is the error that aborted the main loop
+	err error
 }
 
 func NewAlienInvasionApp(cfg *model.Config, state world.Adapter, renderer renderer.Adapter, log logger.Logger) *AlienInvasionApp {
+	seed := cfg.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	rnd, src := newSimulationRand(uint64(seed))
+	eventTicks := cfg.EventTicks
+	if eventTicks == 0 {
+		eventTicks = DefaultEventTicks
+	} else if eventTicks < 0 {
+		eventTicks = 0
+	}
 	return &AlienInvasionApp{
 		cfg:      cfg,
 		state:    state,
 		renderer: renderer,
 		log:      log,
+		clock:    clock.New(),
+		rnd:      rnd,
+		src:      src,
+		seed:     seed,
+		stdout:   os.Stdout,
+		stderr:   os.Stderr,
+		stop:     make(chan struct{}),
+		stats:    model.NewStats(),
+		status:   model.StatusLoading,
+		events:   newEventLog(eventTicks),
+	}
+}
+
+// SetClock replaces the system clock, it must be called before Init
+func (app *AlienInvasionApp) SetClock(c clock.Clock) {
+	app.clock = c
+}
+
+// Snapshot returns a copy of the current simulation state, including destroyed cities
+func (app *AlienInvasionApp) Snapshot() *model.Snapshot {
+	app.mu.RLock()
+	defer app.mu.RUnlock()
+	snapshot := &model.Snapshot{
+		Tick:   app.tick,
+		Status: app.status,
+		Width:  app.state.GetWidth(),
+		Height: app.state.GetHeight(),
+	}
+	for _, city := range app.state.GetAllCities() {
+		c := *city
+		snapshot.Cities = append(snapshot.Cities, &c)
+	}
+	for _, city := range app.destroyedCities {
+		c := *city
+		snapshot.Cities = append(snapshot.Cities, &c)
+	}
+	for _, alien := range app.state.GetAliens() {
+		a := *alien
+		snapshot.Aliens = append(snapshot.Aliens, &a)
 	}
+	sort.Slice(snapshot.Cities, func(i, j int) bool {
+		return snapshot.Cities[i].ID < snapshot.Cities[j].ID
+	})
+	sort.Slice(snapshot.Aliens, func(i, j int) bool {
+		return snapshot.Aliens[i].ID < snapshot.Aliens[j].ID
+	})
+	return snapshot
+}
+
+// Tick returns the number of the last executed move
+func (app *AlienInvasionApp) Tick() int {
+	app.mu.RLock()
+	defer app.mu.RUnlock()
+	return app.tick
+}
+
+// Pause stops executing moves until Resume or Step are called
+func (app *AlienInvasionApp) Pause() error {
+	app.mu.Lock()
+	defer app.mu.Unlock()
+	if app.status == model.StatusFinished {
+		return finishedErr
+	}
+	app.paused = true
+	if app.status == model.StatusRunning {
+		app.status = model.StatusPaused
+	}
+	return nil
+}
+
+// Resume continues executing moves after a Pause
+func (app *AlienInvasionApp) Resume() error {
+	app.mu.Lock()
+	defer app.mu.Unlock()
+	if app.status == model.StatusFinished {
+		return finishedErr
+	}
+	app.paused = false
+	app.steps = 0
+	if app.status == model.StatusPaused {
+		app.status = model.StatusRunning
+	}
+	return nil
+}
+
+// Step executes a single move on next tick while paused
+func (app *AlienInvasionApp) Step() error {
+	app.mu.Lock()
+	defer app.mu.Unlock()
+	if app.status == model.StatusFinished {
+		return finishedErr
+	}
+	if !app.paused {
+		return errors.New("simulation is not paused")
+	}
+	app.steps++
+	return nil
+}
+
+// Stop ends the simulation on next tick, it can be called many times
+func (app *AlienInvasionApp) Stop() {
+	app.stopOnce.Do(func() {
+		close(app.stop)
+	})
 }
 
 func (app *AlienInvasionApp) RenderMap(ctx context.Context, w io.Writer) error {
-	cities := app.state.GetAllCities()
+	app.mu.RLock()
+	defer app.mu.RUnlock()
+	cities := append(app.state.GetAllCities(), app.destroyedCities...)
 	aliens := app.state.GetAllAliensByCity()
 	return app.renderer.Render(ctx, cities, aliens, w)
 }
 
+// RenderOverlay renders the initial map colored by a metric accumulated during the run
+func (app *AlienInvasionApp) RenderOverlay(ctx context.Context, overlay renderer.Overlay, w io.Writer) error {
+	app.mu.RLock()
+	defer app.mu.RUnlock()
+	return app.renderer.RenderOverlay(ctx, app.initialCities, app.stats, overlay, w)
+}
+
+// Stats returns a copy of the metrics accumulated during the run
+func (app *AlienInvasionApp) Stats() *model.Stats {
+	app.mu.RLock()
+	defer app.mu.RUnlock()
+	stats := &model.Stats{Cities: make(map[int]*model.CityStats)}
+	stats.Merge(app.stats)
+	return stats
+}
+
 func (app *AlienInvasionApp) Start() {
 	app.log.Infow("starting invasion app")
-	// load map
-	err := app.state.Load()
-	if err != nil {
+	if err := app.Init(); err != nil {
 		app.log.Errorw("error loading map", "error", err.Error())
 		return
 	}
+	app.MainLoop()
+}
+
+// Init loads the map and places the aliens, MainLoop can be called after it
+func (app *AlienInvasionApp) Init() error {
+	// load map
+	app.mu.Lock()
+	defer app.mu.Unlock()
+	if err := app.load(); err != nil {
+		return err
+	}
+	// a persistent world with aliens is resumed from its last committed tick. Only the world is
+	// persisted, so the stats, destructions and destroyed cities of the previous ticks are lost
+	// and the initial cities are the ones left
+	if committer, ok := app.state.(world.Committer); ok {
+		if tick, ok := committer.Committed(); ok && app.state.GetNumAliens() > 0 {
+			app.tick = tick
+			app.status = model.StatusRunning
+			if app.paused {
+				app.status = model.StatusPaused
+			}
+			app.log.Infow("resuming invasion", "tick", fmt.Sprint(tick))
+			return nil
+		}
+	}
 	cities := app.state.GetAllCities()
 	// add aliens
 	max := len(cities) - 1
 	for i := 0; i < app.cfg.NumAliens; i++ {
-		cityID := getRandomInRange(0, max)
+		cityID := getRandomInRange(app.rnd, 0, max)
 		alien := model.NewAlien(i, cityID)
-		err = app.state.AddAlien(alien)
+		err := app.state.AddAlien(alien)
 		if err != nil {
 			app.log.Warnw("adding alien", "error", err.Error())
 			continue
 		}
+		app.stats.Visit(cityID)
+		app.publish(model.Event{Type: model.EventSpawn, AlienID: alien.ID, CityID: cityID})
 		app.log.Infow("added alien", "id", fmt.Sprintf("%d", alien.ID), "name", alien.Name)
 	}
-	app.MainLoop()
+	app.commit()
+	app.status = model.StatusRunning
+	if app.paused {
+		app.status = model.StatusPaused
+	}
+	return nil
 }
 
-// main loop
+// load loads the map and keeps the initial cities (mu must be held)
+func (app *AlienInvasionApp) load() error {
+	err := app.state.Load()
+	if err != nil {
+		app.status = model.StatusFinished
+		app.publish(model.Event{Type: model.EventEnd, Reason: fmt.Sprintf("error loading map: %s", err.Error())})
+		return err
+	}
+	cities := app.state.GetAllCities()
+	if len(cities) == 0 {
+		app.status = model.StatusFinished
+		return errors.New("empty map")
+	}
+	for _, city := range cities {
+		initial := *city
+		app.initialCities = append(app.initialCities, &initial)
+	}
+	return nil
+}
+
+// main loop, moves are made without pause if the tick interval is 0
 func (app *AlienInvasionApp) MainLoop() {
-	var moves int
-	tickerChan := time.NewTicker(time.Duration(int64(time.Millisecond) * int64(app.cfg.TickInterval))).C
+	// a resumed simulation continues from its tick
+	app.mu.Lock()
+	moves := app.tick
+	numAliens := app.state.GetNumAliens()
+	app.mu.Unlock()
+	lastTick := app.clock.Now()
+	ticks := noDelay
+	if app.cfg.TickInterval > 0 {
+		ticker := app.clock.NewTicker(time.Duration(int64(time.Millisecond) * int64(app.cfg.TickInterval)))
+		defer ticker.Stop()
+		ticks = ticker.C()
+	}
 	for {
-		<-tickerChan
+		stopped := false
+		select {
+		case <-ticks:
+		case <-app.stop:
+			stopped = true
+		}
+		app.mu.Lock()
+		if stopped {
+			app.status = model.StatusFinished
+			app.publish(model.Event{Type: model.EventEnd, AliveAliens: app.state.GetNumAliens(), RemainingCities: app.state.GetNumCities(), Reason: "stopped"})
+			app.mu.Unlock()
+			// an interrupted run can be resumed from its last tick
+			app.saveCheckpoint()
+			app.finish("stopped")
+			return
+		}
+		if app.paused {
+			if app.steps == 0 {
+				app.mu.Unlock()
+				if ticks == noDelay {
+					// avoid spinning while paused
+					app.clock.Sleep(pausedPoll)
+				}
+				continue
+			}
+			app.steps--
+		}
 		moves++
+		app.tick = moves
+		prevMoves, prevFights := app.moves, app.fights
 		err := app.makeMove()
+		now := app.clock.Now()
+		app.tickFights = app.fights - prevFights
+		if elapsed := now.Sub(lastTick).Seconds(); elapsed > 0 {
+			app.movesPerSecond = float64(app.moves-prevMoves) / elapsed
+		}
+		lastTick = now
+		app.commit()
+		if app.cfg.Debug {
+			if err := app.checkInvariants(numAliens); err != nil {
+				app.abort(err)
+				return
+			}
+		}
+		numAliens = app.state.GetNumAliens()
+		app.publish(model.Event{Type: model.EventTick, AliveAliens: numAliens, RemainingCities: app.state.GetNumCities()})
+		app.mu.Unlock()
 		if err != nil {
 			app.log.Warnw("making move", "move #", fmt.Sprint(moves), "error", err.Error())
 		}
-		aliens := app.state.GetAliens()
-		if moves > app.cfg.MaxMoves || len(aliens) == 0 {
-			// save final map and exit
-			finalMapFile := fmt.Sprintf("%s.map", time.Now().Format(time.RFC3339))
-			err = app.state.Save(finalMapFile)
-			if err != nil {
-				app.log.Errorw("writing final map", "filename", finalMapFile, "error", err.Error())
+		if app.cfg.CheckpointEvery > 0 && moves%app.cfg.CheckpointEvery == 0 {
+			app.saveCheckpoint()
+		}
+		if moves > app.cfg.MaxMoves || numAliens == 0 {
+			reason := "max moves reached"
+			if numAliens == 0 {
+				reason = "all aliens destroyed"
 			}
+			app.mu.Lock()
+			app.status = model.StatusFinished
+			app.publish(model.Event{Type: model.EventEnd, AliveAliens: numAliens, RemainingCities: app.state.GetNumCities(), Reason: reason})
+			app.mu.Unlock()
+			app.finish(reason)
 			return
 		}
 	}
 }
 
+// commit persists the state of the current tick if the world adapter supports it (mu must be held)
+func (app *AlienInvasionApp) commit() {
+	committer, ok := app.state.(world.Committer)
+	if !ok {
+		return
+	}
+	if err := committer.Commit(app.tick); err != nil {
+		app.log.Warnw("committing tick", "tick", fmt.Sprint(app.tick), "error", err.Error())
+	}
+}
+
+// abort ends the main loop on an error, keeping the world as it was for inspection (mu must be held, it is released)
+func (app *AlienInvasionApp) abort(err error) {
+	app.err = err
+	app.status = model.StatusFinished
+	app.publish(model.Event{Type: model.EventEnd, AliveAliens: app.state.GetNumAliens(), RemainingCities: app.state.GetNumCities(), Reason: "aborted"})
+	app.log.Errorw("aborting simulation", "tick", fmt.Sprint(app.tick), "error", err.Error())
+	app.mu.Unlock()
+	app.finish("aborted")
+}
+
+// finish writes the final map and the summary report unless disabled
+func (app *AlienInvasionApp) finish(reason string) {
+	if app.cfg.NoFinalMap {
+		return
+	}
+	if err := app.writeOutputs(reason); err != nil {
+		app.log.Errorw("writing final map", "error", err.Error())
+	}
+}
+
 func (app *AlienInvasionApp) makeMove() error {
-	// move aliens
-	aliens := app.state.GetAliens()
-	for _, alien := range aliens {
+	// move aliens, in ID order so runs are reproduced from their seed
+	err := app.state.ForEachAlien(func(alien *model.Alien) error {
 		exits, err := app.state.GetExits(alien.City)
 		if err != nil {
 			app.log.Warnw("getting exits ", "cityID", alien.City, "error", err.Error())
-			continue
+			return nil
 		}
 		numExits := len(exits)
 		if numExits > 0 {
 			// get next move
-			moveIndex := getRandomInRange(0, numExits)
+			moveIndex := getRandomInRange(app.rnd, 0, numExits)
 			// if random index is exactly numExits will not move this turn
 			if moveIndex != numExits {
+				from := alien.City
 				err := app.state.MoveAlien(alien.ID, exits[moveIndex])
 				if err != nil {
 					app.log.Warnw("moving alien", "error", err.Error())
-					continue
+					return nil
 				}
+				app.moves++
+				app.stats.Visit(exits[moveIndex])
+				app.publish(model.Event{Type: model.EventMove, AlienID: alien.ID, From: from, To: exits[moveIndex]})
 			}
 		}
+		return nil
+	})
+	if err != nil {
+		return err
 	}
 
-	// check fights
-	aliensByCity := app.state.GetAllAliensByCity()
-	for cityID, aliensMap := range aliensByCity {
-		if len(aliensMap) > 1 {
-			fightCity, _ := app.state.GetCityByID(cityID)
-			app.log.Infow("Fight !!", "city", fightCity.Name, "aliens", len(aliensMap))
-			err := app.state.RemoveCity(cityID)
-			if err != nil {
-				app.log.Warnw("removing city", "error", err.Error())
-			}
+	// check fights on the cities with more than one alien
+	aliensAt := make(map[int]int)
+	err = app.state.ForEachAlien(func(alien *model.Alien) error {
+		aliensAt[alien.City]++
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	cityIDs := make([]int, 0)
+	for cityID, n := range aliensAt {
+		if n > 1 {
+			cityIDs = append(cityIDs, cityID)
 		}
 	}
+	sort.Ints(cityIDs)
+	for _, cityID := range cityIDs {
+		aliensMap, err := app.state.GetAliensByCity(cityID)
+		if err != nil {
+			app.log.Warnw("getting fight aliens", "cityID", cityID, "error", err.Error())
+			continue
+		}
+		fightCity, err := app.state.GetCityByID(cityID)
+		if err != nil {
+			app.log.Warnw("getting fight city", "cityID", cityID, "error", err.Error())
+			continue
+		}
+		app.log.Infow("Fight !!", "city", fightCity.Name, "aliens", len(aliensMap))
+		app.recordDestruction(fightCity, aliensMap)
+		err = app.state.RemoveCity(cityID)
+		if err != nil {
+			app.log.Warnw("removing city", "error", err.Error())
+			continue
+		}
+		killed := make([]int, 0, len(aliensMap))
+		for alienID := range aliensMap {
+			killed = append(killed, alienID)
+		}
+		sort.Ints(killed)
+		app.publish(model.Event{Type: model.EventDestroy, CityID: cityID, CityName: fightCity.Name, Aliens: killed})
+	}
 	return nil
 }
+
+// recordDestruction keeps a city destroyed by a fight before removing it from the world (mu must be held)
+func (app *AlienInvasionApp) recordDestruction(city *model.City, attackers map[int]*model.Alien) {
+	app.fights++
+	app.stats.Fight(city.ID, app.tick, len(attackers))
+	destroyed := *city
+	destroyed.Destroyed = true
+	app.destroyedCities = append(app.destroyedCities, &destroyed)
+	destruction := &model.Destruction{Tick: app.tick, CityID: city.ID, CityName: city.Name}
+	for _, alien := range attackers {
+		a := *alien
+		destruction.Attackers = append(destruction.Attackers, &a)
+	}
+	sort.Slice(destruction.Attackers, func(i, j int) bool {
+		return destruction.Attackers[i].ID < destruction.Attackers[j].ID
+	})
+	app.destructions = append(app.destructions, destruction)
+}
@@ -0,0 +1,60 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+
+	"github.com/c-kuroki/alien_invasion/pkg/adapters/renderer"
+	"github.com/c-kuroki/alien_invasion/pkg/adapters/world"
+	"github.com/c-kuroki/alien_invasion/pkg/model"
+)
+
+type AppTestSuite struct {
+	suite.Suite
+}
+
+func (suite *AppTestSuite) TestRenderOverlay() {
+	var b bytes.Buffer
+	suite.Require().NoError(GenerateMap(GenerateConfig{Width: 10, Height: 10, Roads: 0.5, Seed: 1}, &b))
+	cfg := &model.Config{MaxMoves: 50, NumAliens: 40, Seed: 3, NoFinalMap: true}
+	invasion := NewAlienInvasionApp(cfg, world.NewInMemoryStateFromReader(&b), renderer.NewSVGRenderer(), zap.NewNop().Sugar())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		invasion.Start()
+	}()
+	// overlays are rendered while the map is loaded and the aliens move
+	for running := true; running; {
+		select {
+		case <-done:
+			running = false
+		default:
+		}
+		suite.Require().NoError(invasion.RenderOverlay(context.Background(), renderer.OverlayFights, io.Discard))
+	}
+	suite.Require().NoError(invasion.Err())
+
+	// fights count the aliens of each destruction
+	stats := invasion.Stats()
+	summary := invasion.Summary("")
+	suite.Require().NotEmpty(summary.Destroyed)
+	for _, destruction := range summary.Destroyed {
+		cs := stats.Cities[destruction.CityID]
+		suite.Require().NotNil(cs)
+		suite.Assert().Equal(len(destruction.Attackers), cs.Fights)
+		suite.Assert().Equal(1, cs.Destructions)
+		tick, ok := cs.TimeToDestruction()
+		suite.Assert().True(ok)
+		suite.Assert().Equal(float64(destruction.Tick), tick)
+	}
+}
+
+// TestAppTestSuite is the entry point of this test suite
+func TestAppTestSuite(t *testing.T) {
+	suite.Run(t, new(AppTestSuite))
+}
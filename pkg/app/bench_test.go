@@ -0,0 +1,74 @@
+package app
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"testing"
+
+	"go.uber.org/zap"
+
+	"github.com/c-kuroki/alien_invasion/pkg/adapters/renderer"
+	"github.com/c-kuroki/alien_invasion/pkg/adapters/world"
+	"github.com/c-kuroki/alien_invasion/pkg/model"
+)
+
+// benchMaps caches the generated maps by number of cities
+var benchMaps = map[int][]byte{}
+
+// benchApp returns a simulation with its aliens placed on a generated square map
+func benchApp(b *testing.B, numCities, numAliens int) *AlienInvasionApp {
+	content, ok := benchMaps[numCities]
+	if !ok {
+		side := int(math.Sqrt(float64(numCities)))
+		var buf bytes.Buffer
+		if err := GenerateMap(GenerateConfig{Width: side, Height: side, Roads: 0.3, Seed: 1}, &buf); err != nil {
+			b.Fatal(err)
+		}
+		content = buf.Bytes()
+		benchMaps[numCities] = content
+	}
+	// events are not kept, as no stream reads them
+	cfg := &model.Config{NumAliens: numAliens, Seed: 1, NoFinalMap: true, EventTicks: -1}
+	state := world.NewInMemoryStateFromReader(bytes.NewReader(content))
+	invasion := NewAlienInvasionApp(cfg, state, renderer.NewSVGRenderer(), zap.NewNop().Sugar())
+	if err := invasion.Init(); err != nil {
+		b.Fatal(err)
+	}
+	return invasion
+}
+
+func BenchmarkMakeMove(b *testing.B) {
+	benchCases := []struct {
+		cities int
+		aliens int
+	}{
+		{100, 10},
+		{10_000, 10},
+		{10_000, 1_000},
+		{10_000, 100_000},
+		{1_000_000, 1_000_000},
+	}
+	for _, bc := range benchCases {
+		b.Run(fmt.Sprintf("cities=%d/aliens=%d", bc.cities, bc.aliens), func(b *testing.B) {
+			if testing.Short() && (bc.cities > 10_000 || bc.aliens > 10_000) {
+				b.Skip("skipping large simulation on short mode")
+			}
+			invasion := benchApp(b, bc.cities, bc.aliens)
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				// the simulation starts again once fights killed half of its aliens
+				if len(invasion.state.GetAliens()) < bc.aliens/2 {
+					b.StopTimer()
+					invasion = benchApp(b, bc.cities, bc.aliens)
+					b.StartTimer()
+				}
+				invasion.tick++
+				if err := invasion.makeMove(); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
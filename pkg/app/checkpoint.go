@@ -0,0 +1,121 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/c-kuroki/alien_invasion/pkg/adapters/renderer"
+	"github.com/c-kuroki/alien_invasion/pkg/adapters/world"
+	"github.com/c-kuroki/alien_invasion/pkg/logger"
+	"github.com/c-kuroki/alien_invasion/pkg/model"
+)
+
+// NewAlienInvasionAppFromCheckpoint returns a simulation restored from a checkpoint, MainLoop
+// continues it from the checkpoint tick (Init must not be called)
+func NewAlienInvasionAppFromCheckpoint(cfg *model.Config, cp *model.Checkpoint, renderer renderer.Adapter, log logger.Logger) *AlienInvasionApp {
+	state := world.NewInMemoryStateFromCities(cp.Width, cp.Height, cp.Cities, cp.Aliens)
+	app := NewAlienInvasionApp(cfg, state, renderer, log)
+	app.rnd, app.src = newSimulationRand(cp.RandState)
+	app.seed = cp.Seed
+	app.tick = cp.Tick
+	app.initialCities = cp.InitialCities
+	app.destroyedCities = cp.DestroyedCities
+	app.destructions = cp.Destructions
+	if cp.Stats != nil {
+		app.stats = cp.Stats
+	}
+	app.moves = cp.Moves
+	app.fights = cp.Fights
+	app.events.restore(cp.Events, cp.Tick)
+	app.status = model.StatusRunning
+	return app
+}
+
+// checkpoint returns the encoded state of the simulation (mu must be held)
+func (app *AlienInvasionApp) checkpoint() ([]byte, error) {
+	cp := &model.Checkpoint{
+		Version:         model.CheckpointVersion,
+		Config:          *app.cfg,
+		Time:            app.clock.Now(),
+		Tick:            app.tick,
+		Seed:            app.seed,
+		RandState:       app.src.state,
+		Width:           app.state.GetWidth(),
+		Height:          app.state.GetHeight(),
+		Cities:          app.state.GetAllCities(),
+		InitialCities:   app.initialCities,
+		DestroyedCities: app.destroyedCities,
+		Destructions:    app.destructions,
+		Stats:           app.stats,
+		Moves:           app.moves,
+		Fights:          app.fights,
+		Events:          app.events.kept(),
+	}
+	for _, alien := range app.state.GetAliens() {
+		cp.Aliens = append(cp.Aliens, alien)
+	}
+	sort.Slice(cp.Cities, func(i, j int) bool {
+		return cp.Cities[i].ID < cp.Cities[j].ID
+	})
+	sort.Slice(cp.Aliens, func(i, j int) bool {
+		return cp.Aliens[i].ID < cp.Aliens[j].ID
+	})
+	return json.Marshal(cp)
+}
+
+// writeCheckpoint writes an encoded checkpoint, replacing the previous one only once it is complete
+func writeCheckpoint(filename string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(filename), filepath.Base(filename)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), filename)
+}
+
+// ReadCheckpoint reads a checkpoint written by a run
+func ReadCheckpoint(filename string) (*model.Checkpoint, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	cp := &model.Checkpoint{}
+	if err := json.Unmarshal(data, cp); err != nil {
+		return nil, fmt.Errorf("invalid checkpoint %s: %w", filename, err)
+	}
+	if cp.Version != model.CheckpointVersion {
+		return nil, fmt.Errorf("invalid checkpoint %s: unsupported version %d", filename, cp.Version)
+	}
+	return cp, nil
+}
+
+// saveCheckpoint writes a checkpoint of the current tick if configured
+func (app *AlienInvasionApp) saveCheckpoint() {
+	if app.cfg.CheckpointFile == "" {
+		return
+	}
+	app.mu.RLock()
+	data, err := app.checkpoint()
+	tick := app.tick
+	app.mu.RUnlock()
+	if err == nil {
+		err = writeCheckpoint(app.cfg.CheckpointFile, data)
+	}
+	if err != nil {
+		app.log.Warnw("writing checkpoint", "tick", fmt.Sprint(tick), "error", err.Error())
+	}
+}
@@ -0,0 +1,104 @@
+package app
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+
+	"github.com/c-kuroki/alien_invasion/pkg/adapters/renderer"
+	"github.com/c-kuroki/alien_invasion/pkg/adapters/world"
+	"github.com/c-kuroki/alien_invasion/pkg/model"
+)
+
+// ticks of the checkpoint and of the whole run, there is a single checkpoint written
+const (
+	checkpointTick = 20
+	maxMoves       = 30
+)
+
+type CheckpointTestSuite struct {
+	suite.Suite
+	mapContent []byte
+	cfg        *model.Config
+}
+
+func (suite *CheckpointTestSuite) SetupTest() {
+	var b bytes.Buffer
+	suite.Require().NoError(GenerateMap(GenerateConfig{Width: 10, Height: 10, Roads: 0.5, Seed: 1}, &b))
+	suite.mapContent = b.Bytes()
+	suite.cfg = &model.Config{
+		MaxMoves:        maxMoves,
+		NumAliens:       10,
+		Seed:            7,
+		NoFinalMap:      true,
+		CheckpointFile:  filepath.Join(suite.T().TempDir(), "checkpoint.json"),
+		CheckpointEvery: checkpointTick,
+	}
+}
+
+// run runs a whole simulation, writing a checkpoint at checkpointTick
+func (suite *CheckpointTestSuite) run() *AlienInvasionApp {
+	state := world.NewInMemoryStateFromReader(bytes.NewReader(suite.mapContent))
+	invasion := NewAlienInvasionApp(suite.cfg, state, renderer.NewSVGRenderer(), zap.NewNop().Sugar())
+	suite.Require().NoError(invasion.Init())
+	invasion.MainLoop()
+	suite.Require().NoError(invasion.Err())
+	return invasion
+}
+
+// resume continues the simulation from its checkpoint
+func (suite *CheckpointTestSuite) resume() *AlienInvasionApp {
+	cp, err := ReadCheckpoint(suite.cfg.CheckpointFile)
+	suite.Require().NoError(err)
+	suite.Require().Equal(checkpointTick, cp.Tick)
+	invasion := NewAlienInvasionAppFromCheckpoint(suite.cfg, cp, renderer.NewSVGRenderer(), zap.NewNop().Sugar())
+	invasion.MainLoop()
+	suite.Require().NoError(invasion.Err())
+	return invasion
+}
+
+func (suite *CheckpointTestSuite) events(invasion *AlienInvasionApp, from int) []model.Event {
+	events, _, cancel, err := invasion.Subscribe(from)
+	suite.Require().NoError(err)
+	cancel()
+	return events
+}
+
+func (suite *CheckpointTestSuite) TestResume() {
+	uninterrupted := suite.run()
+	// the aliens survive the checkpoint
+	suite.Require().Equal(maxMoves+1, uninterrupted.Tick())
+	resumed := suite.resume()
+
+	suite.Assert().Equal(uninterrupted.Snapshot(), resumed.Snapshot())
+	suite.Assert().Equal(uninterrupted.Stats(), resumed.Stats())
+	suite.Assert().Equal(uninterrupted.Summary("end"), resumed.Summary("end"))
+	suite.Assert().Equal(uninterrupted.Metrics().Moves, resumed.Metrics().Moves)
+	// the events of the ticks before the checkpoint are restored
+	suite.Assert().Equal(suite.events(uninterrupted, 0), suite.events(resumed, 0))
+}
+
+func (suite *CheckpointTestSuite) TestEventTicks() {
+	suite.cfg.EventTicks = 5
+	uninterrupted := suite.run()
+	resumed := suite.resume()
+	from := maxMoves + 1 - 4
+	suite.Assert().Equal(suite.events(uninterrupted, from), suite.events(resumed, from))
+	_, _, _, err := resumed.Subscribe(checkpointTick - 5)
+	suite.Assert().ErrorIs(err, ErrEventsExpired)
+
+	// a checkpoint without events can not resume streams from its past ticks
+	suite.cfg.EventTicks = -1
+	suite.run()
+	resumed = suite.resume()
+	_, _, _, err = resumed.Subscribe(checkpointTick)
+	suite.Assert().ErrorIs(err, ErrEventsExpired)
+}
+
+// TestCheckpointTestSuite is the entry point of this test suite
+func TestCheckpointTestSuite(t *testing.T) {
+	suite.Run(t, new(CheckpointTestSuite))
+}
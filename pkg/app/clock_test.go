@@ -0,0 +1,160 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+
+	"github.com/c-kuroki/alien_invasion/pkg/adapters/renderer"
+	"github.com/c-kuroki/alien_invasion/pkg/adapters/world"
+	"github.com/c-kuroki/alien_invasion/pkg/clock"
+	"github.com/c-kuroki/alien_invasion/pkg/model"
+)
+
+const (
+	exampleMapFile = "../../examples/world.map"
+	tickInterval   = 100 * time.Millisecond
+)
+
+// ClockTestSuite drives simulations tick by tick with a fake clock
+type ClockTestSuite struct {
+	suite.Suite
+	start    time.Time
+	clock    *clock.Fake
+	dir      string
+	cfg      *model.Config
+	invasion *AlienInvasionApp
+	events   <-chan model.Event
+	done     chan struct{}
+}
+
+func (suite *ClockTestSuite) SetupTest() {
+	suite.start = time.Date(2022, 11, 22, 12, 53, 16, 0, time.UTC)
+	suite.clock = clock.NewFake(suite.start)
+	suite.dir = suite.T().TempDir()
+	// a single alien never fights, so the run lasts MaxMoves+1 ticks
+	suite.cfg = &model.Config{
+		MapFilename:     exampleMapFile,
+		TickInterval:    int(tickInterval / time.Millisecond),
+		MaxMoves:        5,
+		NumAliens:       1,
+		Seed:            1,
+		CheckpointFile:  filepath.Join(suite.dir, "checkpoint.json"),
+		CheckpointEvery: 2,
+		Output:          model.OutputConfig{Dir: suite.dir, Report: ReportNone},
+	}
+}
+
+// startLoop runs the main loop once the app is set up by init
+func (suite *ClockTestSuite) startLoop(init func(*AlienInvasionApp)) {
+	suite.invasion = NewAlienInvasionApp(suite.cfg, world.NewInMemoryState(suite.cfg.MapFilename), renderer.NewSVGRenderer(), zap.NewNop().Sugar())
+	suite.invasion.SetClock(suite.clock)
+	if init != nil {
+		init(suite.invasion)
+	}
+	suite.Require().NoError(suite.invasion.Init())
+	var cancel func()
+	var err error
+	_, suite.events, cancel, err = suite.invasion.Subscribe(0)
+	suite.Require().NoError(err)
+	suite.T().Cleanup(cancel)
+	suite.done = make(chan struct{})
+	go func() {
+		defer close(suite.done)
+		suite.invasion.MainLoop()
+	}()
+	// wait for the loop ticker or its paused sleep
+	suite.clock.BlockUntil(1)
+}
+
+// waitFor returns the next event of a type
+func (suite *ClockTestSuite) waitFor(eventType string) model.Event {
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case event, ok := <-suite.events:
+			suite.Require().True(ok, "events subscription closed")
+			if event.Type == eventType {
+				return event
+			}
+		case <-timeout:
+			suite.FailNow("timeout waiting for event", eventType)
+		}
+	}
+}
+
+func (suite *ClockTestSuite) TestTickByTick() {
+	suite.startLoop(nil)
+	for tick := 1; tick <= suite.cfg.MaxMoves; tick++ {
+		moves := suite.invasion.Metrics().Moves
+		// a tick is made only once its interval has passed
+		suite.clock.Advance(tickInterval - time.Millisecond)
+		suite.clock.Advance(time.Millisecond)
+		event := suite.waitFor(model.EventTick)
+		suite.Require().Equal(tick, event.Tick)
+		suite.Require().Equal(tick, suite.invasion.Tick())
+		metrics := suite.invasion.Metrics()
+		suite.Assert().Equal(float64(metrics.Moves-moves)/tickInterval.Seconds(), metrics.MovesPerSecond)
+	}
+
+	// checkpoints are stamped with the clock time
+	cp, err := ReadCheckpoint(suite.cfg.CheckpointFile)
+	suite.Require().NoError(err)
+	suite.Assert().Equal(4, cp.Tick)
+	suite.Assert().True(suite.start.Add(4*tickInterval).Equal(cp.Time), "checkpoint time %s", cp.Time)
+
+	suite.clock.Advance(tickInterval)
+	event := suite.waitFor(model.EventEnd)
+	suite.Assert().Equal("max moves reached", event.Reason)
+	<-suite.done
+
+	// the final map is named after the clock time
+	finished := suite.start.Add(time.Duration(suite.cfg.MaxMoves+1) * tickInterval)
+	_, err = os.Stat(filepath.Join(suite.dir, "final-"+finished.Format(outputTimeFormat)+".map"))
+	suite.Assert().NoError(err)
+}
+
+func (suite *ClockTestSuite) TestPaused() {
+	suite.startLoop(func(invasion *AlienInvasionApp) {
+		suite.Require().NoError(invasion.Pause())
+	})
+	for i := 0; i < 3; i++ {
+		suite.clock.Advance(tickInterval)
+	}
+	suite.Require().NoError(suite.invasion.Step())
+	suite.clock.Advance(tickInterval)
+	suite.Assert().Equal(1, suite.waitFor(model.EventTick).Tick)
+
+	suite.invasion.Stop()
+	suite.Assert().Equal("stopped", suite.waitFor(model.EventEnd).Reason)
+	<-suite.done
+	suite.Assert().Equal(1, suite.invasion.Tick())
+}
+
+func (suite *ClockTestSuite) TestPausedWithoutInterval() {
+	suite.cfg.TickInterval = 0
+	suite.startLoop(func(invasion *AlienInvasionApp) {
+		suite.Require().NoError(invasion.Pause())
+	})
+	// the paused loop sleeps on the clock instead of spinning
+	for i := 0; i < 3; i++ {
+		suite.clock.Advance(pausedPoll)
+		suite.clock.BlockUntil(1)
+	}
+	suite.Assert().Equal(0, suite.invasion.Tick())
+
+	suite.Require().NoError(suite.invasion.Resume())
+	suite.clock.Advance(pausedPoll)
+	suite.Assert().Equal("max moves reached", suite.waitFor(model.EventEnd).Reason)
+	<-suite.done
+	suite.Assert().Equal(suite.cfg.MaxMoves+1, suite.invasion.Tick())
+}
+
+// TestClockTestSuite is the entry point of this test suite
+func TestClockTestSuite(t *testing.T) {
+	suite.Run(t, new(ClockTestSuite))
+}
@@ -0,0 +1,107 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+
+	"github.com/c-kuroki/alien_invasion/pkg/adapters/renderer"
+	"github.com/c-kuroki/alien_invasion/pkg/adapters/world"
+	"github.com/c-kuroki/alien_invasion/pkg/model"
+)
+
+type DBTestSuite struct {
+	suite.Suite
+	mapFile string
+	dbFile  string
+}
+
+func (suite *DBTestSuite) SetupTest() {
+	dir := suite.T().TempDir()
+	suite.mapFile = filepath.Join(dir, "world.map")
+	suite.dbFile = filepath.Join(dir, "world.db")
+	f, err := os.Create(suite.mapFile)
+	suite.Require().NoError(err)
+	defer f.Close()
+	suite.Require().NoError(GenerateMap(GenerateConfig{Width: 10, Height: 10, Roads: 0.5, Seed: 1}, f))
+}
+
+// run runs a simulation on the database, resuming it if it has a committed tick
+func (suite *DBTestSuite) run(cfg *model.Config) *AlienInvasionApp {
+	db, err := world.NewBoltState(suite.dbFile, suite.mapFile, zap.NewNop().Sugar())
+	suite.Require().NoError(err)
+	defer db.Close()
+	invasion := NewAlienInvasionApp(cfg, db, renderer.NewSVGRenderer(), zap.NewNop().Sugar())
+	suite.Require().NoError(invasion.Init())
+	invasion.MainLoop()
+	suite.Require().NoError(invasion.Err())
+	return invasion
+}
+
+func (suite *DBTestSuite) TestResume() {
+	cfg := &model.Config{MaxMoves: 3, NumAliens: 60, Seed: 7, NoFinalMap: true}
+	interrupted := suite.run(cfg)
+	before := interrupted.Summary("")
+	suite.Require().NotEmpty(before.Destroyed)
+
+	cfg.MaxMoves = 8
+	resumed := suite.run(cfg)
+	suite.Assert().Equal(cfg.MaxMoves+1, resumed.Tick())
+	// the world continues from the committed tick, but the cities destroyed before and the
+	// stats of the previous ticks are not kept on the database
+	snapshot := resumed.Snapshot()
+	stats := resumed.Stats()
+	for _, destruction := range before.Destroyed {
+		for _, city := range snapshot.Cities {
+			suite.Assert().NotEqual(destruction.CityID, city.ID)
+		}
+		suite.Assert().NotContains(stats.Cities, destruction.CityID)
+	}
+	for _, destruction := range resumed.Summary("").Destroyed {
+		suite.Assert().Greater(destruction.Tick, interrupted.Tick())
+	}
+}
+
+func (suite *DBTestSuite) TestQueries() {
+	db, err := world.NewBoltState(suite.dbFile, suite.mapFile, zap.NewNop().Sugar())
+	suite.Require().NoError(err)
+	defer db.Close()
+	invasion := NewAlienInvasionApp(&model.Config{MaxMoves: 3, NumAliens: 60, Seed: 7, NoFinalMap: true}, db, renderer.NewSVGRenderer(), zap.NewNop().Sugar())
+	suite.Require().NoError(invasion.Init())
+	invasion.MainLoop()
+	suite.Require().NoError(invasion.Err())
+
+	// cities are looked up on the database, destroyed ones on the destroyed cities
+	destroyed := invasion.Summary("").Destroyed
+	suite.Require().NotEmpty(destroyed)
+	city, err := invasion.City(destroyed[0].CityID)
+	suite.Require().NoError(err)
+	suite.Assert().True(city.Destroyed)
+	byName, err := invasion.CityByName(city.Name)
+	suite.Require().NoError(err)
+	suite.Assert().Equal(city, byName)
+	exits, err := invasion.Exits(city.ID)
+	suite.Require().NoError(err)
+	suite.Assert().Empty(exits)
+
+	alive := db.GetAllCities()[0]
+	city, err = invasion.CityByName(alive.Name)
+	suite.Require().NoError(err)
+	suite.Assert().Equal(alive, city)
+	exits, err = invasion.Exits(alive.ID)
+	suite.Require().NoError(err)
+	suite.Assert().Len(exits, len(alive.Roads()))
+
+	_, err = invasion.City(1000)
+	suite.Assert().ErrorIs(err, ErrNotFound)
+	_, err = invasion.CityByName("Nowhere")
+	suite.Assert().ErrorIs(err, ErrNotFound)
+}
+
+// TestDBTestSuite is the entry point of this test suite
+func TestDBTestSuite(t *testing.T) {
+	suite.Run(t, new(DBTestSuite))
+}
@@ -0,0 +1,182 @@
+package app
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/c-kuroki/alien_invasion/pkg/model"
+)
+
+// size of the buffer of each subscriber, slow subscribers are dropped when it is full
+const subscriberBuffer = 1024
+
+// DefaultEventTicks is the number of ticks of events kept to resume streams
+const DefaultEventTicks = 100
+
+// ErrEventsExpired is returned when resuming from a tick whose events are not kept anymore
+var ErrEventsExpired = errors.New("events of the tick are not kept anymore")
+
+type subscriber struct {
+	ch chan model.Event
+}
+
+// flusher is implemented by buffered writers (e.g. bufio.Writer)
+type flusher interface {
+	Flush() error
+}
+
+// eventLog keeps the events of the last ticks on a ring indexed by tick
+type eventLog struct {
+	ticks [][]model.Event
+	// first and last ticks on the ring, first > last while it is empty
+	first, last int
+	// dropped is set once the events of a tick are discarded
+	dropped bool
+}
+
+// newEventLog returns a log of the events of size ticks (0 keeps none)
+func newEventLog(size int) *eventLog {
+	return &eventLog{ticks: make([][]model.Event, size), first: 0, last: -1}
+}
+
+// add logs an event, events are added in tick order
+func (l *eventLog) add(event model.Event) {
+	size := len(l.ticks)
+	if size == 0 {
+		l.first, l.last, l.dropped = event.Tick+1, event.Tick, true
+		return
+	}
+	if l.first > l.last {
+		l.first, l.last = event.Tick, event.Tick-1
+	}
+	for l.last < event.Tick {
+		l.last++
+		// slots are reused, backlogs are copies
+		l.ticks[l.last%size] = l.ticks[l.last%size][:0]
+		if l.last-l.first >= size {
+			l.first = l.last - size + 1
+			l.dropped = true
+		}
+	}
+	l.ticks[event.Tick%size] = append(l.ticks[event.Tick%size], event)
+}
+
+// kept returns a copy of all the logged events
+func (l *eventLog) kept() []model.Event {
+	events, _ := l.since(l.first)
+	return events
+}
+
+// restore logs the events kept by a checkpoint of a tick, older ticks are reported as expired.
+// End events are skipped, a restored simulation goes on
+func (l *eventLog) restore(events []model.Event, tick int) {
+	for _, event := range events {
+		if event.Type != model.EventEnd {
+			l.add(event)
+		}
+	}
+	if l.first > l.last {
+		l.first, l.last = tick+1, tick
+	}
+	if l.first > 0 {
+		l.dropped = true
+	}
+}
+
+// since returns a copy of the logged events from a tick (inclusive)
+func (l *eventLog) since(from int) ([]model.Event, error) {
+	if l.dropped && from < l.first {
+		return nil, fmt.Errorf("%w: tick %d is before the first kept tick %d", ErrEventsExpired, from, l.first)
+	}
+	if from < l.first {
+		from = l.first
+	}
+	var events []model.Event
+	for tick := from; tick <= l.last; tick++ {
+		events = append(events, l.ticks[tick%len(l.ticks)]...)
+	}
+	return events, nil
+}
+
+// Subscribe returns the logged events since fromTick (inclusive) and a channel receiving new events.
+// The channel is closed when cancel is called or when the subscriber is too slow to keep up,
+// in that case it can subscribe again from its last complete tick. Only the events of the last
+// ticks are kept (see model.Config.EventTicks), older ticks return ErrEventsExpired
+func (app *AlienInvasionApp) Subscribe(fromTick int) ([]model.Event, <-chan model.Event, func(), error) {
+	app.mu.Lock()
+	defer app.mu.Unlock()
+	backlog, err := app.events.since(fromTick)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	// the end event of a finished simulation was already published, it ends every backlog so
+	// streams subscribing after it (e.g. from a later tick) are closed
+	if app.status == model.StatusFinished && (len(backlog) == 0 || backlog[len(backlog)-1].Type != model.EventEnd) {
+		end := model.Event{Type: model.EventEnd, Tick: app.tick, Reason: "finished"}
+		if app.end != nil {
+			end = *app.end
+		}
+		backlog = append(backlog, end)
+	}
+	sub := &subscriber{ch: make(chan model.Event, subscriberBuffer)}
+	if app.subscribers == nil {
+		app.subscribers = make(map[*subscriber]bool)
+	}
+	app.subscribers[sub] = true
+	cancel := func() {
+		app.mu.Lock()
+		defer app.mu.Unlock()
+		app.unsubscribe(sub)
+	}
+	return backlog, sub.ch, cancel, nil
+}
+
+// SetEventsWriter records every event as a JSON line on w, which is flushed after each tick if it
+// has a Flush method. It must be called before Init, write errors stop the recording
+func (app *AlienInvasionApp) SetEventsWriter(w io.Writer) {
+	app.eventsOut = w
+	app.eventsEnc = json.NewEncoder(w)
+}
+
+// unsubscribe closes a subscriber channel (mu must be held)
+func (app *AlienInvasionApp) unsubscribe(sub *subscriber) {
+	if app.subscribers[sub] {
+		delete(app.subscribers, sub)
+		close(sub.ch)
+	}
+}
+
+// publish logs and records an event and sends it to subscribers (mu must be held)
+func (app *AlienInvasionApp) publish(event model.Event) {
+	event.Tick = app.tick
+	if event.Type == model.EventEnd {
+		app.end = &event
+	}
+	app.events.add(event)
+	app.record(event)
+	for sub := range app.subscribers {
+		select {
+		case sub.ch <- event:
+		default:
+			app.log.Warnw("dropping slow events subscriber", "tick", app.tick)
+			app.unsubscribe(sub)
+		}
+	}
+}
+
+// record writes an event on the events writer if set (mu must be held)
+func (app *AlienInvasionApp) record(event model.Event) {
+	if app.eventsEnc == nil {
+		return
+	}
+	err := app.eventsEnc.Encode(event)
+	if f, ok := app.eventsOut.(flusher); ok && err == nil && (event.Type == model.EventTick || event.Type == model.EventEnd) {
+		err = f.Flush()
+	}
+	if err != nil {
+		app.log.Errorw("recording events", "tick", fmt.Sprint(app.tick), "error", err.Error())
+		app.eventsEnc = nil
+	}
+}
@@ -0,0 +1,141 @@
+package app
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+
+	"github.com/c-kuroki/alien_invasion/pkg/adapters/renderer"
+	"github.com/c-kuroki/alien_invasion/pkg/adapters/world"
+	"github.com/c-kuroki/alien_invasion/pkg/model"
+)
+
+type EventsTestSuite struct {
+	suite.Suite
+}
+
+// ticks returns the ticks of events
+func ticks(events []model.Event) []int {
+	var ticks []int
+	for _, event := range events {
+		ticks = append(ticks, event.Tick)
+	}
+	return ticks
+}
+
+func (suite *EventsTestSuite) TestEventLog() {
+	log := newEventLog(3)
+	events, err := log.since(0)
+	suite.Require().NoError(err)
+	suite.Assert().Empty(events)
+
+	for tick := 0; tick < 3; tick++ {
+		log.add(model.Event{Tick: tick, Type: model.EventMove})
+		log.add(model.Event{Tick: tick, Type: model.EventTick})
+	}
+	events, err = log.since(0)
+	suite.Require().NoError(err)
+	suite.Assert().Equal([]int{0, 0, 1, 1, 2, 2}, ticks(events))
+
+	// the oldest tick is dropped, and can not be resumed from anymore
+	log.add(model.Event{Tick: 3, Type: model.EventTick})
+	_, err = log.since(0)
+	suite.Assert().ErrorIs(err, ErrEventsExpired)
+	next, err := log.since(4)
+	suite.Require().NoError(err)
+	suite.Assert().Empty(next)
+	events, err = log.since(2)
+	suite.Require().NoError(err)
+	suite.Assert().Equal([]int{2, 2, 3}, ticks(events))
+
+	// backlogs are not changed when slots are reused
+	log.add(model.Event{Tick: 4, Type: model.EventMove})
+	log.add(model.Event{Tick: 5, Type: model.EventMove})
+	suite.Assert().Equal([]int{2, 2, 3}, ticks(events))
+	events, err = log.since(3)
+	suite.Require().NoError(err)
+	suite.Assert().Equal([]int{3, 4, 5}, ticks(events))
+}
+
+func (suite *EventsTestSuite) TestResumedLog() {
+	// a log starting on a resumed tick returns what it has
+	log := newEventLog(3)
+	log.add(model.Event{Tick: 10, Type: model.EventTick})
+	events, err := log.since(0)
+	suite.Require().NoError(err)
+	suite.Assert().Equal([]int{10}, ticks(events))
+}
+
+func (suite *EventsTestSuite) TestNoLog() {
+	log := newEventLog(0)
+	log.add(model.Event{Tick: 0, Type: model.EventSpawn})
+	log.add(model.Event{Tick: 1, Type: model.EventTick})
+	_, err := log.since(1)
+	suite.Assert().ErrorIs(err, ErrEventsExpired)
+	events, err := log.since(2)
+	suite.Require().NoError(err)
+	suite.Assert().Empty(events)
+}
+
+func (suite *EventsTestSuite) TestEventTicks() {
+	// a single alien never fights, so the run lasts MaxMoves+1 ticks
+	cfg := &model.Config{MaxMoves: 20, NumAliens: 1, Seed: 1, NoFinalMap: true, EventTicks: 5}
+	invasion := NewAlienInvasionApp(cfg, world.NewInMemoryState(exampleMapFile), renderer.NewSVGRenderer(), zap.NewNop().Sugar())
+	var recorded bytes.Buffer
+	out := bufio.NewWriter(&recorded)
+	invasion.SetEventsWriter(out)
+	suite.Require().NoError(invasion.Init())
+	invasion.MainLoop()
+	last := invasion.Tick()
+
+	_, _, _, err := invasion.Subscribe(0)
+	suite.Assert().ErrorIs(err, ErrEventsExpired)
+	backlog, _, cancel, err := invasion.Subscribe(last - 4)
+	suite.Require().NoError(err)
+	cancel()
+	suite.Assert().Equal(last-4, backlog[0].Tick)
+	suite.Assert().Equal(model.EventEnd, backlog[len(backlog)-1].Type)
+
+	// the events writer is flushed on every tick and receives the whole run
+	dec := json.NewDecoder(&recorded)
+	var events []model.Event
+	for dec.More() {
+		var event model.Event
+		suite.Require().NoError(dec.Decode(&event))
+		events = append(events, event)
+	}
+	suite.Require().NotEmpty(events)
+	suite.Assert().Equal(model.EventSpawn, events[0].Type)
+	suite.Assert().Equal(backlog, events[len(events)-len(backlog):])
+}
+
+func (suite *EventsTestSuite) TestSlowSubscriber() {
+	cfg := &model.Config{MaxMoves: 10, NumAliens: 1, Seed: 1, NoFinalMap: true}
+	invasion := NewAlienInvasionApp(cfg, world.NewInMemoryState(exampleMapFile), renderer.NewSVGRenderer(), zap.NewNop().Sugar())
+	suite.Require().NoError(invasion.Init())
+	_, slow, cancel, err := invasion.Subscribe(0)
+	suite.Require().NoError(err)
+	defer cancel()
+
+	invasion.mu.Lock()
+	for i := 0; i <= subscriberBuffer; i++ {
+		invasion.publish(model.Event{Type: model.EventTick})
+	}
+	invasion.mu.Unlock()
+	// buffered events are delivered, then the channel is closed
+	received := 0
+	for range slow {
+		received++
+	}
+	suite.Assert().Equal(subscriberBuffer, received)
+	suite.Assert().Empty(invasion.subscribers)
+}
+
+// TestEventsTestSuite is the entry point of this test suite
+func TestEventsTestSuite(t *testing.T) {
+	suite.Run(t, new(EventsTestSuite))
+}
@@ -0,0 +1,146 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// GenerateConfig sets the size and connectivity of generated maps
+type GenerateConfig struct {
+	Width  int
+	Height int
+	// Roads is the probability of adding each road that is not needed to connect all cities
+	Roads float64
+	// Seed initializes the random generator, 0 uses current time
+	Seed int64
+}
+
+var syllables = []string{
+	"ba", "da", "fa", "ga", "ka", "la", "ma", "na", "pa", "ra", "sa", "ta", "va", "xa", "za",
+	"be", "de", "fe", "ge", "ke", "le", "me", "ne", "pe", "re", "se", "te", "ve", "xe", "ze",
+	"bi", "di", "fi", "gi", "ki", "li", "mi", "ni", "pi", "ri", "si", "ti", "vi", "xi", "zi",
+	"bo", "do", "fo", "go", "ko", "lo", "mo", "no", "po", "ro", "so", "to", "vo", "xo", "zo",
+	"bu", "du", "fu", "gu", "ku", "lu", "mu", "nu", "pu", "ru", "su", "tu", "vu", "xu", "zu",
+}
+
+// GenerateMap writes a random map of cities placed on a grid, all cities are connected
+func GenerateMap(cfg GenerateConfig, w io.Writer) error {
+	if cfg.Width < 1 || cfg.Height < 1 {
+		return errors.New("width and height should be greater than 0")
+	}
+	if cfg.Roads < 0 || cfg.Roads > 1 {
+		return errors.New("roads probability should be between 0 and 1")
+	}
+	rnd := newRand(cfg.Seed)
+	numCities := cfg.Width * cfg.Height
+	// east[i] and south[i] tell if city i has a road to its east and south neighbours
+	east := make([]bool, numCities)
+	south := make([]bool, numCities)
+
+	// random spanning tree (randomized depth first search) connects all cities
+	visited := make([]bool, numCities)
+	stack := []int{rnd.Intn(numCities)}
+	visited[stack[0]] = true
+	for len(stack) > 0 {
+		current := stack[len(stack)-1]
+		var next []int
+		for _, n := range gridNeighbours(current, cfg.Width, cfg.Height) {
+			if !visited[n] {
+				next = append(next, n)
+			}
+		}
+		if len(next) == 0 {
+			stack = stack[:len(stack)-1]
+			continue
+		}
+		n := next[rnd.Intn(len(next))]
+		connect(current, n, cfg.Width, east, south)
+		visited[n] = true
+		stack = append(stack, n)
+	}
+	// extra roads
+	for i := 0; i < numCities; i++ {
+		if i%cfg.Width < cfg.Width-1 && !east[i] && rnd.Float64() < cfg.Roads {
+			east[i] = true
+		}
+		if i/cfg.Width < cfg.Height-1 && !south[i] && rnd.Float64() < cfg.Roads {
+			south[i] = true
+		}
+	}
+
+	// unique city names
+	names := make([]string, numCities)
+	used := make(map[string]bool, numCities)
+	for i := range names {
+		length := 2
+		for attempts := 0; ; attempts++ {
+			// longer names when short ones are exhausted
+			if attempts > 0 && attempts%10 == 0 {
+				length++
+			}
+			var b strings.Builder
+			for j := 0; j < length; j++ {
+				b.WriteString(syllables[rnd.Intn(len(syllables))])
+			}
+			name := strings.ToUpper(b.String()[:1]) + b.String()[1:]
+			if !used[name] {
+				used[name] = true
+				names[i] = name
+				break
+			}
+		}
+	}
+
+	for i := 0; i < numCities; i++ {
+		line := names[i]
+		if i >= cfg.Width && south[i-cfg.Width] {
+			line += " north=" + names[i-cfg.Width]
+		}
+		if east[i] {
+			line += " east=" + names[i+1]
+		}
+		if south[i] {
+			line += " south=" + names[i+cfg.Width]
+		}
+		if i%cfg.Width > 0 && east[i-1] {
+			line += " west=" + names[i-1]
+		}
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// gridNeighbours returns the cities next to a city on the grid
+func gridNeighbours(i, width, height int) []int {
+	var neighbours []int
+	x, y := i%width, i/width
+	if y > 0 {
+		neighbours = append(neighbours, i-width)
+	}
+	if x < width-1 {
+		neighbours = append(neighbours, i+1)
+	}
+	if y < height-1 {
+		neighbours = append(neighbours, i+width)
+	}
+	if x > 0 {
+		neighbours = append(neighbours, i-1)
+	}
+	return neighbours
+}
+
+// connect adds a road between two cities next to each other
+func connect(a, b, width int, east, south []bool) {
+	if a > b {
+		a, b = b, a
+	}
+	if b-a == 1 && a/width == b/width {
+		east[a] = true
+	} else {
+		south[a] = true
+	}
+}
@@ -0,0 +1,117 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/c-kuroki/alien_invasion/pkg/adapters/world"
+	"github.com/c-kuroki/alien_invasion/pkg/model"
+)
+
+var (
+	// ErrNoHistory is returned by time travel requests when the world does not keep its history
+	ErrNoHistory = errors.New("simulation history not enabled")
+	// ErrTickNotFound is returned by time travel requests for ticks not committed yet
+	ErrTickNotFound = errors.New("tick not found")
+	// ErrInvalidRange is returned by diffs ending before they start
+	ErrInvalidRange = errors.New("invalid tick range")
+)
+
+// SnapshotAt returns the simulation state as it was after a tick, including the cities destroyed until then
+func (app *AlienInvasionApp) SnapshotAt(tick int) (*model.Snapshot, error) {
+	app.mu.RLock()
+	defer app.mu.RUnlock()
+	return app.snapshotAt(tick)
+}
+
+// Diff returns the changes of the world between two ticks, from can not be after to
+func (app *AlienInvasionApp) Diff(from, to int) (*model.Diff, error) {
+	if from > to {
+		return nil, fmt.Errorf("%w: from tick %d is after to tick %d", ErrInvalidRange, from, to)
+	}
+	app.mu.RLock()
+	defer app.mu.RUnlock()
+	before, err := app.snapshotAt(from)
+	if err != nil {
+		return nil, err
+	}
+	after, err := app.snapshotAt(to)
+	if err != nil {
+		return nil, err
+	}
+	diff := &model.Diff{
+		From:            from,
+		To:              to,
+		DestroyedCities: []*model.City{},
+		MovedAliens:     []*model.AlienMove{},
+		KilledAliens:    []*model.Alien{},
+		SpawnedAliens:   []*model.Alien{},
+	}
+	cities := make(map[int]*model.City, len(after.Cities))
+	for _, city := range after.Cities {
+		cities[city.ID] = city
+	}
+	for _, city := range before.Cities {
+		if c, ok := cities[city.ID]; !city.Destroyed && (!ok || c.Destroyed) {
+			diff.DestroyedCities = append(diff.DestroyedCities, city)
+		}
+	}
+	aliens := make(map[int]*model.Alien, len(before.Aliens))
+	for _, alien := range before.Aliens {
+		aliens[alien.ID] = alien
+	}
+	for _, alien := range after.Aliens {
+		prev, ok := aliens[alien.ID]
+		if !ok {
+			diff.SpawnedAliens = append(diff.SpawnedAliens, alien)
+			continue
+		}
+		delete(aliens, alien.ID)
+		if prev.City != alien.City {
+			diff.MovedAliens = append(diff.MovedAliens, &model.AlienMove{AlienID: alien.ID, Name: alien.Name, From: prev.City, To: alien.City, Moves: alien.Moves - prev.Moves})
+		}
+	}
+	for _, alien := range before.Aliens {
+		if _, ok := aliens[alien.ID]; ok {
+			diff.KilledAliens = append(diff.KilledAliens, alien)
+		}
+	}
+	return diff, nil
+}
+
+// snapshotAt returns the state after a tick from the world history (mu must be held)
+func (app *AlienInvasionApp) snapshotAt(tick int) (*model.Snapshot, error) {
+	traveler, ok := app.state.(world.TimeTraveler)
+	if !ok {
+		return nil, ErrNoHistory
+	}
+	state, err := traveler.StateAt(tick)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrTickNotFound, err.Error())
+	}
+	snapshot := &model.Snapshot{
+		Tick:   tick,
+		Status: app.status,
+		Width:  app.state.GetWidth(),
+		Height: app.state.GetHeight(),
+		Cities: state.GetAllCities(),
+	}
+	// destructions are kept in the same order as destroyed cities
+	for ix, destruction := range app.destructions {
+		if destruction.Tick <= tick {
+			c := *app.destroyedCities[ix]
+			snapshot.Cities = append(snapshot.Cities, &c)
+		}
+	}
+	for _, alien := range state.GetAliens() {
+		snapshot.Aliens = append(snapshot.Aliens, alien)
+	}
+	sort.Slice(snapshot.Cities, func(i, j int) bool {
+		return snapshot.Cities[i].ID < snapshot.Cities[j].ID
+	})
+	sort.Slice(snapshot.Aliens, func(i, j int) bool {
+		return snapshot.Aliens[i].ID < snapshot.Aliens[j].ID
+	})
+	return snapshot, nil
+}
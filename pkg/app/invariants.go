@@ -0,0 +1,169 @@
+package app
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/c-kuroki/alien_invasion/pkg/model"
+)
+
+var opposite = map[string]string{
+	model.North: model.South,
+	model.East:  model.West,
+	model.South: model.North,
+	model.West:  model.East,
+}
+
+// InvariantError is returned when the world breaks an invariant after a tick, on debug mode
+type InvariantError struct {
+	Tick       int
+	Violations []string
+	// Dump is the world when the violations were found
+	Dump string
+}
+
+func (e *InvariantError) Error() string {
+	return fmt.Sprintf("invariants violated after tick %d:\n  %s\n%s", e.Tick, strings.Join(e.Violations, "\n  "), e.Dump)
+}
+
+// Err returns the error that aborted the simulation, nil if it was not aborted
+func (app *AlienInvasionApp) Err() error {
+	app.mu.RLock()
+	defer app.mu.RUnlock()
+	return app.err
+}
+
+// checkInvariants verifies the world after a tick, prevAliens is the number of aliens before it (mu must be held)
+func (app *AlienInvasionApp) checkInvariants(prevAliens int) error {
+	var violations []string
+	violate := func(format string, args ...interface{}) {
+		violations = append(violations, fmt.Sprintf(format, args...))
+	}
+	destroyed := make(map[string]bool, len(app.destroyedCities))
+	for _, city := range app.destroyedCities {
+		destroyed[city.Name] = true
+	}
+
+	// roads are bidirectional and lead to existing cities
+	cities := app.state.GetAllCities()
+	for _, city := range cities {
+		if city.Destroyed || destroyed[city.Name] {
+			violate("destroyed city %s is still in the world", city.Name)
+		}
+		roads := city.Roads()
+		directions := make([]string, 0, len(roads))
+		for direction := range roads {
+			directions = append(directions, direction)
+		}
+		sort.Strings(directions)
+		for _, direction := range directions {
+			name := roads[direction]
+			if destroyed[name] {
+				violate("%s %s road leads to destroyed city %s", city.Name, direction, name)
+				continue
+			}
+			other, err := app.state.GetCityByName(name)
+			if err != nil {
+				violate("%s %s road leads to unknown city %s", city.Name, direction, name)
+				continue
+			}
+			if back := other.Roads()[opposite[direction]]; back != city.Name {
+				violate("%s %s road to %s has no road back (%s road leads to %q)", city.Name, direction, name, opposite[direction], back)
+			}
+		}
+	}
+
+	// aliens by ID and by city agree
+	aliens := app.state.GetAliens()
+	aliensByCity := app.state.GetAllAliensByCity()
+	for _, alienID := range sortedKeys(aliens) {
+		alien := aliens[alienID]
+		if alien.ID != alienID {
+			violate("alien %d is stored as alien %d", alien.ID, alienID)
+		}
+		if _, err := app.state.GetCityByID(alien.City); err != nil {
+			violate("alien %d is on unknown city %d", alienID, alien.City)
+		}
+		if _, ok := aliensByCity[alien.City][alienID]; !ok {
+			violate("alien %d on city %d is not among the aliens of its city", alienID, alien.City)
+		}
+	}
+	cityIDs := make([]int, 0, len(aliensByCity))
+	for cityID := range aliensByCity {
+		cityIDs = append(cityIDs, cityID)
+	}
+	sort.Ints(cityIDs)
+	for _, cityID := range cityIDs {
+		cityAliens := aliensByCity[cityID]
+		for _, alienID := range sortedKeys(cityAliens) {
+			if alien, ok := aliens[alienID]; !ok {
+				violate("alien %d of city %d is not an alive alien", alienID, cityID)
+			} else if alien.City != cityID {
+				violate("alien %d of city %d is on city %d", alienID, cityID, alien.City)
+			}
+		}
+		// fights destroy cities with two or more aliens
+		if len(cityAliens) > 1 {
+			violate("city %d holds %d aliens after the fights", cityID, len(cityAliens))
+		}
+	}
+
+	if len(aliens) > prevAliens {
+		violate("aliens increased from %d to %d", prevAliens, len(aliens))
+	}
+	if len(violations) == 0 {
+		return nil
+	}
+	return &InvariantError{Tick: app.tick, Violations: violations, Dump: app.dump()}
+}
+
+// dump describes the world in detail (mu must be held)
+func (app *AlienInvasionApp) dump() string {
+	var b strings.Builder
+	cities := app.state.GetAllCities()
+	sort.Slice(cities, func(i, j int) bool {
+		return cities[i].ID < cities[j].ID
+	})
+	aliens := app.state.GetAliens()
+	aliensByCity := app.state.GetAllAliensByCity()
+	fmt.Fprintf(&b, "world %dx%d at tick %d: %d cities, %d aliens\n", app.state.GetWidth(), app.state.GetHeight(), app.tick, len(cities), len(aliens))
+	b.WriteString("cities:\n")
+	for _, city := range cities {
+		fmt.Fprintf(&b, "  %d %s (%d,%d)", city.ID, city.Name, city.X, city.Y)
+		for _, direction := range []string{model.North, model.East, model.South, model.West} {
+			if name := city.Roads()[direction]; name != "" {
+				fmt.Fprintf(&b, " %s=%s", direction, name)
+			}
+		}
+		fmt.Fprintf(&b, " aliens=%v\n", sortedKeys(aliensByCity[city.ID]))
+	}
+	b.WriteString("destroyed cities:\n")
+	for ix, city := range app.destroyedCities {
+		fmt.Fprintf(&b, "  %d %s (%d,%d) at tick %d\n", city.ID, city.Name, city.X, city.Y, app.destructions[ix].Tick)
+	}
+	b.WriteString("aliens:\n")
+	for _, alienID := range sortedKeys(aliens) {
+		alien := aliens[alienID]
+		fmt.Fprintf(&b, "  %d %s city=%d origin=%d moves=%d\n", alienID, alien.Name, alien.City, alien.Origin, alien.Moves)
+	}
+	b.WriteString("aliens by city:\n")
+	cityIDs := make([]int, 0, len(aliensByCity))
+	for cityID := range aliensByCity {
+		cityIDs = append(cityIDs, cityID)
+	}
+	sort.Ints(cityIDs)
+	for _, cityID := range cityIDs {
+		fmt.Fprintf(&b, "  %d: %v\n", cityID, sortedKeys(aliensByCity[cityID]))
+	}
+	return b.String()
+}
+
+func sortedKeys(aliens map[int]*model.Alien) []int {
+	ids := make([]int, 0, len(aliens))
+	for id := range aliens {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	return ids
+}
@@ -0,0 +1,109 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+
+	"github.com/c-kuroki/alien_invasion/pkg/adapters/renderer"
+	"github.com/c-kuroki/alien_invasion/pkg/adapters/world"
+	"github.com/c-kuroki/alien_invasion/pkg/model"
+)
+
+type InvariantsTestSuite struct {
+	suite.Suite
+}
+
+// brokenState leaves cities with fights in the world
+type brokenState struct {
+	*world.InMemoryState
+}
+
+func (st *brokenState) RemoveCity(cityID int) error {
+	return nil
+}
+
+// generateMap writes a random map and returns its filename
+func (suite *InvariantsTestSuite) generateMap(cfg GenerateConfig) string {
+	filename := filepath.Join(suite.T().TempDir(), "world.map")
+	f, err := os.Create(filename)
+	suite.Require().NoError(err)
+	defer f.Close()
+	suite.Require().NoError(GenerateMap(cfg, f))
+	return filename
+}
+
+func (suite *InvariantsTestSuite) run(cfg *model.Config, state world.Adapter) *AlienInvasionApp {
+	invasion := NewAlienInvasionApp(cfg, state, renderer.NewSVGRenderer(), zap.NewNop().Sugar())
+	suite.Require().NoError(invasion.Init())
+	invasion.MainLoop()
+	return invasion
+}
+
+func (suite *InvariantsTestSuite) TestRandomSimulations() {
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 50; i++ {
+		width, height := 1+rnd.Intn(8), 1+rnd.Intn(8)
+		mapFile := suite.generateMap(GenerateConfig{Width: width, Height: height, Roads: rnd.Float64(), Seed: rnd.Int63() + 1})
+		cfg := &model.Config{
+			MaxMoves:   1 + rnd.Intn(200),
+			NumAliens:  1 + rnd.Intn(2*width*height),
+			Seed:       rnd.Int63() + 1,
+			NoFinalMap: true,
+			Debug:      true,
+		}
+		var state world.Adapter
+		adapter := []string{"memory", "event_sourced", "bolt"}[i%3]
+		switch adapter {
+		case "memory":
+			state = world.NewInMemoryState(mapFile)
+		case "event_sourced":
+			state = world.NewEventSourcedState(world.NewInMemoryState(mapFile), 1+rnd.Intn(10))
+		case "bolt":
+			db, err := world.NewBoltState(filepath.Join(suite.T().TempDir(), "world.db"), mapFile, zap.NewNop().Sugar())
+			suite.Require().NoError(err)
+			defer db.Close()
+			state = db
+		}
+		name := fmt.Sprintf("%s %dx%d %d aliens seed %d", adapter, width, height, cfg.NumAliens, cfg.Seed)
+		suite.Run(name, func() {
+			invasion := suite.run(cfg, state)
+			suite.Require().NoError(invasion.Err())
+			suite.Assert().Equal(model.StatusFinished, invasion.Snapshot().Status)
+		})
+	}
+}
+
+func (suite *InvariantsTestSuite) TestViolation() {
+	// every alien on one of two cities, so the first tick has a fight
+	mapFile := suite.generateMap(GenerateConfig{Width: 2, Height: 1, Seed: 1})
+	cfg := &model.Config{MaxMoves: 10, NumAliens: 5, Seed: 1, NoFinalMap: true, Debug: true}
+	invasion := suite.run(cfg, &brokenState{world.NewInMemoryState(mapFile)})
+
+	var invariantErr *InvariantError
+	suite.Require().True(errors.As(invasion.Err(), &invariantErr), "error %v", invasion.Err())
+	suite.Assert().Equal(1, invariantErr.Tick)
+	suite.Assert().Equal(1, invasion.Tick())
+	violations := strings.Join(invariantErr.Violations, "\n")
+	suite.Assert().Contains(violations, "is still in the world")
+	suite.Assert().Contains(violations, "aliens after the fights")
+	suite.Assert().Contains(invariantErr.Dump, "aliens by city:")
+	suite.Assert().Equal(model.StatusFinished, invasion.Snapshot().Status)
+
+	// without debug the broken world is not noticed
+	cfg.Debug = false
+	invasion = suite.run(cfg, &brokenState{world.NewInMemoryState(mapFile)})
+	suite.Assert().NoError(invasion.Err())
+}
+
+// TestInvariantsTestSuite is the entry point of this test suite
+func TestInvariantsTestSuite(t *testing.T) {
+	suite.Run(t, new(InvariantsTestSuite))
+}
@@ -0,0 +1,105 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+
+	"github.com/c-kuroki/alien_invasion/pkg/adapters/maps"
+	"github.com/c-kuroki/alien_invasion/pkg/adapters/renderer"
+	"github.com/c-kuroki/alien_invasion/pkg/adapters/world"
+	"github.com/c-kuroki/alien_invasion/pkg/model"
+)
+
+var ErrInvalidMap = errors.New("invalid map")
+
+// MapsApp manages stored world maps
+type MapsApp struct {
+	store    maps.Adapter
+	renderer renderer.Adapter
+}
+
+func NewMapsApp(store maps.Adapter, renderer renderer.Adapter) *MapsApp {
+	return &MapsApp{
+		store:    store,
+		renderer: renderer,
+	}
+}
+
+func (m *MapsApp) List() ([]*model.MapInfo, error) {
+	return m.store.List()
+}
+
+func (m *MapsApp) Get(name string) ([]byte, error) {
+	return m.store.Get(name)
+}
+
+func (m *MapsApp) Delete(name string) error {
+	return m.store.Delete(name)
+}
+
+// Validate loads a map using the world loader and reports why it is invalid
+func (m *MapsApp) Validate(data []byte) *model.MapValidation {
+	return ValidateMap(data)
+}
+
+// ValidateMap loads a map using the world loader and reports why it is invalid
+func ValidateMap(data []byte) *model.MapValidation {
+	state, err := loadMap(data)
+	if err != nil {
+		return &model.MapValidation{Error: err}
+	}
+	return &model.MapValidation{
+		Valid:  true,
+		Cities: state.GetNumCities(),
+		Width:  state.GetWidth(),
+		Height: state.GetHeight(),
+	}
+}
+
+// Upload validates and stores a map, invalid maps return ErrInvalidMap and the validation result
+func (m *MapsApp) Upload(name string, data []byte) (*model.MapInfo, *model.MapValidation, error) {
+	validation := m.Validate(data)
+	if !validation.Valid {
+		return nil, validation, ErrInvalidMap
+	}
+	info, err := m.store.Put(name, data)
+	return info, validation, err
+}
+
+// Preview renders a map before any alien is placed, invalid maps return a *model.MapError
+func (m *MapsApp) Preview(ctx context.Context, data []byte, w io.Writer) error {
+	state, mapErr := loadMap(data)
+	if mapErr != nil {
+		return mapErr
+	}
+	return m.renderer.Render(ctx, state.GetAllCities(), map[int]map[int]*model.Alien{}, w)
+}
+
+// PreviewStored renders a stored map before any alien is placed
+func (m *MapsApp) PreviewStored(ctx context.Context, name string, w io.Writer) error {
+	data, err := m.store.Get(name)
+	if err != nil {
+		return err
+	}
+	return m.Preview(ctx, data, w)
+}
+
+// loadMap loads a map in memory, empty maps are not valid
+func loadMap(data []byte) (*world.InMemoryState, *model.MapError) {
+	state := world.NewInMemoryStateFromReader(bytes.NewReader(data))
+	if err := state.Load(); err != nil {
+		mapErr := &model.MapError{Message: err.Error()}
+		var loadErr *world.LoadError
+		if errors.As(err, &loadErr) {
+			mapErr.Line = loadErr.Line
+			mapErr.Message = loadErr.Err.Error()
+		}
+		return nil, mapErr
+	}
+	if state.GetNumCities() == 0 {
+		return nil, &model.MapError{Message: "empty map"}
+	}
+	return state, nil
+}
@@ -0,0 +1,211 @@
+package app
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/c-kuroki/alien_invasion/pkg/adapters/export"
+	"github.com/c-kuroki/alien_invasion/pkg/model"
+)
+
+// DefaultOutputTemplate is the final map filename if no template is set
+const DefaultOutputTemplate = "final-{{.Time}}.{{.Ext}}"
+
+// ReportNone disables the summary report on stdout
+const ReportNone = "none"
+
+// time format of output filenames, without the colons of RFC3339 that some filesystems reject
+const outputTimeFormat = "20060102T150405"
+
+// OutputName holds the fields of output filename templates
+type OutputName struct {
+	Time string
+	Seed int64
+	Tick int
+	Ext  string
+}
+
+// ValidateOutput checks the output format and filename templates
+func ValidateOutput(cfg model.OutputConfig) error {
+	if _, err := export.New(cfg.Format); err != nil {
+		return err
+	}
+	if cfg.Report != ReportNone && cfg.Report != "" && cfg.Report != export.FormatText && cfg.Report != export.FormatJSON {
+		return fmt.Errorf("invalid report format [%s] (should be text, json or none)", cfg.Report)
+	}
+	for _, tmpl := range []string{cfg.Template, cfg.SummaryFile} {
+		if _, err := OutputFilename("", tmpl, OutputName{}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// OutputFilename renders a filename template, relative filenames are placed on dir
+func OutputFilename(dir, tmpl string, name OutputName) (string, error) {
+	t, err := template.New("filename").Option("missingkey=error").Parse(tmpl)
+	if err != nil {
+		return "", fmt.Errorf("invalid filename template: %w", err)
+	}
+	var b bytes.Buffer
+	if err := t.Execute(&b, name); err != nil {
+		return "", fmt.Errorf("invalid filename template: %w", err)
+	}
+	filename := b.String()
+	if filepath.IsAbs(filename) || dir == "" {
+		return filename, nil
+	}
+	return filepath.Join(dir, filename), nil
+}
+
+// writeOutputs writes the final map and the summary report at the end of the run
+func (app *AlienInvasionApp) writeOutputs(reason string) error {
+	cfg := app.cfg.Output
+	exporter, err := export.New(cfg.Format)
+	if err != nil {
+		return err
+	}
+	summary := app.Summary(reason)
+	name := OutputName{
+		Time: app.clock.Now().Format(outputTimeFormat),
+		Seed: app.seed,
+		Tick: summary.Tick,
+		Ext:  exporter.Extension(),
+	}
+	if cfg.Stdout {
+		if err := exporter.Export(app.stdout, summary.Cities, summary.Aliens); err != nil {
+			return err
+		}
+	} else {
+		tmpl := cfg.Template
+		if tmpl == "" {
+			tmpl = DefaultOutputTemplate
+		}
+		err := app.writeFile(cfg.Dir, tmpl, name, func(f *os.File) error {
+			return exporter.Export(f, summary.Cities, summary.Aliens)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if cfg.Report != ReportNone {
+		out := app.stdout
+		if cfg.Stdout {
+			out = app.stderr
+		}
+		if err := export.WriteSummary(out, summary, cfg.Report); err != nil {
+			return err
+		}
+	}
+	if cfg.SummaryFile == "" {
+		return nil
+	}
+	name.Ext = "json"
+	return app.writeFile(cfg.Dir, cfg.SummaryFile, name, func(f *os.File) error {
+		format := export.FormatText
+		if strings.EqualFold(filepath.Ext(f.Name()), ".json") {
+			format = export.FormatJSON
+		}
+		return export.WriteSummary(f, summary, format)
+	})
+}
+
+// writeFile creates an output file from a filename template
+func (app *AlienInvasionApp) writeFile(dir, tmpl string, name OutputName, write func(*os.File) error) error {
+	filename, err := OutputFilename(dir, tmpl, name)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(filename), 0o755); err != nil {
+		return err
+	}
+	f, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	if err := write(f); err != nil {
+		f.Close()
+		return err
+	}
+	app.log.Infow("output written", "filename", filename)
+	return f.Close()
+}
+
+// Summary reports how the run ended and its survivors, reason is empty while running
+func (app *AlienInvasionApp) Summary(reason string) *model.Summary {
+	app.mu.RLock()
+	defer app.mu.RUnlock()
+	summary := &model.Summary{
+		Tick:      app.tick,
+		Reason:    reason,
+		Destroyed: []*model.Destruction{},
+		Cities:    []*model.City{},
+		Aliens:    []*model.Alien{},
+		Trapped:   []int{},
+	}
+	summary.Destroyed = append(summary.Destroyed, app.destructions...)
+	for _, city := range app.state.GetAllCities() {
+		c := *city
+		summary.Cities = append(summary.Cities, &c)
+	}
+	for _, alien := range app.state.GetAliens() {
+		a := *alien
+		summary.Aliens = append(summary.Aliens, &a)
+	}
+	sort.Slice(summary.Cities, func(i, j int) bool {
+		return summary.Cities[i].ID < summary.Cities[j].ID
+	})
+	sort.Slice(summary.Aliens, func(i, j int) bool {
+		return summary.Aliens[i].ID < summary.Aliens[j].ID
+	})
+	for _, alien := range summary.Aliens {
+		if exits, err := app.state.GetExits(alien.City); err == nil && len(exits) == 0 {
+			summary.Trapped = append(summary.Trapped, alien.ID)
+		}
+	}
+	summary.Fragmentation = fragmentation(summary.Cities)
+	return summary
+}
+
+// fragmentation finds the groups of cities connected by roads
+func fragmentation(cities []*model.City) model.Fragmentation {
+	byName := make(map[string]*model.City, len(cities))
+	for _, city := range cities {
+		byName[city.Name] = city
+	}
+	frag := model.Fragmentation{Sizes: []int{}}
+	visited := make(map[string]bool, len(cities))
+	for _, city := range cities {
+		if visited[city.Name] {
+			continue
+		}
+		if len(city.Roads()) == 0 {
+			frag.Isolated++
+		}
+		// walk the group of the city
+		size := 0
+		visited[city.Name] = true
+		queue := []*model.City{city}
+		for len(queue) > 0 {
+			current := queue[0]
+			queue = queue[1:]
+			size++
+			for _, name := range current.Roads() {
+				next, ok := byName[name]
+				if ok && !visited[name] {
+					visited[name] = true
+					queue = append(queue, next)
+				}
+			}
+		}
+		frag.Components++
+		frag.Sizes = append(frag.Sizes, size)
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(frag.Sizes)))
+	return frag
+}
@@ -0,0 +1,200 @@
+package app
+
+import (
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/c-kuroki/alien_invasion/pkg/adapters/world"
+	"github.com/c-kuroki/alien_invasion/pkg/model"
+)
+
+var ErrNotFound = errors.New("not found")
+
+// Status returns a summary of the simulation progress
+func (app *AlienInvasionApp) Status() *model.Status {
+	app.mu.RLock()
+	defer app.mu.RUnlock()
+	return &model.Status{
+		Tick:            app.tick,
+		Status:          app.status,
+		MaxMoves:        app.cfg.MaxMoves,
+		AliveAliens:     app.state.GetNumAliens(),
+		RemainingCities: app.state.GetNumCities(),
+		DestroyedCities: len(app.destroyedCities),
+	}
+}
+
+// Ready reports whether the map was loaded
+func (app *AlienInvasionApp) Ready() bool {
+	app.mu.RLock()
+	defer app.mu.RUnlock()
+	return len(app.initialCities) > 0
+}
+
+// Metrics returns the progress counters exported to monitoring
+func (app *AlienInvasionApp) Metrics() *model.Metrics {
+	status := app.Status()
+	app.mu.RLock()
+	defer app.mu.RUnlock()
+	return &model.Metrics{
+		Status:         *status,
+		Moves:          app.moves,
+		Fights:         app.fights,
+		TickFights:     app.tickFights,
+		MovesPerSecond: app.movesPerSecond,
+	}
+}
+
+// City returns a copy of a city by ID, including destroyed cities
+func (app *AlienInvasionApp) City(cityID int) (*model.City, error) {
+	app.mu.RLock()
+	defer app.mu.RUnlock()
+	city, err := app.state.GetCityByID(cityID)
+	return app.findCity(city, err, func(c *model.City) bool { return c.ID == cityID })
+}
+
+// CityByName returns a copy of a city by name, including destroyed cities
+func (app *AlienInvasionApp) CityByName(name string) (*model.City, error) {
+	app.mu.RLock()
+	defer app.mu.RUnlock()
+	city, err := app.state.GetCityByName(name)
+	return app.findCity(city, err, func(c *model.City) bool { return c.Name == name })
+}
+
+// findCity returns a copy of a city found on the world, or looks for it on destroyed cities when
+// the world has no such city (mu must be held)
+func (app *AlienInvasionApp) findCity(city *model.City, err error, match func(*model.City) bool) (*model.City, error) {
+	if err == nil {
+		c := *city
+		return &c, nil
+	}
+	if !world.IsNotFound(err) {
+		return nil, err
+	}
+	for _, city := range app.destroyedCities {
+		if match(city) {
+			c := *city
+			return &c, nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+// Exits returns the roads leaving a city, destroyed cities have no exits
+func (app *AlienInvasionApp) Exits(cityID int) ([]model.Exit, error) {
+	app.mu.RLock()
+	defer app.mu.RUnlock()
+	city, err := app.state.GetCityByID(cityID)
+	city, err = app.findCity(city, err, func(c *model.City) bool { return c.ID == cityID })
+	if err != nil {
+		return nil, err
+	}
+	exits := []model.Exit{}
+	if city.Destroyed {
+		return exits, nil
+	}
+	roads := city.Roads()
+	for _, direction := range []string{model.North, model.East, model.South, model.West} {
+		name, ok := roads[direction]
+		if !ok {
+			continue
+		}
+		neighbour, err := app.state.GetCityByName(name)
+		if err != nil {
+			return nil, err
+		}
+		exits = append(exits, model.Exit{Direction: direction, CityID: neighbour.ID, CityName: neighbour.Name})
+	}
+	return exits, nil
+}
+
+// Alien returns a copy of an alive alien by ID
+func (app *AlienInvasionApp) Alien(alienID int) (*model.Alien, error) {
+	app.mu.RLock()
+	defer app.mu.RUnlock()
+	alien, err := app.state.GetAlienByID(alienID)
+	if err != nil {
+		return nil, ErrNotFound
+	}
+	a := *alien
+	return &a, nil
+}
+
+// Cities returns a page of the cities (including destroyed ones) matching a filter, sorted by ID,
+// and the number of cities matching it
+func (app *AlienInvasionApp) Cities(filter model.CityFilter, offset, limit int) ([]*model.City, int) {
+	app.mu.RLock()
+	defer app.mu.RUnlock()
+	all := []*model.City{}
+	all = append(all, app.state.GetAllCities()...)
+	all = append(all, app.destroyedCities...)
+	sort.Slice(all, func(i, j int) bool {
+		return all[i].ID < all[j].ID
+	})
+	cities := []*model.City{}
+	total := 0
+	for _, city := range all {
+		if filter.Name != "" && !strings.Contains(city.Name, filter.Name) {
+			continue
+		}
+		if filter.Destroyed != nil && city.Destroyed != *filter.Destroyed {
+			continue
+		}
+		if filter.HasAliens != nil && app.hasAliens(city) != *filter.HasAliens {
+			continue
+		}
+		if total >= offset && len(cities) < limit {
+			c := *city
+			cities = append(cities, &c)
+		}
+		total++
+	}
+	return cities, total
+}
+
+// hasAliens reports whether there are aliens on a city (mu must be held)
+func (app *AlienInvasionApp) hasAliens(city *model.City) bool {
+	if city.Destroyed {
+		return false
+	}
+	aliens, err := app.state.GetAliensByCity(city.ID)
+	return err == nil && len(aliens) > 0
+}
+
+// Aliens returns a page of the alive aliens matching a filter, sorted by ID, and the number of
+// aliens matching it
+func (app *AlienInvasionApp) Aliens(filter model.AlienFilter, offset, limit int) ([]*model.Alien, int) {
+	app.mu.RLock()
+	defer app.mu.RUnlock()
+	aliens := []*model.Alien{}
+	total := 0
+	add := func(alien *model.Alien) error {
+		if filter.Name != "" && !strings.Contains(alien.Name, filter.Name) {
+			return nil
+		}
+		if total >= offset && len(aliens) < limit {
+			a := *alien
+			aliens = append(aliens, &a)
+		}
+		total++
+		return nil
+	}
+	if filter.CityID < 0 {
+		if err := app.state.ForEachAlien(add); err != nil {
+			app.log.Warnw("listing aliens", "error", err.Error())
+		}
+		return aliens, total
+	}
+	// only the aliens of the city are read
+	inCity, _ := app.state.GetAliensByCity(filter.CityID)
+	alienIDs := make([]int, 0, len(inCity))
+	for alienID := range inCity {
+		alienIDs = append(alienIDs, alienID)
+	}
+	sort.Ints(alienIDs)
+	for _, alienID := range alienIDs {
+		_ = add(inCity[alienID])
+	}
+	return aliens, total
+}
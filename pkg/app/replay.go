@@ -0,0 +1,60 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/c-kuroki/alien_invasion/pkg/model"
+)
+
+// Replay loads the map and applies the events recorded by a run up to a tick (-1 for all of them)
+// instead of running the simulation, the state can then be queried and rendered
+func (app *AlienInvasionApp) Replay(events []model.Event, tick int) error {
+	app.mu.Lock()
+	defer app.mu.Unlock()
+	if err := app.load(); err != nil {
+		return err
+	}
+	app.status = model.StatusPaused
+	for _, event := range events {
+		if tick >= 0 && event.Tick > tick {
+			break
+		}
+		app.tick = event.Tick
+		if err := app.apply(event); err != nil {
+			return fmt.Errorf("replaying %s event of tick %d: %w", event.Type, event.Tick, err)
+		}
+		app.events.add(event)
+	}
+	return nil
+}
+
+// apply applies a recorded event to the world (mu must be held)
+func (app *AlienInvasionApp) apply(event model.Event) error {
+	switch event.Type {
+	case model.EventSpawn:
+		if _, err := app.state.GetCityByID(event.CityID); err != nil {
+			return err
+		}
+		app.stats.Visit(event.CityID)
+		return app.state.AddAlien(model.NewAlien(event.AlienID, event.CityID))
+	case model.EventMove:
+		if _, err := app.state.GetCityByID(event.To); err != nil {
+			return err
+		}
+		app.moves++
+		app.stats.Visit(event.To)
+		return app.state.MoveAlien(event.AlienID, event.To)
+	case model.EventDestroy:
+		city, err := app.state.GetCityByID(event.CityID)
+		if err != nil {
+			return err
+		}
+		// attackers are the aliens on the city when it was destroyed
+		attackers, _ := app.state.GetAliensByCity(event.CityID)
+		app.recordDestruction(city, attackers)
+		return app.state.RemoveCity(event.CityID)
+	case model.EventEnd:
+		app.status = model.StatusFinished
+	}
+	return nil
+}
@@ -0,0 +1,311 @@
+package app
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/c-kuroki/alien_invasion/pkg/adapters/maps"
+	"github.com/c-kuroki/alien_invasion/pkg/adapters/renderer"
+	"github.com/c-kuroki/alien_invasion/pkg/adapters/world"
+	"github.com/c-kuroki/alien_invasion/pkg/clock"
+	"github.com/c-kuroki/alien_invasion/pkg/logger"
+	"github.com/c-kuroki/alien_invasion/pkg/model"
+)
+
+var (
+	ErrInvalidSimulation = errors.New("invalid simulation")
+	ErrTooManySessions   = errors.New("too many simulations")
+)
+
+// SessionsConfig sets the limits of hosted simulations
+type SessionsConfig struct {
+	// MaxSessions is the max number of concurrent simulations, the oldest finished simulation is
+	// deleted to make room for a new one
+	MaxSessions int
+	// MaxLifetime is the time a simulation is kept, including finished ones (0 to keep them until deleted)
+	MaxLifetime time.Duration
+	MaxAliens   int
+	MaxMoves    int
+	// MinTickInterval is the minimum tick interval in ms
+	MinTickInterval int
+	// Clock expires the simulations (the system clock if nil)
+	Clock clock.Clock
+}
+
+func DefaultSessionsConfig() SessionsConfig {
+	return SessionsConfig{
+		MaxSessions:     16,
+		MaxLifetime:     time.Hour,
+		MaxAliens:       10000,
+		MaxMoves:        10000,
+		MinTickInterval: 10,
+	}
+}
+
+// Session is a simulation hosted by the server
+type Session struct {
+	ID        string
+	CreatedAt time.Time
+	ExpiresAt time.Time
+	Request   model.SimulationRequest
+	App       *AlienInvasionApp
+}
+
+// Info describes the session and its current status
+func (s *Session) Info() *model.SessionInfo {
+	info := &model.SessionInfo{
+		ID:           s.ID,
+		CreatedAt:    s.CreatedAt,
+		Example:      s.Request.Example,
+		NumAliens:    s.Request.NumAliens,
+		Seed:         s.Request.Seed,
+		TickInterval: s.Request.TickInterval,
+		MaxMoves:     s.Request.MaxMoves,
+		Status:       s.App.Status(),
+	}
+	if !s.ExpiresAt.IsZero() {
+		expiresAt := s.ExpiresAt
+		info.ExpiresAt = &expiresAt
+	}
+	return info
+}
+
+// SessionManager hosts many independent simulations, each one with its own world state
+type SessionManager struct {
+	cfg      SessionsConfig
+	maps     maps.Adapter
+	renderer renderer.Adapter
+	log      logger.Logger
+	mu       sync.RWMutex
+	sessions map[string]*Session
+	done     chan struct{}
+}
+
+// NewSessionManager returns a session manager, simulations can use stored maps by name
+func NewSessionManager(cfg SessionsConfig, maps maps.Adapter, renderer renderer.Adapter, log logger.Logger) *SessionManager {
+	if cfg.Clock == nil {
+		cfg.Clock = clock.New()
+	}
+	sm := &SessionManager{
+		cfg:      cfg,
+		maps:     maps,
+		renderer: renderer,
+		log:      log,
+		sessions: make(map[string]*Session),
+		done:     make(chan struct{}),
+	}
+	go sm.reap()
+	return sm
+}
+
+// Create validates the request, loads the map and starts a new simulation
+func (sm *SessionManager) Create(req model.SimulationRequest) (*Session, error) {
+	if err := sm.validate(&req); err != nil {
+		return nil, err
+	}
+	if sm.full() {
+		return nil, ErrTooManySessions
+	}
+	var mem *world.InMemoryState
+	if req.Map != "" {
+		mem = world.NewInMemoryStateFromReader(strings.NewReader(req.Map))
+	} else {
+		// the map is read from the store, so its location is never part of the errors
+		data, err := sm.maps.Get(req.Example)
+		switch {
+		case errors.Is(err, maps.ErrNotFound):
+			return nil, fmt.Errorf("%w: unknown example [%s]", ErrInvalidSimulation, req.Example)
+		case errors.Is(err, maps.ErrInvalidName):
+			return nil, fmt.Errorf("%w: %s", ErrInvalidSimulation, err.Error())
+		case err != nil:
+			sm.log.Errorw("reading example", "example", req.Example, "error", err.Error())
+			return nil, fmt.Errorf("reading example [%s]", req.Example)
+		}
+		mem = world.NewInMemoryStateFromReader(bytes.NewReader(data))
+	}
+	var state world.Adapter = mem
+	if req.History > 0 {
+		state = world.NewEventSourcedState(mem, req.History)
+	}
+	if req.Seed == 0 {
+		req.Seed = sm.cfg.Clock.Now().UnixNano()
+	}
+	cfg := &model.Config{
+		TickInterval: req.TickInterval,
+		MaxMoves:     req.MaxMoves,
+		NumAliens:    req.NumAliens,
+		Seed:         req.Seed,
+		NoFinalMap:   true,
+	}
+	id, err := newSessionID()
+	if err != nil {
+		return nil, err
+	}
+	invasion := NewAlienInvasionApp(cfg, state, sm.renderer, sm.log)
+	if req.Paused {
+		_ = invasion.Pause()
+	}
+	if err := invasion.Init(); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidSimulation, err.Error())
+	}
+	// uploaded map is not needed anymore
+	req.Map = ""
+	session := &Session{
+		ID:        id,
+		CreatedAt: sm.cfg.Clock.Now(),
+		Request:   req,
+		App:       invasion,
+	}
+	if sm.cfg.MaxLifetime > 0 {
+		session.ExpiresAt = session.CreatedAt.Add(sm.cfg.MaxLifetime)
+	}
+
+	// check again, other sessions could be created while loading the map
+	sm.mu.Lock()
+	var evicted *Session
+	if sm.cfg.MaxSessions > 0 && len(sm.sessions) >= sm.cfg.MaxSessions {
+		if evicted = sm.oldestFinished(); evicted == nil {
+			sm.mu.Unlock()
+			return nil, ErrTooManySessions
+		}
+		delete(sm.sessions, evicted.ID)
+	}
+	sm.sessions[id] = session
+	sm.mu.Unlock()
+	if evicted != nil {
+		evicted.App.Stop()
+		sm.log.Infow("simulation evicted", "id", evicted.ID)
+	}
+
+	go invasion.MainLoop()
+	sm.log.Infow("simulation created", "id", id, "aliens", req.NumAliens)
+	return session, nil
+}
+
+// validate checks request against limits and sets default values
+func (sm *SessionManager) validate(req *model.SimulationRequest) error {
+	if req.Map == "" && req.Example == "" {
+		return fmt.Errorf("%w: a map or an example name is required", ErrInvalidSimulation)
+	}
+	if req.NumAliens < 1 || (sm.cfg.MaxAliens > 0 && req.NumAliens > sm.cfg.MaxAliens) {
+		return fmt.Errorf("%w: aliens should be between 1 and %d", ErrInvalidSimulation, sm.cfg.MaxAliens)
+	}
+	if req.MaxMoves == 0 {
+		req.MaxMoves = sm.cfg.MaxMoves
+	}
+	if req.MaxMoves < 1 || (sm.cfg.MaxMoves > 0 && req.MaxMoves > sm.cfg.MaxMoves) {
+		return fmt.Errorf("%w: max moves should be between 1 and %d", ErrInvalidSimulation, sm.cfg.MaxMoves)
+	}
+	if req.History < 0 {
+		return fmt.Errorf("%w: history can not be negative", ErrInvalidSimulation)
+	}
+	if req.TickInterval == 0 {
+		req.TickInterval = 1000
+	}
+	if req.TickInterval < sm.cfg.MinTickInterval || req.TickInterval < 1 {
+		return fmt.Errorf("%w: tick interval should be at least %d ms", ErrInvalidSimulation, sm.cfg.MinTickInterval)
+	}
+	return nil
+}
+
+// full returns true when the max number of sessions is reached and none of them is finished
+func (sm *SessionManager) full() bool {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+	return sm.cfg.MaxSessions > 0 && len(sm.sessions) >= sm.cfg.MaxSessions && sm.oldestFinished() == nil
+}
+
+// oldestFinished returns the oldest finished session, nil if none (mu must be held)
+func (sm *SessionManager) oldestFinished() *Session {
+	var oldest *Session
+	for _, session := range sm.sessions {
+		if session.App.Status().Status != model.StatusFinished {
+			continue
+		}
+		if oldest == nil || session.CreatedAt.Before(oldest.CreatedAt) {
+			oldest = session
+		}
+	}
+	return oldest
+}
+
+// Get returns a session by ID
+func (sm *SessionManager) Get(id string) (*Session, error) {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+	session, ok := sm.sessions[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return session, nil
+}
+
+// List returns all sessions sorted by creation time
+func (sm *SessionManager) List() []*Session {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+	sessions := make([]*Session, 0, len(sm.sessions))
+	for _, session := range sm.sessions {
+		sessions = append(sessions, session)
+	}
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].CreatedAt.Before(sessions[j].CreatedAt)
+	})
+	return sessions
+}
+
+// Delete stops and removes a session
+func (sm *SessionManager) Delete(id string) error {
+	sm.mu.Lock()
+	session, ok := sm.sessions[id]
+	delete(sm.sessions, id)
+	sm.mu.Unlock()
+	if !ok {
+		return ErrNotFound
+	}
+	session.App.Stop()
+	sm.log.Infow("simulation deleted", "id", id)
+	return nil
+}
+
+// Close stops all the sessions
+func (sm *SessionManager) Close() {
+	close(sm.done)
+	for _, session := range sm.List() {
+		_ = sm.Delete(session.ID)
+	}
+}
+
+// reap deletes expired sessions
+func (sm *SessionManager) reap() {
+	ticker := sm.cfg.Clock.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-sm.done:
+			return
+		case now := <-ticker.C():
+			for _, session := range sm.List() {
+				if !session.ExpiresAt.IsZero() && now.After(session.ExpiresAt) {
+					sm.log.Infow("simulation expired", "id", session.ID)
+					_ = sm.Delete(session.ID)
+				}
+			}
+		}
+	}
+}
+
+func newSessionID() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,143 @@
+package app
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+
+	"github.com/c-kuroki/alien_invasion/pkg/adapters/maps"
+	"github.com/c-kuroki/alien_invasion/pkg/adapters/renderer"
+	"github.com/c-kuroki/alien_invasion/pkg/clock"
+	"github.com/c-kuroki/alien_invasion/pkg/model"
+)
+
+type SessionsTestSuite struct {
+	suite.Suite
+	clock    *clock.Fake
+	cfg      SessionsConfig
+	sessions *SessionManager
+}
+
+func (suite *SessionsTestSuite) SetupTest() {
+	suite.clock = clock.NewFake(time.Date(2022, 11, 22, 12, 53, 16, 0, time.UTC))
+	suite.cfg = DefaultSessionsConfig()
+	suite.cfg.MaxSessions = 2
+	suite.cfg.MaxLifetime = time.Minute
+	suite.cfg.MinTickInterval = 1
+	suite.cfg.Clock = suite.clock
+	suite.sessions = NewSessionManager(suite.cfg, maps.NewFileStore("../../examples"), renderer.NewSVGRenderer(), zap.NewNop().Sugar())
+	suite.T().Cleanup(suite.sessions.Close)
+	// wait for the reaper ticker
+	suite.clock.BlockUntil(1)
+}
+
+func (suite *SessionsTestSuite) create(seed int64) *Session {
+	session, err := suite.sessions.Create(model.SimulationRequest{
+		Example: "world", NumAliens: 20, Seed: seed, TickInterval: 1, MaxMoves: 50,
+	})
+	suite.Require().NoError(err)
+	return session
+}
+
+// waitFinished waits for the end of a simulation
+func (suite *SessionsTestSuite) waitFinished(session *Session) {
+	suite.Require().Eventually(func() bool {
+		return session.App.Status().Status == model.StatusFinished
+	}, 5*time.Second, time.Millisecond)
+}
+
+func (suite *SessionsTestSuite) TestSameSeed() {
+	first, second := suite.create(42), suite.create(42)
+	suite.waitFinished(first)
+	suite.waitFinished(second)
+	suite.Assert().Equal(first.App.Snapshot(), second.App.Snapshot())
+	firstEvents, _, cancel, err := first.App.Subscribe(0)
+	suite.Require().NoError(err)
+	cancel()
+	secondEvents, _, cancel, err := second.App.Subscribe(0)
+	suite.Require().NoError(err)
+	cancel()
+	suite.Assert().NotEmpty(firstEvents)
+	suite.Assert().Equal(firstEvents, secondEvents)
+}
+
+func (suite *SessionsTestSuite) TestMaxSessions() {
+	req := model.SimulationRequest{Example: "world", NumAliens: 20, TickInterval: 1, MaxMoves: 50, Paused: true}
+	first, err := suite.sessions.Create(req)
+	suite.Require().NoError(err)
+	suite.clock.Advance(time.Second)
+	second, err := suite.sessions.Create(req)
+	suite.Require().NoError(err)
+	_, err = suite.sessions.Create(req)
+	suite.Assert().ErrorIs(err, ErrTooManySessions)
+
+	// the oldest finished simulation makes room for a new one
+	suite.Require().NoError(second.App.Resume())
+	suite.waitFinished(second)
+	suite.Require().NoError(first.App.Resume())
+	suite.waitFinished(first)
+	suite.create(3)
+	suite.Assert().Len(suite.sessions.List(), 2)
+	_, err = suite.sessions.Get(first.ID)
+	suite.Assert().ErrorIs(err, ErrNotFound)
+	_, err = suite.sessions.Get(second.ID)
+	suite.Assert().NoError(err)
+}
+
+func (suite *SessionsTestSuite) TestDelete() {
+	session := suite.create(1)
+	suite.Require().NoError(suite.sessions.Delete(session.ID))
+	_, err := suite.sessions.Get(session.ID)
+	suite.Assert().ErrorIs(err, ErrNotFound)
+	suite.Assert().ErrorIs(suite.sessions.Delete(session.ID), ErrNotFound)
+	suite.Assert().Empty(suite.sessions.List())
+	// the simulation is stopped
+	suite.waitFinished(session)
+}
+
+func (suite *SessionsTestSuite) TestLifetime() {
+	session := suite.create(1)
+	info := session.Info()
+	suite.Require().NotNil(info.ExpiresAt)
+	suite.Assert().Equal(info.CreatedAt.Add(time.Minute), *info.ExpiresAt)
+
+	suite.clock.Advance(time.Minute)
+	// the reaper runs on its own goroutine, give it time to act before checking nothing happened
+	time.Sleep(10 * time.Millisecond)
+	_, err := suite.sessions.Get(session.ID)
+	suite.Assert().NoError(err)
+
+	suite.clock.Advance(time.Second)
+	suite.Assert().Eventually(func() bool {
+		_, err := suite.sessions.Get(session.ID)
+		return err != nil
+	}, 5*time.Second, time.Millisecond)
+	suite.waitFinished(session)
+}
+
+func (suite *SessionsTestSuite) TestUploadedMap() {
+	data, err := os.ReadFile(exampleMapFile)
+	suite.Require().NoError(err)
+	suite.cfg.MaxLifetime = 0
+	sessions := NewSessionManager(suite.cfg, maps.NewFileStore(suite.T().TempDir()), renderer.NewSVGRenderer(), zap.NewNop().Sugar())
+	defer sessions.Close()
+	session, err := sessions.Create(model.SimulationRequest{Map: string(data), NumAliens: 1, Paused: true})
+	suite.Require().NoError(err)
+	suite.Assert().Nil(session.Info().ExpiresAt)
+	suite.Assert().Empty(session.Request.Map)
+	// the default seed comes from the clock
+	suite.Assert().Equal(suite.clock.Now().UnixNano(), session.Request.Seed)
+
+	// the error of an unknown example does not tell where maps are stored
+	_, err = sessions.Create(model.SimulationRequest{Example: "world", NumAliens: 1})
+	suite.Assert().ErrorIs(err, ErrInvalidSimulation)
+	suite.Assert().EqualError(err, "invalid simulation: unknown example [world]")
+}
+
+// TestSessionsTestSuite is the entry point of this test suite
+func TestSessionsTestSuite(t *testing.T) {
+	suite.Run(t, new(SessionsTestSuite))
+}
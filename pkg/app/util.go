@@ -5,7 +5,43 @@ import (
 	"time"
 )
 
-func getRandomInRange(min, max int) int {
-	rand.Seed(time.Now().UnixNano())
-	return rand.Intn(max-min+1) + min
+// splitMix is a SplitMix64 random source, its whole state is a single value so simulations
+// are checkpointed and restored without replaying their draws
+type splitMix struct {
+	state uint64
+}
+
+func (s *splitMix) Uint64() uint64 {
+	s.state += 0x9e3779b97f4a7c15
+	z := s.state
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return z ^ (z >> 31)
+}
+
+func (s *splitMix) Int63() int64 {
+	return int64(s.Uint64() >> 1)
+}
+
+func (s *splitMix) Seed(seed int64) {
+	s.state = uint64(seed)
+}
+
+// newRand returns a random generator, seeded with current time if seed is 0
+func newRand(seed int64) *rand.Rand {
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	return rand.New(rand.NewSource(seed))
+}
+
+// newSimulationRand returns the random generator of a simulation with its source, set to a
+// checkpointed state
+func newSimulationRand(state uint64) (*rand.Rand, *splitMix) {
+	src := &splitMix{state: state}
+	return rand.New(src), src
+}
+
+func getRandomInRange(rnd *rand.Rand, min, max int) int {
+	return rnd.Intn(max-min+1) + min
 }
@@ -0,0 +1,274 @@
+// Package auth authenticates API keys and HMAC signed tokens and authorizes them by role
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Role grants access to a set of operations, each role includes the lower ones
+type Role string
+
+const (
+	RoleNone Role = ""
+	// RoleViewer can observe simulations and read maps
+	RoleViewer Role = "viewer"
+	// RoleOperator can also control and create simulations and manage maps
+	RoleOperator Role = "operator"
+)
+
+var (
+	ErrUnauthenticated = errors.New("missing or invalid credentials")
+	ErrForbidden       = errors.New("insufficient role")
+)
+
+const (
+	// minSecretLength is the minimum length of token secrets
+	minSecretLength = 32
+	// minKeyLength is the minimum length of keys in clear
+	minKeyLength = 16
+	// minDistinct is the minimum number of distinct characters of keys and token secrets in clear
+	minDistinct = 6
+)
+
+// weakSecrets are placeholders and common values rejected as hashed keys, keys in clear are
+// already rejected by their length
+var weakSecrets = []string{"replace-me", "change-me", "changeme", "secret", "password", "test", "admin", "token", "key"}
+
+// placeholders are rejected when found anywhere on keys and token secrets in clear
+var placeholders = []string{"replace-me", "replaceme", "change-me", "changeme", "placeholder"}
+
+var roleLevels = map[Role]int{
+	RoleNone:     0,
+	RoleViewer:   1,
+	RoleOperator: 2,
+}
+
+// Allows reports whether the role includes another one
+func (r Role) Allows(required Role) bool {
+	return roleLevels[r] >= roleLevels[required]
+}
+
+func (r Role) valid() bool {
+	_, ok := roleLevels[r]
+	return ok
+}
+
+// Key is an API key, stored in clear or as the hex SHA-256 of the key
+type Key struct {
+	Name      string `json:"name" yaml:"name"`
+	Key       string `json:"key,omitempty" yaml:"key,omitempty"`
+	KeySHA256 string `json:"key_sha256,omitempty" yaml:"key_sha256,omitempty"`
+	Role      Role   `json:"role" yaml:"role"`
+}
+
+// Config is the auth configuration file
+type Config struct {
+	Keys []Key `json:"keys" yaml:"keys"`
+	// TokenSecret verifies HS256 signed bearer tokens (disabled if empty)
+	TokenSecret string `json:"token_secret,omitempty" yaml:"token_secret,omitempty"`
+	// Anonymous is the role of requests without credentials (none if empty)
+	Anonymous Role `json:"anonymous,omitempty" yaml:"anonymous,omitempty"`
+}
+
+// Identity is an authenticated caller
+type Identity struct {
+	Name string
+	Role Role
+}
+
+// Claims are the payload of a signed token, tokens without expiration are rejected
+type Claims struct {
+	Subject   string `json:"sub"`
+	Role      Role   `json:"role"`
+	ExpiresAt int64  `json:"exp,omitempty"`
+}
+
+type Authenticator struct {
+	// keys by hex SHA-256
+	keys      map[string]Key
+	secret    []byte
+	anonymous Role
+	now       func() time.Time
+}
+
+// LoadConfig reads an auth configuration from a .json, .yaml or .yml file
+func LoadConfig(filename string) (*Config, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	cfg := &Config{}
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".json":
+		err = json.Unmarshal(data, cfg)
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, cfg)
+	default:
+		return nil, fmt.Errorf("invalid auth file extension [%s] (should be .json, .yaml or .yml)", filepath.Ext(filename))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parsing auth config %s : %w", filename, err)
+	}
+	return cfg, nil
+}
+
+// NewAuthenticator validates a configuration and returns its authenticator, short or known
+// weak keys and token secrets (like the placeholders of the example file) are rejected
+func NewAuthenticator(cfg *Config) (*Authenticator, error) {
+	if !cfg.Anonymous.valid() {
+		return nil, fmt.Errorf("invalid anonymous role [%s]", cfg.Anonymous)
+	}
+	if cfg.TokenSecret != "" && (len(cfg.TokenSecret) < minSecretLength || isWeakValue(cfg.TokenSecret)) {
+		return nil, fmt.Errorf("weak token secret, it should be a random value of %d characters or more (e.g. openssl rand -hex 32)", minSecretLength)
+	}
+	a := &Authenticator{
+		keys:      make(map[string]Key),
+		secret:    []byte(cfg.TokenSecret),
+		anonymous: cfg.Anonymous,
+		now:       time.Now,
+	}
+	for _, key := range cfg.Keys {
+		if key.Role == RoleNone || !key.Role.valid() {
+			return nil, fmt.Errorf("key %s: invalid role [%s]", key.Name, key.Role)
+		}
+		hash := strings.ToLower(key.KeySHA256)
+		if key.Key != "" {
+			if len(key.Key) < minKeyLength || isWeakValue(key.Key) {
+				return nil, fmt.Errorf("key %s: weak key, it should be a random value of %d characters or more (e.g. openssl rand -hex 16)", key.Name, minKeyLength)
+			}
+			hash = hashKey(key.Key)
+		}
+		if _, err := hex.DecodeString(hash); err != nil || len(hash) != sha256.Size*2 {
+			return nil, fmt.Errorf("key %s: a key or a valid key_sha256 is required", key.Name)
+		}
+		if isWeak(hash) {
+			return nil, fmt.Errorf("key %s: key_sha256 is the hash of a weak key", key.Name)
+		}
+		a.keys[hash] = key
+	}
+	return a, nil
+}
+
+// Authenticate resolves the identity of an API key or a signed token, an empty
+// credential gets the anonymous role
+func (a *Authenticator) Authenticate(credential string) (*Identity, error) {
+	if credential == "" {
+		return &Identity{Name: "anonymous", Role: a.anonymous}, nil
+	}
+	// tokens have 3 dot separated parts, keys are looked up by hash
+	if strings.Count(credential, ".") == 2 && len(a.secret) > 0 {
+		return a.verifyToken(credential)
+	}
+	// looking up by hash does not leak the keys through timing
+	key, ok := a.keys[hashKey(credential)]
+	if !ok {
+		return nil, ErrUnauthenticated
+	}
+	return &Identity{Name: key.Name, Role: key.Role}, nil
+}
+
+// Authorize checks an identity has a role
+func Authorize(identity *Identity, required Role) error {
+	if identity == nil || identity.Role == RoleNone {
+		return ErrUnauthenticated
+	}
+	if !identity.Role.Allows(required) {
+		return ErrForbidden
+	}
+	return nil
+}
+
+var tokenHeader = base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+
+// SignToken returns a HS256 JWT for the claims
+func SignToken(secret string, claims Claims) (string, error) {
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+	signed := tokenHeader + "." + base64.RawURLEncoding.EncodeToString(payload)
+	return signed + "." + sign([]byte(secret), signed), nil
+}
+
+func (a *Authenticator) verifyToken(token string) (*Identity, error) {
+	parts := strings.Split(token, ".")
+	var header struct {
+		Alg string `json:"alg"`
+	}
+	if data, err := base64.RawURLEncoding.DecodeString(parts[0]); err != nil || json.Unmarshal(data, &header) != nil || header.Alg != "HS256" {
+		return nil, ErrUnauthenticated
+	}
+	expected := sign(a.secret, parts[0]+"."+parts[1])
+	if !hmac.Equal([]byte(expected), []byte(parts[2])) {
+		return nil, ErrUnauthenticated
+	}
+	var claims Claims
+	data, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil || json.Unmarshal(data, &claims) != nil {
+		return nil, ErrUnauthenticated
+	}
+	if claims.ExpiresAt == 0 || a.now().Unix() >= claims.ExpiresAt {
+		return nil, ErrUnauthenticated
+	}
+	if claims.Role == RoleNone || !claims.Role.valid() {
+		return nil, ErrUnauthenticated
+	}
+	return &Identity{Name: claims.Subject, Role: claims.Role}, nil
+}
+
+func sign(secret []byte, data string) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(data))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// isWeakValue reports whether a key or token secret in clear holds a placeholder or has low
+// entropy: few distinct characters or a repeated sequence of characters
+func isWeakValue(value string) bool {
+	lower := strings.ToLower(value)
+	for _, placeholder := range placeholders {
+		if strings.Contains(lower, placeholder) {
+			return true
+		}
+	}
+	distinct := make(map[rune]bool)
+	for _, r := range value {
+		distinct[r] = true
+	}
+	if len(distinct) < minDistinct {
+		return true
+	}
+	for period := 1; period <= len(value)/2; period++ {
+		if strings.HasPrefix(value, value[period:]) {
+			return true
+		}
+	}
+	return false
+}
+
+// isWeak reports whether a hashed key is the hash of one of the weak secrets
+func isWeak(hash string) bool {
+	for _, weak := range weakSecrets {
+		if hash == hashKey(weak) {
+			return true
+		}
+	}
+	return false
+}
+
+func hashKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,143 @@
+package auth
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+const (
+	secret    = "a-token-secret-of-32-characters!"
+	viewerKey = "viewer-key-for-tests"
+)
+
+type AuthTestSuite struct {
+	suite.Suite
+	auth *Authenticator
+}
+
+func (suite *AuthTestSuite) SetupTest() {
+	sum := sha256.Sum256([]byte("hashed-key"))
+	var err error
+	suite.auth, err = NewAuthenticator(&Config{
+		Keys: []Key{
+			{Name: "dashboard", Key: viewerKey, Role: RoleViewer},
+			{Name: "ops", KeySHA256: hex.EncodeToString(sum[:]), Role: RoleOperator},
+		},
+		TokenSecret: secret,
+		Anonymous:   RoleViewer,
+	})
+	suite.Require().NoError(err)
+	suite.auth.now = func() time.Time { return time.Unix(1000, 0) }
+}
+
+func (suite *AuthTestSuite) TestKeys() {
+	identity, err := suite.auth.Authenticate(viewerKey)
+	suite.Require().NoError(err)
+	suite.Assert().Equal(&Identity{Name: "dashboard", Role: RoleViewer}, identity)
+	suite.Assert().ErrorIs(Authorize(identity, RoleOperator), ErrForbidden)
+
+	identity, err = suite.auth.Authenticate("hashed-key")
+	suite.Require().NoError(err)
+	suite.Assert().NoError(Authorize(identity, RoleOperator))
+
+	_, err = suite.auth.Authenticate("unknown")
+	suite.Assert().ErrorIs(err, ErrUnauthenticated)
+
+	identity, err = suite.auth.Authenticate("")
+	suite.Require().NoError(err)
+	suite.Assert().Equal(RoleViewer, identity.Role)
+}
+
+func (suite *AuthTestSuite) TestTokens() {
+	token, err := SignToken(secret, Claims{Subject: "ci", Role: RoleOperator, ExpiresAt: 2000})
+	suite.Require().NoError(err)
+	identity, err := suite.auth.Authenticate(token)
+	suite.Require().NoError(err)
+	suite.Assert().Equal(&Identity{Name: "ci", Role: RoleOperator}, identity)
+
+	expired, err := SignToken(secret, Claims{Subject: "ci", Role: RoleOperator, ExpiresAt: 1000})
+	suite.Require().NoError(err)
+	_, err = suite.auth.Authenticate(expired)
+	suite.Assert().ErrorIs(err, ErrUnauthenticated)
+
+	forged, err := SignToken("another-token-secret-of-32-chars", Claims{Subject: "ci", Role: RoleOperator, ExpiresAt: 2000})
+	suite.Require().NoError(err)
+	_, err = suite.auth.Authenticate(forged)
+	suite.Assert().ErrorIs(err, ErrUnauthenticated)
+
+	// tokens must expire
+	unlimited, err := SignToken(secret, Claims{Subject: "ci", Role: RoleOperator})
+	suite.Require().NoError(err)
+	_, err = suite.auth.Authenticate(unlimited)
+	suite.Assert().ErrorIs(err, ErrUnauthenticated)
+}
+
+func (suite *AuthTestSuite) TestWeakSecrets() {
+	weak := hashKey("change-me")
+	for name, cfg := range map[string]*Config{
+		"short secret":       {TokenSecret: "s3cret"},
+		"placeholder secret": {TokenSecret: "replace-me"},
+		"short key":          {Keys: []Key{{Name: "ops", Key: "operator-key", Role: RoleOperator}}},
+		"placeholder key":    {Keys: []Key{{Name: "ops", Key: "REPLACE-ME", Role: RoleOperator}}},
+		"weak hashed key":    {Keys: []Key{{Name: "ops", KeySHA256: weak, Role: RoleOperator}}},
+		"test hashed key":    {Keys: []Key{{Name: "ops", KeySHA256: hashKey("test"), Role: RoleOperator}}},
+		// long enough, but placeholders or low entropy
+		"long placeholder secret": {TokenSecret: "replace-me-with-a-random-value-of-32-chars"},
+		"repeated secret":         {TokenSecret: strings.Repeat("a", minSecretLength)},
+		"few characters secret":   {TokenSecret: strings.Repeat("ab12", 10)},
+		"periodic secret":         {TokenSecret: strings.Repeat("s3cr3t-v4lu3", 3)},
+		"long placeholder key":    {Keys: []Key{{Name: "ops", Key: "ChangeMe-operator-key", Role: RoleOperator}}},
+		"repeated key":            {Keys: []Key{{Name: "ops", Key: strings.Repeat("0", minKeyLength), Role: RoleOperator}}},
+		"periodic key":            {Keys: []Key{{Name: "ops", Key: strings.Repeat("key-1234", 2), Role: RoleOperator}}},
+	} {
+		_, err := NewAuthenticator(cfg)
+		suite.Assert().Error(err, name)
+	}
+
+	// the example file does not load until its placeholders are replaced
+	cfg, err := LoadConfig("../../examples/auth.yaml")
+	suite.Require().NoError(err)
+	_, err = NewAuthenticator(cfg)
+	suite.Assert().ErrorContains(err, "weak token secret")
+	cfg.TokenSecret = secret
+	_, err = NewAuthenticator(cfg)
+	suite.Assert().ErrorContains(err, "key dashboard: weak key")
+	cfg.Keys[0].Key = viewerKey
+	_, err = NewAuthenticator(cfg)
+	suite.Assert().ErrorContains(err, "key ops: key_sha256 is the hash of a weak key")
+	cfg.Keys[1].KeySHA256 = hashKey("operator-key-for-tests")
+	_, err = NewAuthenticator(cfg)
+	suite.Assert().NoError(err)
+}
+
+func (suite *AuthTestSuite) TestLoadConfig() {
+	file := filepath.Join(suite.T().TempDir(), "auth.yaml")
+	suite.Require().NoError(os.WriteFile(file, []byte(`
+anonymous: viewer
+token_secret: a-token-secret-of-32-characters!
+keys:
+  - name: ops
+    key: operator-key-for-tests
+    role: operator
+`), 0o600))
+	cfg, err := LoadConfig(file)
+	suite.Require().NoError(err)
+	suite.Assert().Equal(RoleViewer, cfg.Anonymous)
+	suite.Require().Len(cfg.Keys, 1)
+	suite.Assert().Equal(RoleOperator, cfg.Keys[0].Role)
+
+	_, err = NewAuthenticator(&Config{Keys: []Key{{Name: "bad", Key: viewerKey, Role: "admin"}}})
+	suite.Assert().Error(err)
+}
+
+// TestAuth is the entry point of this test suite
+func TestAuth(t *testing.T) {
+	suite.Run(t, new(AuthTestSuite))
+}
@@ -0,0 +1,331 @@
+// Package client is a typed client for the Alien Invasion HTTP service (see /openapi.json)
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/c-kuroki/alien_invasion/pkg/model"
+)
+
+// Playback actions
+const (
+	ActionPause  = "pause"
+	ActionResume = "resume"
+	ActionStep   = "step"
+)
+
+// Error is returned when the service replies with an error status
+type Error struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("alien invasion service: %d %s", e.StatusCode, e.Message)
+}
+
+// Client calls the endpoints of a simulation, the one of a single simulation service or
+// a hosted one (see Simulation)
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+	// prefixes of simulation endpoints (map, state, control, events) and REST API endpoints
+	simPrefix string
+	apiPrefix string
+}
+
+// NewClient returns a client for the service at baseURL (e.g. http://localhost:8080),
+// http.DefaultClient is used if httpClient is nil
+func NewClient(baseURL string, httpClient *http.Client) *Client {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &Client{
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		httpClient: httpClient,
+		simPrefix:  "",
+		apiPrefix:  "/api/v1",
+	}
+}
+
+// Simulation returns a client for a simulation hosted by a service in server mode
+func (c *Client) Simulation(id string) *Client {
+	prefix := "/api/v1/simulations/" + url.PathEscape(id)
+	return &Client{
+		baseURL:    c.baseURL,
+		httpClient: c.httpClient,
+		simPrefix:  prefix,
+		apiPrefix:  prefix,
+	}
+}
+
+// CityFilter filters and paginates ListCities results, zero values are not sent
+type CityFilter struct {
+	Name      string
+	Destroyed *bool
+	HasAliens *bool
+	Offset    int
+	Limit     int
+}
+
+// AlienFilter filters and paginates ListAliens results, zero values are not sent (except City, -1 is not sent)
+type AlienFilter struct {
+	City   int
+	Name   string
+	Offset int
+	Limit  int
+}
+
+type CityPage struct {
+	Items  []*model.City `json:"items"`
+	Total  int           `json:"total"`
+	Offset int           `json:"offset"`
+	Limit  int           `json:"limit"`
+}
+
+type AlienPage struct {
+	Items  []*model.Alien `json:"items"`
+	Total  int            `json:"total"`
+	Offset int            `json:"offset"`
+	Limit  int            `json:"limit"`
+}
+
+// PlaybackStatus is returned by Control
+type PlaybackStatus struct {
+	Tick   int    `json:"tick"`
+	Status string `json:"status"`
+}
+
+func (c *Client) Status(ctx context.Context) (*model.Status, error) {
+	var status model.Status
+	err := c.getJSON(ctx, c.apiPrefix+"/status", nil, &status)
+	return &status, err
+}
+
+// Snapshot returns the whole simulation state
+func (c *Client) Snapshot(ctx context.Context) (*model.Snapshot, error) {
+	var snapshot model.Snapshot
+	err := c.getJSON(ctx, c.simPrefix+"/state", nil, &snapshot)
+	return &snapshot, err
+}
+
+func (c *Client) ListCities(ctx context.Context, filter CityFilter) (*CityPage, error) {
+	query := url.Values{}
+	if filter.Name != "" {
+		query.Set("name", filter.Name)
+	}
+	if filter.Destroyed != nil {
+		query.Set("destroyed", strconv.FormatBool(*filter.Destroyed))
+	}
+	if filter.HasAliens != nil {
+		query.Set("has_aliens", strconv.FormatBool(*filter.HasAliens))
+	}
+	setPagination(query, filter.Offset, filter.Limit)
+	var page CityPage
+	err := c.getJSON(ctx, c.apiPrefix+"/cities", query, &page)
+	return &page, err
+}
+
+// City returns a city by ID or name
+func (c *Client) City(ctx context.Context, idOrName string) (*model.City, error) {
+	var city model.City
+	err := c.getJSON(ctx, c.apiPrefix+"/cities/"+url.PathEscape(idOrName), nil, &city)
+	return &city, err
+}
+
+// Exits returns the roads leaving a city (by ID or name)
+func (c *Client) Exits(ctx context.Context, idOrName string) ([]model.Exit, error) {
+	var exits []model.Exit
+	err := c.getJSON(ctx, c.apiPrefix+"/cities/"+url.PathEscape(idOrName)+"/exits", nil, &exits)
+	return exits, err
+}
+
+func (c *Client) ListAliens(ctx context.Context, filter AlienFilter) (*AlienPage, error) {
+	query := url.Values{}
+	if filter.City >= 0 {
+		query.Set("city", strconv.Itoa(filter.City))
+	}
+	if filter.Name != "" {
+		query.Set("name", filter.Name)
+	}
+	setPagination(query, filter.Offset, filter.Limit)
+	var page AlienPage
+	err := c.getJSON(ctx, c.apiPrefix+"/aliens", query, &page)
+	return &page, err
+}
+
+func (c *Client) Alien(ctx context.Context, id int) (*model.Alien, error) {
+	var alien model.Alien
+	err := c.getJSON(ctx, c.apiPrefix+"/aliens/"+strconv.Itoa(id), nil, &alien)
+	return &alien, err
+}
+
+// Control pauses, resumes or steps the simulation
+func (c *Client) Control(ctx context.Context, action string) (*PlaybackStatus, error) {
+	var status PlaybackStatus
+	err := c.do(ctx, http.MethodPost, c.simPrefix+"/control/"+url.PathEscape(action), nil, nil, "", &status)
+	return &status, err
+}
+
+// Map writes the SVG map, overlay and theme are optional
+func (c *Client) Map(ctx context.Context, overlay, theme string, w io.Writer) error {
+	query := url.Values{}
+	if overlay != "" {
+		query.Set("overlay", overlay)
+	}
+	if theme != "" {
+		query.Set("theme", theme)
+	}
+	resp, err := c.send(ctx, http.MethodGet, c.simPrefix+"/map", query, nil, "")
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, err = io.Copy(w, resp.Body)
+	return err
+}
+
+// server mode
+
+// CreateSimulation starts a simulation hosted by a service in server mode
+func (c *Client) CreateSimulation(ctx context.Context, req model.SimulationRequest) (*model.SessionInfo, error) {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	var info model.SessionInfo
+	err = c.do(ctx, http.MethodPost, "/api/v1/simulations", nil, bytes.NewReader(body), "application/json", &info)
+	return &info, err
+}
+
+func (c *Client) ListSimulations(ctx context.Context) ([]*model.SessionInfo, error) {
+	var infos []*model.SessionInfo
+	err := c.getJSON(ctx, "/api/v1/simulations", nil, &infos)
+	return infos, err
+}
+
+func (c *Client) GetSimulation(ctx context.Context, id string) (*model.SessionInfo, error) {
+	var info model.SessionInfo
+	err := c.getJSON(ctx, "/api/v1/simulations/"+url.PathEscape(id), nil, &info)
+	return &info, err
+}
+
+func (c *Client) DeleteSimulation(ctx context.Context, id string) error {
+	return c.do(ctx, http.MethodDelete, "/api/v1/simulations/"+url.PathEscape(id), nil, nil, "", nil)
+}
+
+// maps
+
+func (c *Client) ListMaps(ctx context.Context) ([]*model.MapInfo, error) {
+	var maps []*model.MapInfo
+	err := c.getJSON(ctx, "/api/v1/maps", nil, &maps)
+	return maps, err
+}
+
+func (c *Client) DownloadMap(ctx context.Context, name string) ([]byte, error) {
+	resp, err := c.send(ctx, http.MethodGet, "/api/v1/maps/"+url.PathEscape(name), nil, nil, "")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	return io.ReadAll(resp.Body)
+}
+
+// UploadMap validates and stores a map, for invalid maps it returns an *Error (422) and the validation result
+func (c *Client) UploadMap(ctx context.Context, name string, data []byte) (*model.MapInfo, *model.MapValidation, error) {
+	resp, err := c.send(ctx, http.MethodPut, "/api/v1/maps/"+url.PathEscape(name), nil, bytes.NewReader(data), "text/plain")
+	if err != nil {
+		var apiErr *Error
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusUnprocessableEntity {
+			var validation model.MapValidation
+			if json.Unmarshal([]byte(apiErr.Message), &validation) == nil {
+				return nil, &validation, err
+			}
+		}
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+	var info model.MapInfo
+	if err := json.NewDecoder(resp.Body).Decode(&info); err != nil {
+		return nil, nil, err
+	}
+	return &info, &model.MapValidation{Valid: true}, nil
+}
+
+func (c *Client) ValidateMap(ctx context.Context, data []byte) (*model.MapValidation, error) {
+	var validation model.MapValidation
+	err := c.do(ctx, http.MethodPost, "/api/v1/maps/validate", nil, bytes.NewReader(data), "text/plain", &validation)
+	return &validation, err
+}
+
+func (c *Client) DeleteMap(ctx context.Context, name string) error {
+	return c.do(ctx, http.MethodDelete, "/api/v1/maps/"+url.PathEscape(name), nil, nil, "", nil)
+}
+
+// helpers
+
+func (c *Client) getJSON(ctx context.Context, path string, query url.Values, out interface{}) error {
+	return c.do(ctx, http.MethodGet, path, query, nil, "", out)
+}
+
+// do sends a request and decodes the JSON response into out (if not nil)
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body io.Reader, contentType string, out interface{}) error {
+	resp, err := c.send(ctx, method, path, query, body, contentType)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if out == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// send sends a request, error statuses are returned as *Error
+func (c *Client) send(ctx context.Context, method, path string, query url.Values, body io.Reader, contentType string) (*http.Response, error) {
+	u := c.baseURL + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u, body)
+	if err != nil {
+		return nil, err
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 400 {
+		defer resp.Body.Close()
+		data, _ := io.ReadAll(resp.Body)
+		message := strings.TrimSpace(string(data))
+		// error messages are sent as JSON strings
+		var s string
+		if json.Unmarshal(data, &s) == nil {
+			message = s
+		}
+		return nil, &Error{StatusCode: resp.StatusCode, Message: message}
+	}
+	return resp, nil
+}
+
+func setPagination(query url.Values, offset, limit int) {
+	if offset > 0 {
+		query.Set("offset", strconv.Itoa(offset))
+	}
+	if limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+}
@@ -0,0 +1,153 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+
+	"github.com/c-kuroki/alien_invasion/pkg/adapters/maps"
+	"github.com/c-kuroki/alien_invasion/pkg/adapters/renderer"
+	"github.com/c-kuroki/alien_invasion/pkg/adapters/world"
+	"github.com/c-kuroki/alien_invasion/pkg/app"
+	"github.com/c-kuroki/alien_invasion/pkg/auth"
+	"github.com/c-kuroki/alien_invasion/pkg/model"
+	httpport "github.com/c-kuroki/alien_invasion/pkg/ports/http"
+)
+
+const exampleMapFile = "../../examples/world.map"
+
+type ClientTestSuite struct {
+	suite.Suite
+	server *httptest.Server
+	client *Client
+	ctx    context.Context
+}
+
+func (suite *ClientTestSuite) SetupTest() {
+	suite.ctx = context.Background()
+	data, err := os.ReadFile(exampleMapFile)
+	suite.Require().NoError(err)
+	dir := suite.T().TempDir()
+	suite.Require().NoError(os.WriteFile(filepath.Join(dir, "world.map"), data, 0o644))
+
+	rnd := renderer.NewSVGRenderer()
+	cfg := &model.Config{TickInterval: 1000, MaxMoves: 10, NumAliens: 2, Seed: 1}
+	invasion := app.NewAlienInvasionApp(cfg, world.NewInMemoryState(exampleMapFile), rnd, zap.NewNop().Sugar())
+	suite.Require().NoError(invasion.Init())
+
+	store := maps.NewFileStore(dir)
+	sessions := app.NewSessionManager(app.DefaultSessionsConfig(), store, rnd, zap.NewNop().Sugar())
+	suite.T().Cleanup(sessions.Close)
+	srv := httpport.NewHTTPService(invasion, ":0")
+	srv.SetMaps(app.NewMapsApp(store, rnd))
+	srv.SetSessions(sessions)
+	// maps are only changed with authentication enabled
+	authenticator, err := auth.NewAuthenticator(&auth.Config{Anonymous: auth.RoleOperator})
+	suite.Require().NoError(err)
+	srv.SetAuth(authenticator)
+
+	suite.server = httptest.NewServer(srv.Router())
+	suite.T().Cleanup(suite.server.Close)
+	suite.client = NewClient(suite.server.URL, nil)
+}
+
+func (suite *ClientTestSuite) TestSimulation() {
+	status, err := suite.client.Status(suite.ctx)
+	suite.Require().NoError(err)
+	suite.Assert().Equal(2, status.AliveAliens)
+
+	cities, err := suite.client.ListCities(suite.ctx, CityFilter{Limit: 1})
+	suite.Require().NoError(err)
+	suite.Require().Len(cities.Items, 1)
+	suite.Assert().Equal(status.RemainingCities, cities.Total)
+
+	city, err := suite.client.City(suite.ctx, cities.Items[0].Name)
+	suite.Require().NoError(err)
+	suite.Assert().Equal(cities.Items[0].ID, city.ID)
+	_, err = suite.client.Exits(suite.ctx, city.Name)
+	suite.Require().NoError(err)
+
+	aliens, err := suite.client.ListAliens(suite.ctx, AlienFilter{City: -1})
+	suite.Require().NoError(err)
+	suite.Require().Len(aliens.Items, 2)
+	alien, err := suite.client.Alien(suite.ctx, aliens.Items[0].ID)
+	suite.Require().NoError(err)
+	suite.Assert().Equal(aliens.Items[0].Name, alien.Name)
+
+	snapshot, err := suite.client.Snapshot(suite.ctx)
+	suite.Require().NoError(err)
+	suite.Assert().Len(snapshot.Aliens, 2)
+
+	var svg bytes.Buffer
+	suite.Require().NoError(suite.client.Map(suite.ctx, "", renderer.ThemeDark, &svg))
+	suite.Assert().True(strings.Contains(svg.String(), "<svg"))
+}
+
+func (suite *ClientTestSuite) TestErrors() {
+	_, err := suite.client.Alien(suite.ctx, 1000)
+	var apiErr *Error
+	suite.Require().True(errors.As(err, &apiErr))
+	suite.Assert().Equal(http.StatusNotFound, apiErr.StatusCode)
+
+	_, err = suite.client.Control(suite.ctx, "rewind")
+	suite.Require().True(errors.As(err, &apiErr))
+	suite.Assert().Equal(http.StatusNotFound, apiErr.StatusCode)
+}
+
+func (suite *ClientTestSuite) TestSessions() {
+	info, err := suite.client.CreateSimulation(suite.ctx, model.SimulationRequest{
+		Example: "world", NumAliens: 3, Seed: 7, TickInterval: 1000, MaxMoves: 10, Paused: true,
+	})
+	suite.Require().NoError(err)
+	suite.Assert().NotEmpty(info.ID)
+
+	infos, err := suite.client.ListSimulations(suite.ctx)
+	suite.Require().NoError(err)
+	suite.Assert().Len(infos, 1)
+
+	aliens, err := suite.client.Simulation(info.ID).ListAliens(suite.ctx, AlienFilter{City: -1})
+	suite.Require().NoError(err)
+	suite.Assert().Equal(3, aliens.Total)
+
+	suite.Require().NoError(suite.client.DeleteSimulation(suite.ctx, info.ID))
+	_, err = suite.client.GetSimulation(suite.ctx, info.ID)
+	suite.Assert().Error(err)
+}
+
+func (suite *ClientTestSuite) TestMaps() {
+	data := []byte("Foo east=Bar\nBar west=Foo\n")
+	info, validation, err := suite.client.UploadMap(suite.ctx, "small", data)
+	suite.Require().NoError(err)
+	suite.Assert().True(validation.Valid)
+	suite.Assert().Equal("small", info.Name)
+
+	downloaded, err := suite.client.DownloadMap(suite.ctx, "small")
+	suite.Require().NoError(err)
+	suite.Assert().Equal(data, downloaded)
+
+	list, err := suite.client.ListMaps(suite.ctx)
+	suite.Require().NoError(err)
+	suite.Assert().Len(list, 2)
+
+	_, validation, err = suite.client.UploadMap(suite.ctx, "broken", []byte("Foo up=Bar\n"))
+	suite.Require().Error(err)
+	suite.Require().NotNil(validation)
+	suite.Assert().False(validation.Valid)
+	suite.Assert().Equal(uint64(1), validation.Error.Line)
+
+	suite.Require().NoError(suite.client.DeleteMap(suite.ctx, "small"))
+}
+
+// TestClient is the entry point of this test suite
+func TestClient(t *testing.T) {
+	suite.Run(t, new(ClientTestSuite))
+}
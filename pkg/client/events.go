@@ -0,0 +1,65 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/c-kuroki/alien_invasion/pkg/model"
+)
+
+// FromNow streams only the events of the next ticks
+const FromNow = -1
+
+// Events streams the simulation events (SSE) starting from a tick (or FromNow), the channel is
+// closed after the end event, when ctx is cancelled or when the connection is lost.
+// Stream errors are sent on the error channel (buffered) before closing.
+func (c *Client) Events(ctx context.Context, from int) (<-chan model.Event, <-chan error, error) {
+	query := url.Values{}
+	if from != FromNow {
+		query.Set("from", strconv.Itoa(from))
+	}
+	resp, err := c.send(ctx, http.MethodGet, c.simPrefix+"/events", query, nil, "")
+	if err != nil {
+		return nil, nil, err
+	}
+	events := make(chan model.Event)
+	errs := make(chan error, 1)
+	go func() {
+		defer close(events)
+		defer resp.Body.Close()
+		scanner := bufio.NewScanner(resp.Body)
+		var data strings.Builder
+		for scanner.Scan() {
+			line := scanner.Text()
+			switch {
+			case strings.HasPrefix(line, "data:"):
+				data.WriteString(strings.TrimSpace(strings.TrimPrefix(line, "data:")))
+			case line == "" && data.Len() > 0:
+				// blank line dispatches the event
+				var event model.Event
+				if err := json.Unmarshal([]byte(data.String()), &event); err != nil {
+					errs <- err
+					return
+				}
+				data.Reset()
+				select {
+				case events <- event:
+				case <-ctx.Done():
+					return
+				}
+				if event.Type == model.EventEnd {
+					return
+				}
+			}
+		}
+		if err := scanner.Err(); err != nil && ctx.Err() == nil {
+			errs <- err
+		}
+	}()
+	return events, errs, nil
+}
@@ -0,0 +1,54 @@
+// Package clock abstracts time so timing behaviour can be driven by tests
+package clock
+
+import "time"
+
+// Clock tells the time and waits for it
+type Clock interface {
+	Now() time.Time
+	NewTicker(d time.Duration) Ticker
+	Sleep(d time.Duration)
+}
+
+// Ticker delivers the time on its channel every period
+type Ticker interface {
+	C() <-chan time.Time
+	Stop()
+}
+
+// check that interfaces are implemented
+var (
+	_ Clock  = realClock{}
+	_ Ticker = (*realTicker)(nil)
+)
+
+// New returns the system clock
+func New() Clock {
+	return realClock{}
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) NewTicker(d time.Duration) Ticker {
+	return &realTicker{ticker: time.NewTicker(d)}
+}
+
+func (realClock) Sleep(d time.Duration) {
+	time.Sleep(d)
+}
+
+type realTicker struct {
+	ticker *time.Ticker
+}
+
+func (t *realTicker) C() <-chan time.Time {
+	return t.ticker.C
+}
+
+func (t *realTicker) Stop() {
+	t.ticker.Stop()
+}
@@ -0,0 +1,122 @@
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+// check that interfaces are implemented
+var (
+	_ Clock  = (*Fake)(nil)
+	_ Ticker = (*fakeTicker)(nil)
+)
+
+// Fake is a clock whose time only moves when Advance is called, tickers and sleepers are
+// triggered by it without actually waiting
+type Fake struct {
+	mu       sync.Mutex
+	cond     *sync.Cond
+	now      time.Time
+	tickers  map[*fakeTicker]bool
+	sleepers map[*sleeper]bool
+}
+
+type fakeTicker struct {
+	clock  *Fake
+	c      chan time.Time
+	period time.Duration
+	next   time.Time
+}
+
+type sleeper struct {
+	until time.Time
+	done  chan struct{}
+}
+
+// NewFake returns a fake clock set at now
+func NewFake(now time.Time) *Fake {
+	c := &Fake{
+		now:      now,
+		tickers:  make(map[*fakeTicker]bool),
+		sleepers: make(map[*sleeper]bool),
+	}
+	c.cond = sync.NewCond(&c.mu)
+	return c
+}
+
+func (c *Fake) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// NewTicker returns a ticker firing as the clock advances, like time.Ticker it drops ticks
+// while its channel is full
+func (c *Fake) NewTicker(d time.Duration) Ticker {
+	if d <= 0 {
+		panic("non-positive interval for NewTicker")
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	t := &fakeTicker{clock: c, c: make(chan time.Time, 1), period: d, next: c.now.Add(d)}
+	c.tickers[t] = true
+	c.cond.Broadcast()
+	return t
+}
+
+// Sleep blocks until the clock is advanced by d
+func (c *Fake) Sleep(d time.Duration) {
+	if d <= 0 {
+		return
+	}
+	c.mu.Lock()
+	s := &sleeper{until: c.now.Add(d), done: make(chan struct{})}
+	c.sleepers[s] = true
+	c.cond.Broadcast()
+	c.mu.Unlock()
+	<-s.done
+}
+
+// Advance moves the clock forward, firing the tickers and waking the sleepers that are due
+func (c *Fake) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	for t := range c.tickers {
+		for !t.next.After(c.now) {
+			select {
+			case t.c <- t.next:
+			default:
+			}
+			t.next = t.next.Add(t.period)
+		}
+	}
+	for s := range c.sleepers {
+		if !s.until.After(c.now) {
+			delete(c.sleepers, s)
+			close(s.done)
+		}
+	}
+	c.cond.Broadcast()
+}
+
+// BlockUntil waits until there are n running tickers and sleepers, so the clock is not advanced
+// before the code under test waits on it
+func (c *Fake) BlockUntil(n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for len(c.tickers)+len(c.sleepers) < n {
+		c.cond.Wait()
+	}
+}
+
+func (t *fakeTicker) C() <-chan time.Time {
+	return t.c
+}
+
+func (t *fakeTicker) Stop() {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	delete(t.clock.tickers, t)
+	t.clock.cond.Broadcast()
+}
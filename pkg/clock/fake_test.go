@@ -0,0 +1,70 @@
+package clock
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type FakeTestSuite struct {
+	suite.Suite
+	start time.Time
+	clock *Fake
+}
+
+func (suite *FakeTestSuite) SetupTest() {
+	suite.start = time.Date(2022, 11, 22, 12, 53, 16, 0, time.UTC)
+	suite.clock = NewFake(suite.start)
+}
+
+func (suite *FakeTestSuite) TestNow() {
+	suite.Assert().Equal(suite.start, suite.clock.Now())
+	suite.clock.Advance(time.Minute)
+	suite.Assert().Equal(suite.start.Add(time.Minute), suite.clock.Now())
+}
+
+func (suite *FakeTestSuite) TestTicker() {
+	ticker := suite.clock.NewTicker(100 * time.Millisecond)
+	suite.clock.Advance(99 * time.Millisecond)
+	suite.Assert().Empty(ticker.C())
+	suite.clock.Advance(time.Millisecond)
+	suite.Require().Len(ticker.C(), 1)
+	suite.Assert().Equal(suite.start.Add(100*time.Millisecond), <-ticker.C())
+
+	// ticks are dropped while the channel is full
+	suite.clock.Advance(350 * time.Millisecond)
+	suite.Require().Len(ticker.C(), 1)
+	suite.Assert().Equal(suite.start.Add(200*time.Millisecond), <-ticker.C())
+	suite.clock.Advance(50 * time.Millisecond)
+	suite.Assert().Equal(suite.start.Add(500*time.Millisecond), <-ticker.C())
+
+	ticker.Stop()
+	suite.clock.Advance(time.Second)
+	suite.Assert().Empty(ticker.C())
+}
+
+func (suite *FakeTestSuite) TestSleep() {
+	done := make(chan time.Time)
+	go func() {
+		suite.clock.Sleep(time.Second)
+		done <- suite.clock.Now()
+	}()
+	suite.clock.BlockUntil(1)
+	suite.clock.Advance(999 * time.Millisecond)
+	select {
+	case <-done:
+		suite.Fail("sleep ended before its time")
+	default:
+	}
+	suite.clock.Advance(time.Millisecond)
+	suite.Assert().Equal(suite.start.Add(time.Second), <-done)
+
+	// non-positive sleeps return at once
+	suite.clock.Sleep(0)
+}
+
+// TestFakeTestSuite is the entry point of this test suite
+func TestFakeTestSuite(t *testing.T) {
+	suite.Run(t, new(FakeTestSuite))
+}
@@ -0,0 +1,292 @@
+// Package metrics is a minimal metrics registry exposed in Prometheus text format
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Metric types
+const (
+	typeCounter   = "counter"
+	typeGauge     = "gauge"
+	typeHistogram = "histogram"
+)
+
+// DefaultBuckets are histogram buckets (in seconds) suited to request latencies
+var DefaultBuckets = []float64{.001, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+// CollectFunc emits the samples of a metric at scrape time
+type CollectFunc func(emit func(value float64, labelValues ...string))
+
+// Registry holds metrics and writes them in Prometheus text format
+type Registry struct {
+	mu       sync.Mutex
+	families []*family
+	names    map[string]bool
+}
+
+func NewRegistry() *Registry {
+	return &Registry{names: make(map[string]bool)}
+}
+
+// family is a metric with all its labeled series
+type family struct {
+	name       string
+	help       string
+	typ        string
+	labelNames []string
+	buckets    []float64
+	series     map[string]*series
+	collect    CollectFunc
+}
+
+type series struct {
+	labelValues []string
+	value       float64
+	// histogram
+	counts []uint64
+	count  uint64
+}
+
+type Counter struct {
+	reg *Registry
+	f   *family
+}
+
+// Inc adds 1 to the series with the given label values
+func (c *Counter) Inc(labelValues ...string) {
+	c.Add(1, labelValues...)
+}
+
+// Add adds a non negative value to the series with the given label values
+func (c *Counter) Add(v float64, labelValues ...string) {
+	if v < 0 {
+		return
+	}
+	c.reg.mu.Lock()
+	defer c.reg.mu.Unlock()
+	c.f.get(labelValues).value += v
+}
+
+type Gauge struct {
+	reg *Registry
+	f   *family
+}
+
+func (g *Gauge) Set(v float64, labelValues ...string) {
+	g.reg.mu.Lock()
+	defer g.reg.mu.Unlock()
+	g.f.get(labelValues).value = v
+}
+
+func (g *Gauge) Add(v float64, labelValues ...string) {
+	g.reg.mu.Lock()
+	defer g.reg.mu.Unlock()
+	g.f.get(labelValues).value += v
+}
+
+type Histogram struct {
+	reg *Registry
+	f   *family
+}
+
+// Observe records a value on the series with the given label values
+func (h *Histogram) Observe(v float64, labelValues ...string) {
+	h.reg.mu.Lock()
+	defer h.reg.mu.Unlock()
+	s := h.f.get(labelValues)
+	if s.counts == nil {
+		s.counts = make([]uint64, len(h.f.buckets))
+	}
+	for i, bound := range h.f.buckets {
+		if v <= bound {
+			s.counts[i]++
+		}
+	}
+	s.count++
+	s.value += v
+}
+
+func (r *Registry) NewCounter(name, help string, labelNames ...string) *Counter {
+	return &Counter{reg: r, f: r.register(name, help, typeCounter, labelNames, nil, nil)}
+}
+
+func (r *Registry) NewGauge(name, help string, labelNames ...string) *Gauge {
+	return &Gauge{reg: r, f: r.register(name, help, typeGauge, labelNames, nil, nil)}
+}
+
+// NewHistogram registers a histogram, DefaultBuckets are used if buckets is nil
+func (r *Registry) NewHistogram(name, help string, buckets []float64, labelNames ...string) *Histogram {
+	if buckets == nil {
+		buckets = DefaultBuckets
+	}
+	buckets = append([]float64(nil), buckets...)
+	sort.Float64s(buckets)
+	return &Histogram{reg: r, f: r.register(name, help, typeHistogram, labelNames, buckets, nil)}
+}
+
+// NewCounterFunc registers a counter whose samples are collected at scrape time
+func (r *Registry) NewCounterFunc(name, help string, collect CollectFunc, labelNames ...string) {
+	r.register(name, help, typeCounter, labelNames, nil, collect)
+}
+
+// NewGaugeFunc registers a gauge whose samples are collected at scrape time
+func (r *Registry) NewGaugeFunc(name, help string, collect CollectFunc, labelNames ...string) {
+	r.register(name, help, typeGauge, labelNames, nil, collect)
+}
+
+// register adds a metric family, registering the same name twice is a programming error
+func (r *Registry) register(name, help, typ string, labelNames []string, buckets []float64, collect CollectFunc) *family {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.names[name] {
+		panic(fmt.Sprintf("metric %s already registered", name))
+	}
+	r.names[name] = true
+	f := &family{
+		name:       name,
+		help:       help,
+		typ:        typ,
+		labelNames: labelNames,
+		buckets:    buckets,
+		series:     make(map[string]*series),
+		collect:    collect,
+	}
+	r.families = append(r.families, f)
+	return f
+}
+
+// get returns the series of the given label values, creating it if needed (registry mu must be held)
+func (f *family) get(labelValues []string) *series {
+	if len(labelValues) != len(f.labelNames) {
+		panic(fmt.Sprintf("metric %s: expected %d label values, got %d", f.name, len(f.labelNames), len(labelValues)))
+	}
+	key := strings.Join(labelValues, "\xff")
+	s, ok := f.series[key]
+	if !ok {
+		s = &series{labelValues: append([]string(nil), labelValues...)}
+		f.series[key] = s
+	}
+	return s
+}
+
+// WriteTo writes all metrics in Prometheus text exposition format (version 0.0.4)
+func (r *Registry) WriteTo(w io.Writer) (int64, error) {
+	r.mu.Lock()
+	families := append([]*family(nil), r.families...)
+	r.mu.Unlock()
+
+	cw := &countingWriter{w: bufio.NewWriter(w)}
+	for _, f := range families {
+		fmt.Fprintf(cw, "# HELP %s %s\n", f.name, escape(f.help, false))
+		fmt.Fprintf(cw, "# TYPE %s %s\n", f.name, f.typ)
+		if f.collect != nil {
+			// collected outside the registry lock, collectors may be slow
+			f.collect(func(value float64, labelValues ...string) {
+				writeSample(cw, f.name, f.labelNames, labelValues, "", "", value)
+			})
+			continue
+		}
+		r.mu.Lock()
+		for _, s := range f.sorted() {
+			if f.typ != typeHistogram {
+				writeSample(cw, f.name, f.labelNames, s.labelValues, "", "", s.value)
+				continue
+			}
+			for i, bound := range f.buckets {
+				var count uint64
+				if s.counts != nil {
+					count = s.counts[i]
+				}
+				writeSample(cw, f.name+"_bucket", f.labelNames, s.labelValues, "le", formatFloat(bound), float64(count))
+			}
+			writeSample(cw, f.name+"_bucket", f.labelNames, s.labelValues, "le", "+Inf", float64(s.count))
+			writeSample(cw, f.name+"_sum", f.labelNames, s.labelValues, "", "", s.value)
+			writeSample(cw, f.name+"_count", f.labelNames, s.labelValues, "", "", float64(s.count))
+		}
+		r.mu.Unlock()
+	}
+	if cw.err != nil {
+		return cw.n, cw.err
+	}
+	return cw.n, cw.w.Flush()
+}
+
+// sorted returns the series ordered by label values, so output is stable
+func (f *family) sorted() []*series {
+	keys := make([]string, 0, len(f.series))
+	for key := range f.series {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	list := make([]*series, 0, len(keys))
+	for _, key := range keys {
+		list = append(list, f.series[key])
+	}
+	return list
+}
+
+func writeSample(w io.Writer, name string, labelNames, labelValues []string, extraName, extraValue string, value float64) {
+	var labels []string
+	for i, labelName := range labelNames {
+		var labelValue string
+		if i < len(labelValues) {
+			labelValue = labelValues[i]
+		}
+		labels = append(labels, fmt.Sprintf(`%s="%s"`, labelName, escape(labelValue, true)))
+	}
+	if extraName != "" {
+		labels = append(labels, fmt.Sprintf(`%s="%s"`, extraName, extraValue))
+	}
+	if len(labels) > 0 {
+		fmt.Fprintf(w, "%s{%s} %s\n", name, strings.Join(labels, ","), formatFloat(value))
+		return
+	}
+	fmt.Fprintf(w, "%s %s\n", name, formatFloat(value))
+}
+
+func formatFloat(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	case math.IsNaN(v):
+		return "NaN"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+// escape escapes backslashes and new lines (and double quotes on label values)
+func escape(s string, quotes bool) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, "\n", `\n`)
+	if quotes {
+		s = strings.ReplaceAll(s, `"`, `\"`)
+	}
+	return s
+}
+
+// countingWriter counts written bytes and keeps the first error
+type countingWriter struct {
+	w   *bufio.Writer
+	n   int64
+	err error
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	if cw.err != nil {
+		return 0, cw.err
+	}
+	n, err := cw.w.Write(p)
+	cw.n += int64(n)
+	cw.err = err
+	return n, err
+}
@@ -0,0 +1,60 @@
+package metrics
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type MetricsTestSuite struct {
+	suite.Suite
+	reg *Registry
+}
+
+func (suite *MetricsTestSuite) SetupTest() {
+	suite.reg = NewRegistry()
+}
+
+func (suite *MetricsTestSuite) TestTextFormat() {
+	requests := suite.reg.NewCounter("requests_total", "Requests.", "code")
+	requests.Inc("200")
+	requests.Add(2, "500")
+	requests.Inc("200")
+	suite.reg.NewGaugeFunc("tick", "Last tick.", func(emit func(float64, ...string)) {
+		emit(42, `sim "a"`)
+	}, "simulation")
+	latency := suite.reg.NewHistogram("latency_seconds", "Latency.", []float64{0.1, 1})
+	latency.Observe(0.05)
+	latency.Observe(0.5)
+	latency.Observe(2)
+
+	var buf bytes.Buffer
+	_, err := suite.reg.WriteTo(&buf)
+	suite.Require().NoError(err)
+	suite.Assert().Equal(`# HELP requests_total Requests.
+# TYPE requests_total counter
+requests_total{code="200"} 2
+requests_total{code="500"} 2
+# HELP tick Last tick.
+# TYPE tick gauge
+tick{simulation="sim \"a\""} 42
+# HELP latency_seconds Latency.
+# TYPE latency_seconds histogram
+latency_seconds_bucket{le="0.1"} 1
+latency_seconds_bucket{le="1"} 2
+latency_seconds_bucket{le="+Inf"} 3
+latency_seconds_sum 2.55
+latency_seconds_count 3
+`, buf.String())
+}
+
+func (suite *MetricsTestSuite) TestDuplicatedName() {
+	suite.reg.NewGauge("tick", "Last tick.")
+	suite.Assert().Panics(func() { suite.reg.NewCounter("tick", "Last tick.") })
+}
+
+// TestMetrics is the entry point of this test suite
+func TestMetrics(t *testing.T) {
+	suite.Run(t, new(MetricsTestSuite))
+}
@@ -7,16 +7,21 @@ import (
 )
 
 type Alien struct {
-	ID   int
-	Name string
-	City int
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+	City int    `json:"city"`
+	// Origin is the city where the alien landed
+	Origin int `json:"origin"`
+	// Moves is the number of moves made by the alien
+	Moves int `json:"moves"`
 }
 
 func NewAlien(ID, cityID int) *Alien {
 	return &Alien{
-		ID:   ID,
-		Name: randomAlienName(ID),
-		City: cityID,
+		ID:     ID,
+		Name:   randomAlienName(ID),
+		City:   cityID,
+		Origin: cityID,
 	}
 }
 
@@ -0,0 +1,34 @@
+package model
+
+import "time"
+
+// CheckpointVersion is increased when the checkpoint format changes
+const CheckpointVersion = 2
+
+// Checkpoint is the full state of a simulation at a tick, a simulation restored from it continues
+// exactly where it left off
+type Checkpoint struct {
+	Version int    `json:"version"`
+	Config  Config `json:"config"`
+	// Time the checkpoint was written
+	Time time.Time `json:"time"`
+	Tick int       `json:"tick"`
+	// Seed initialized the random generator, RandState is its current state
+	Seed      int64  `json:"seed"`
+	RandState uint64 `json:"rand_state"`
+	// world
+	Width  int      `json:"width"`
+	Height int      `json:"height"`
+	Cities []*City  `json:"cities"`
+	Aliens []*Alien `json:"aliens"`
+	// InitialCities are the cities before any destruction, DestroyedCities the ones destroyed so far
+	InitialCities   []*City        `json:"initial_cities"`
+	DestroyedCities []*City        `json:"destroyed_cities"`
+	Destructions    []*Destruction `json:"destructions"`
+	Stats           *Stats         `json:"stats"`
+	// counters
+	Moves  int `json:"moves"`
+	Fights int `json:"fights"`
+	// Events of the last ticks, so event streams resume across restarts
+	Events []Event `json:"events,omitempty"`
+}
@@ -21,6 +21,15 @@ type City struct {
 	West  string `json:"west"`
 	X     int    `json:"x"`
 	Y     int    `json:"y"`
+	// Destroyed is set on cities removed by a fight, kept only for rendering
+	Destroyed bool `json:"destroyed,omitempty"`
+}
+
+// Exit is a road from a city to a neighbour
+type Exit struct {
+	Direction string `json:"direction"`
+	CityID    int    `json:"city_id"`
+	CityName  string `json:"city_name"`
 }
 
 func (c *City) String() string {
@@ -38,3 +47,21 @@ var validCard map[string]bool = map[string]bool{
 func IsValidCard(card string) bool {
 	return validCard[card]
 }
+
+// Roads returns the names of connected cities by direction
+func (c *City) Roads() map[string]string {
+	roads := make(map[string]string, 4)
+	if c.North != "" {
+		roads[North] = c.North
+	}
+	if c.East != "" {
+		roads[East] = c.East
+	}
+	if c.South != "" {
+		roads[South] = c.South
+	}
+	if c.West != "" {
+		roads[West] = c.West
+	}
+	return roads
+}
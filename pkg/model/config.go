@@ -1,8 +1,102 @@
 package model
 
+import "time"
+
 type Config struct {
-	MapFilename  string
-	TickInterval int
-	MaxMoves     int
-	NumAliens    int
+	MapFilename string `json:"map" yaml:"map"`
+	// TickInterval is the pause between moves in ms, 0 runs moves without pause
+	TickInterval int `json:"tick_interval" yaml:"tick_interval"`
+	MaxMoves     int `json:"max_moves" yaml:"max_moves"`
+	NumAliens    int `json:"aliens" yaml:"aliens"`
+	// Seed initializes the random generator, 0 uses current time
+	Seed int64 `json:"seed" yaml:"seed"`
+	// NoFinalMap disables writing the final map file (e.g. on server sessions)
+	NoFinalMap bool `json:"no_final_map" yaml:"no_final_map"`
+	// Theme is a preset name or a theme file
+	Theme string `json:"theme" yaml:"theme"`
+	// EventsFile records the simulation events as JSON lines, used by replays
+	EventsFile string `json:"events_file,omitempty" yaml:"events_file,omitempty"`
+	// EventTicks is the number of ticks of events kept to resume event streams, 0 uses the
+	// default and -1 keeps none
+	EventTicks int `json:"event_ticks,omitempty" yaml:"event_ticks,omitempty"`
+	// DBFile keeps the world on a database file committed every tick, an interrupted run using
+	// the same file resumes from its last committed tick
+	DBFile string `json:"db_file,omitempty" yaml:"db_file,omitempty"`
+	// CheckpointFile receives the full simulation state every CheckpointEvery ticks (0 only when
+	// stopped), a run can be resumed from it
+	CheckpointFile  string `json:"checkpoint_file,omitempty" yaml:"checkpoint_file,omitempty"`
+	CheckpointEvery int    `json:"checkpoint_every" yaml:"checkpoint_every"`
+	// History keeps the world history with a snapshot every History ticks, so past ticks can be
+	// queried (0 disables it)
+	History int `json:"history" yaml:"history"`
+	// HistoryTicks is the number of last ticks kept in the history, older ticks can not be
+	// queried (0 keeps every tick)
+	HistoryTicks int `json:"history_ticks,omitempty" yaml:"history_ticks,omitempty"`
+	// Debug checks the world invariants after every tick, aborting the run with a dump of the
+	// world on violations
+	Debug   bool          `json:"debug,omitempty" yaml:"debug,omitempty"`
+	Output  OutputConfig  `json:"output" yaml:"output"`
+	Service ServiceConfig `json:"service" yaml:"service"`
+}
+
+// OutputConfig sets where and how the final map is written
+type OutputConfig struct {
+	// Dir is the directory of output files, relative filenames are written on it
+	Dir string `json:"dir" yaml:"dir"`
+	// Template is the final map filename, a text/template with Time, Seed, Tick and Ext fields
+	Template string `json:"template" yaml:"template"`
+	// Stdout writes the final map to stdout instead of a file
+	Stdout bool `json:"stdout" yaml:"stdout"`
+	// Format of the final map: text (map file), json or dot (Graphviz)
+	Format string `json:"format" yaml:"format"`
+	// Report is the format of the summary report written to stdout (stderr if the final map is
+	// written to stdout): text, json or none
+	Report string `json:"report" yaml:"report"`
+	// SummaryFile is a summary report file, JSON if its extension is .json and text otherwise,
+	// not written if empty (also a template)
+	SummaryFile string `json:"summary_file,omitempty" yaml:"summary_file,omitempty"`
+}
+
+// ServiceConfig configures the http and grpc services, addresses set to -1 disable a service
+type ServiceConfig struct {
+	HTTPAddress string `json:"http_address" yaml:"http_address"`
+	GRPCAddress string `json:"grpc_address" yaml:"grpc_address"`
+	MapsDir     string `json:"maps_dir" yaml:"maps_dir"`
+	// MapPresets is a directory of read-only maps, also listed and used by name
+	MapPresets string `json:"map_presets" yaml:"map_presets"`
+	AuthFile   string `json:"auth_file,omitempty" yaml:"auth_file,omitempty"`
+	// http server, zero timeouts mean no timeout
+	ReadTimeout  Duration `json:"read_timeout" yaml:"read_timeout"`
+	WriteTimeout Duration `json:"write_timeout" yaml:"write_timeout"`
+	IdleTimeout  Duration `json:"idle_timeout" yaml:"idle_timeout"`
+	TLSCertFile  string   `json:"tls_cert,omitempty" yaml:"tls_cert,omitempty"`
+	TLSKeyFile   string   `json:"tls_key,omitempty" yaml:"tls_key,omitempty"`
+	// server mode
+	MaxSessions     int      `json:"max_sessions" yaml:"max_sessions"`
+	SessionLifetime Duration `json:"session_lifetime" yaml:"session_lifetime"`
+}
+
+// Duration is a time.Duration encoded as text (e.g. 30s) on config files and flags
+type Duration time.Duration
+
+func (d Duration) String() string {
+	return time.Duration(d).String()
+}
+
+// Set parses a flag value
+func (d *Duration) Set(s string) error {
+	v, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = Duration(v)
+	return nil
+}
+
+func (d Duration) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+func (d *Duration) UnmarshalText(text []byte) error {
+	return d.Set(string(text))
 }
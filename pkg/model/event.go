@@ -0,0 +1,63 @@
+package model
+
+import "encoding/json"
+
+// Event types
+const (
+	// EventSpawn an alien was placed on a city before the first tick
+	EventSpawn = "spawn"
+	// EventMove an alien moved between two cities
+	EventMove = "move"
+	// EventDestroy a city was destroyed by a fight, killing its aliens
+	EventDestroy = "destroy"
+	// EventTick all the moves and fights of a tick were done
+	EventTick = "tick"
+	// EventEnd the simulation finished
+	EventEnd = "end"
+)
+
+// Event is a change of the simulation state during a tick
+type Event struct {
+	Tick int    `json:"tick"`
+	Type string `json:"type"`
+	// spawn and move
+	AlienID int `json:"alien_id"`
+	From    int `json:"from"`
+	To      int `json:"to"`
+	// spawn and destroy
+	CityID   int    `json:"city_id"`
+	CityName string `json:"city_name,omitempty"`
+	Aliens   []int  `json:"aliens,omitempty"`
+	// tick and end
+	AliveAliens     int    `json:"alive_aliens,omitempty"`
+	RemainingCities int    `json:"remaining_cities,omitempty"`
+	Reason          string `json:"reason,omitempty"`
+}
+
+// MarshalJSON encodes only the fields used by the event type (0 is a valid ID, so omitempty can not be used)
+func (e Event) MarshalJSON() ([]byte, error) {
+	fields := map[string]interface{}{
+		"tick": e.Tick,
+		"type": e.Type,
+	}
+	switch e.Type {
+	case EventSpawn:
+		fields["alien_id"] = e.AlienID
+		fields["city_id"] = e.CityID
+	case EventMove:
+		fields["alien_id"] = e.AlienID
+		fields["from"] = e.From
+		fields["to"] = e.To
+	case EventDestroy:
+		fields["city_id"] = e.CityID
+		fields["city_name"] = e.CityName
+		fields["aliens"] = e.Aliens
+	case EventTick, EventEnd:
+		fields["alive_aliens"] = e.AliveAliens
+		fields["remaining_cities"] = e.RemainingCities
+		if e.Reason != "" {
+			fields["reason"] = e.Reason
+		}
+	}
+	return json.Marshal(fields)
+}
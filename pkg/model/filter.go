@@ -0,0 +1,17 @@
+package model
+
+// CityFilter selects cities, empty fields match any city
+type CityFilter struct {
+	// Name substring
+	Name      string
+	Destroyed *bool
+	HasAliens *bool
+}
+
+// AlienFilter selects aliens, empty fields match any alien
+type AlienFilter struct {
+	// CityID of the city the aliens are in, -1 for any city
+	CityID int
+	// Name substring
+	Name string
+}
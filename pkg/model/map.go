@@ -0,0 +1,37 @@
+package model
+
+import (
+	"fmt"
+	"time"
+)
+
+// MapInfo describes a stored world map
+type MapInfo struct {
+	Name      string    `json:"name"`
+	Size      int64     `json:"size"`
+	UpdatedAt time.Time `json:"updated_at"`
+	// ReadOnly maps are presets that can not be replaced nor deleted
+	ReadOnly bool `json:"read_only,omitempty"`
+}
+
+// MapValidation is the result of validating a world map
+type MapValidation struct {
+	Valid  bool      `json:"valid"`
+	Cities int       `json:"cities,omitempty"`
+	Width  int       `json:"width,omitempty"`
+	Height int       `json:"height,omitempty"`
+	Error  *MapError `json:"error,omitempty"`
+}
+
+// MapError describes why a map is invalid, Line is 0 for errors not related to a single line
+type MapError struct {
+	Line    uint64 `json:"line,omitempty"`
+	Message string `json:"message"`
+}
+
+func (e *MapError) Error() string {
+	if e.Line == 0 {
+		return e.Message
+	}
+	return fmt.Sprintf("line %d: %s", e.Line, e.Message)
+}
@@ -0,0 +1,34 @@
+package model
+
+import "time"
+
+// SimulationRequest holds the parameters of a simulation hosted by the server
+type SimulationRequest struct {
+	// Map is an uploaded map content, if empty Example is used
+	Map string `json:"map,omitempty"`
+	// Example is the name of a stored map (e.g. big)
+	Example      string `json:"example,omitempty"`
+	NumAliens    int    `json:"aliens"`
+	Seed         int64  `json:"seed,omitempty"`
+	TickInterval int    `json:"tick_interval,omitempty"`
+	MaxMoves     int    `json:"max_moves,omitempty"`
+	// Paused starts the simulation paused
+	Paused bool `json:"paused,omitempty"`
+	// History keeps the world history with a snapshot every History ticks, so past ticks can be
+	// queried (0 disables it)
+	History int `json:"history,omitempty"`
+}
+
+// SessionInfo describes a simulation hosted by the server
+type SessionInfo struct {
+	ID        string    `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	// ExpiresAt is nil for simulations kept until deleted
+	ExpiresAt    *time.Time `json:"expires_at,omitempty"`
+	Example      string     `json:"example,omitempty"`
+	NumAliens    int        `json:"aliens"`
+	Seed         int64      `json:"seed"`
+	TickInterval int        `json:"tick_interval"`
+	MaxMoves     int        `json:"max_moves"`
+	Status       *Status    `json:"status"`
+}
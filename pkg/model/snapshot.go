@@ -0,0 +1,64 @@
+package model
+
+// Simulation status
+const (
+	StatusLoading  = "loading"
+	StatusRunning  = "running"
+	StatusPaused   = "paused"
+	StatusFinished = "finished"
+)
+
+// Snapshot is the state of a simulation at a given tick
+type Snapshot struct {
+	Tick   int      `json:"tick"`
+	Status string   `json:"status"`
+	Width  int      `json:"width"`
+	Height int      `json:"height"`
+	Cities []*City  `json:"cities"`
+	Aliens []*Alien `json:"aliens"`
+}
+
+// Status summarizes the simulation progress
+type Status struct {
+	Tick            int    `json:"tick"`
+	Status          string `json:"status"`
+	MaxMoves        int    `json:"max_moves"`
+	AliveAliens     int    `json:"alive_aliens"`
+	RemainingCities int    `json:"remaining_cities"`
+	DestroyedCities int    `json:"destroyed_cities"`
+}
+
+// Metrics are the simulation progress counters exported to monitoring
+type Metrics struct {
+	Status
+	// alien moves and fights since the start
+	Moves  int
+	Fights int
+	// fights of the last tick
+	TickFights int
+	// alien moves per second during the last tick
+	MovesPerSecond float64
+}
+
+// Diff lists the changes of the world between two ticks
+type Diff struct {
+	From int `json:"from"`
+	To   int `json:"to"`
+	// DestroyedCities were on the world at From and not at To
+	DestroyedCities []*City `json:"destroyed_cities"`
+	// MovedAliens are at a different city at To
+	MovedAliens []*AlienMove `json:"moved_aliens"`
+	// KilledAliens were alive at From and not at To, SpawnedAliens the reverse
+	KilledAliens  []*Alien `json:"killed_aliens"`
+	SpawnedAliens []*Alien `json:"spawned_aliens"`
+}
+
+// AlienMove is the change of city of an alien between two ticks
+type AlienMove struct {
+	AlienID int    `json:"alien_id"`
+	Name    string `json:"name"`
+	From    int    `json:"from"`
+	To      int    `json:"to"`
+	// Moves is the number of moves made between the two ticks
+	Moves int `json:"moves"`
+}
@@ -0,0 +1,78 @@
+package model
+
+// CityStats holds metrics accumulated for a city during one or more runs
+type CityStats struct {
+	CityID int `json:"city_id"`
+	Visits int `json:"visits"`
+	// Fights counts the aliens that fought at the city, each fight destroys it
+	Fights       int `json:"fights"`
+	Destructions int `json:"destructions"`
+	// DestroyedTicks is the sum of the ticks at which the city was destroyed
+	DestroyedTicks int `json:"destroyed_ticks"`
+}
+
+// TimeToDestruction returns the average tick at which the city was destroyed, false if it was
+// never destroyed
+func (cs *CityStats) TimeToDestruction() (float64, bool) {
+	if cs.Destructions == 0 {
+		return 0, false
+	}
+	return float64(cs.DestroyedTicks) / float64(cs.Destructions), true
+}
+
+// Stats accumulates per city metrics for a run or a batch of runs
+type Stats struct {
+	Runs   int                `json:"runs"`
+	Cities map[int]*CityStats `json:"cities"`
+}
+
+func NewStats() *Stats {
+	return &Stats{
+		Runs:   1,
+		Cities: make(map[int]*CityStats),
+	}
+}
+
+// City returns the metrics of a city, creating them if needed
+func (s *Stats) City(cityID int) *CityStats {
+	cs, ok := s.Cities[cityID]
+	if !ok {
+		cs = &CityStats{CityID: cityID}
+		s.Cities[cityID] = cs
+	}
+	return cs
+}
+
+// Visit records an alien arriving at a city
+func (s *Stats) Visit(cityID int) {
+	s.City(cityID).Visits++
+}
+
+// Fight records a fight of a number of aliens at a city, that destroys it at passed tick
+func (s *Stats) Fight(cityID, tick, aliens int) {
+	cs := s.City(cityID)
+	cs.Fights += aliens
+	cs.Destructions++
+	cs.DestroyedTicks += tick
+}
+
+// DestructionProbability returns the empirical probability of a city being destroyed in a run
+func (s *Stats) DestructionProbability(cityID int) float64 {
+	cs, ok := s.Cities[cityID]
+	if !ok || s.Runs == 0 {
+		return 0
+	}
+	return float64(cs.Destructions) / float64(s.Runs)
+}
+
+// Merge adds metrics from other runs (e.g. from a batch), cities are matched by ID
+func (s *Stats) Merge(other *Stats) {
+	s.Runs += other.Runs
+	for cityID, ocs := range other.Cities {
+		cs := s.City(cityID)
+		cs.Visits += ocs.Visits
+		cs.Fights += ocs.Fights
+		cs.Destructions += ocs.Destructions
+		cs.DestroyedTicks += ocs.DestroyedTicks
+	}
+}
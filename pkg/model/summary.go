@@ -0,0 +1,43 @@
+package model
+
+// Summary reports how a run ended and its survivors
+type Summary struct {
+	Tick   int    `json:"tick"`
+	Reason string `json:"reason"`
+	// Destroyed lists the cities destroyed by fights, in order
+	Destroyed []*Destruction `json:"destroyed"`
+	// surviving cities with their remaining roads and alive aliens with their location
+	Cities []*City  `json:"cities"`
+	Aliens []*Alien `json:"aliens"`
+	// Trapped are the IDs of alive aliens on cities without roads
+	Trapped       []int         `json:"trapped"`
+	Fragmentation Fragmentation `json:"fragmentation"`
+}
+
+// Destruction is a city destroyed by a fight between its attackers
+type Destruction struct {
+	Tick      int      `json:"tick"`
+	CityID    int      `json:"city_id"`
+	CityName  string   `json:"city_name"`
+	Attackers []*Alien `json:"attackers"`
+}
+
+// Fragmentation describes the groups of cities still connected by roads
+type Fragmentation struct {
+	// Components is the number of groups of connected cities
+	Components int `json:"components"`
+	// Sizes is the number of cities of each group, largest first
+	Sizes []int `json:"sizes"`
+	// Isolated is the number of cities without roads
+	Isolated int `json:"isolated"`
+}
+
+// CityName returns the name of a surviving city by ID
+func (s *Summary) CityName(cityID int) string {
+	for _, city := range s.Cities {
+		if city.ID == cityID {
+			return city.Name
+		}
+	}
+	return ""
+}
@@ -0,0 +1,74 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/c-kuroki/alien_invasion/pkg/auth"
+	"github.com/c-kuroki/alien_invasion/pkg/ports/grpc/pb"
+)
+
+// methods requiring the operator role, the rest require the viewer role
+var operatorMethods = map[string]bool{
+	pb.AlienInvasion_CreateSimulation_FullMethodName: true,
+	pb.AlienInvasion_DeleteSimulation_FullMethodName: true,
+	pb.AlienInvasion_Control_FullMethodName:          true,
+}
+
+// SetAuth enables authentication, it must be called before Start
+func (srv *GRPCService) SetAuth(authenticator *auth.Authenticator) {
+	srv.auth = authenticator
+}
+
+func (srv *GRPCService) unaryAuth(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if err := srv.authorize(ctx, info.FullMethod); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (srv *GRPCService) streamAuth(s interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if err := srv.authorize(stream.Context(), info.FullMethod); err != nil {
+		return err
+	}
+	return handler(s, stream)
+}
+
+// authorize checks the API key or token passed as "authorization: Bearer <credential>" or
+// "x-api-key" metadata grants the role required by a method
+func (srv *GRPCService) authorize(ctx context.Context, method string) error {
+	identity, err := srv.auth.Authenticate(credential(ctx))
+	if err == nil {
+		required := auth.RoleViewer
+		if operatorMethods[method] {
+			required = auth.RoleOperator
+		}
+		err = auth.Authorize(identity, required)
+	}
+	switch {
+	case errors.Is(err, auth.ErrForbidden):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case err != nil:
+		return status.Error(codes.Unauthenticated, err.Error())
+	}
+	return nil
+}
+
+func credential(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, value := range md.Get("authorization") {
+		if strings.HasPrefix(value, "Bearer ") {
+			return strings.TrimSpace(strings.TrimPrefix(value, "Bearer "))
+		}
+	}
+	if keys := md.Get("x-api-key"); len(keys) > 0 {
+		return keys[0]
+	}
+	return ""
+}
@@ -0,0 +1,81 @@
+package grpc
+
+import (
+	"github.com/c-kuroki/alien_invasion/pkg/model"
+	"github.com/c-kuroki/alien_invasion/pkg/ports/grpc/pb"
+)
+
+// conversions from domain models to protobuf messages
+
+func toCity(city *model.City) *pb.City {
+	return &pb.City{
+		Id:        int32(city.ID),
+		Name:      city.Name,
+		X:         int32(city.X),
+		Y:         int32(city.Y),
+		North:     city.North,
+		East:      city.East,
+		South:     city.South,
+		West:      city.West,
+		Destroyed: city.Destroyed,
+	}
+}
+
+func toAlien(alien *model.Alien) *pb.Alien {
+	return &pb.Alien{
+		Id:     int32(alien.ID),
+		Name:   alien.Name,
+		City:   int32(alien.City),
+		Origin: int32(alien.Origin),
+		Moves:  int32(alien.Moves),
+	}
+}
+
+func toStatusMessage(status *model.Status) *pb.Status {
+	return &pb.Status{
+		Tick:            int32(status.Tick),
+		Status:          status.Status,
+		MaxMoves:        int32(status.MaxMoves),
+		AliveAliens:     int32(status.AliveAliens),
+		RemainingCities: int32(status.RemainingCities),
+		DestroyedCities: int32(status.DestroyedCities),
+	}
+}
+
+func toSimulation(info *model.SessionInfo) *pb.Simulation {
+	simulation := &pb.Simulation{
+		Id:           info.ID,
+		CreatedAt:    info.CreatedAt.Unix(),
+		Example:      info.Example,
+		Aliens:       int32(info.NumAliens),
+		Seed:         info.Seed,
+		TickInterval: int32(info.TickInterval),
+		MaxMoves:     int32(info.MaxMoves),
+	}
+	if info.ExpiresAt != nil {
+		simulation.ExpiresAt = info.ExpiresAt.Unix()
+	}
+	if info.Status != nil {
+		simulation.Status = toStatusMessage(info.Status)
+	}
+	return simulation
+}
+
+func toEvent(event model.Event) *pb.Event {
+	msg := &pb.Event{
+		Tick:            int32(event.Tick),
+		Type:            event.Type,
+		AlienId:         int32(event.AlienID),
+		From:            int32(event.From),
+		To:              int32(event.To),
+		CityId:          int32(event.CityID),
+		CityName:        event.CityName,
+		AliveAliens:     int32(event.AliveAliens),
+		RemainingCities: int32(event.RemainingCities),
+		Reason:          event.Reason,
+	}
+	for _, id := range event.Aliens {
+		msg.Aliens = append(msg.Aliens, int32(id))
+	}
+	return msg
+}
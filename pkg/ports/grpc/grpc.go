@@ -0,0 +1,361 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/c-kuroki/alien_invasion/pkg/app"
+	"github.com/c-kuroki/alien_invasion/pkg/auth"
+	"github.com/c-kuroki/alien_invasion/pkg/model"
+	"github.com/c-kuroki/alien_invasion/pkg/ports/grpc/pb"
+)
+
+//go:generate protoc --go_out=paths=source_relative:. --go-grpc_out=paths=source_relative:. pb/alien_invasion.proto
+
+const (
+	defaultLimit = 100
+	maxLimit     = 1000
+)
+
+type GRPCService struct {
+	pb.UnimplementedAlienInvasionServer
+	invasion       *app.AlienInvasionApp
+	sessions       *app.SessionManager
+	serviceAddress string
+	// authentication, disabled if nil
+	auth   *auth.Authenticator
+	mu     sync.Mutex
+	server *grpc.Server
+	// closing is closed on Shutdown to end event streams
+	closing   chan struct{}
+	closeOnce sync.Once
+}
+
+var _ pb.AlienInvasionServer = (*GRPCService)(nil)
+
+// NewGRPCService returns a service for a single simulation, invasion can be nil when
+// the service only hosts sessions (see SetSessions)
+func NewGRPCService(invasion *app.AlienInvasionApp, serviceAddress string) *GRPCService {
+	return &GRPCService{
+		invasion:       invasion,
+		serviceAddress: serviceAddress,
+		closing:        make(chan struct{}),
+	}
+}
+
+// SetSessions enables the multi-simulation RPCs
+func (srv *GRPCService) SetSessions(sessions *app.SessionManager) {
+	srv.sessions = sessions
+}
+
+// Start listens on the service address and serves RPCs until Shutdown is called
+func (srv *GRPCService) Start() error {
+	lis, err := net.Listen("tcp", srv.serviceAddress)
+	if err != nil {
+		return err
+	}
+	log.Printf("Starting grpc server at %s\n", srv.serviceAddress)
+	return srv.Serve(lis)
+}
+
+// Serve serves RPCs on a listener until Shutdown is called, it returns nil after a Shutdown
+func (srv *GRPCService) Serve(lis net.Listener) error {
+	var opts []grpc.ServerOption
+	if srv.auth != nil {
+		opts = append(opts, grpc.UnaryInterceptor(srv.unaryAuth), grpc.StreamInterceptor(srv.streamAuth))
+	}
+	server := grpc.NewServer(opts...)
+	pb.RegisterAlienInvasionServer(server, srv)
+	srv.mu.Lock()
+	if srv.server != nil {
+		srv.mu.Unlock()
+		return errors.New("grpc server already started")
+	}
+	srv.server = server
+	srv.mu.Unlock()
+	err := server.Serve(lis)
+	if errors.Is(err, grpc.ErrServerStopped) {
+		return nil
+	}
+	return err
+}
+
+// Shutdown ends event streams and waits for active RPCs until ctx is done
+func (srv *GRPCService) Shutdown(ctx context.Context) error {
+	srv.closeOnce.Do(func() { close(srv.closing) })
+	srv.mu.Lock()
+	server := srv.server
+	srv.mu.Unlock()
+	if server == nil {
+		return nil
+	}
+	stopped := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		server.Stop()
+		return ctx.Err()
+	}
+}
+
+// simulation resolves the simulation of a request, the single simulation if id is empty
+func (srv *GRPCService) simulation(id string) (*app.AlienInvasionApp, error) {
+	if id == "" {
+		if srv.invasion == nil {
+			return nil, status.Error(codes.InvalidArgument, "simulation_id is required")
+		}
+		return srv.invasion, nil
+	}
+	if srv.sessions == nil {
+		return nil, status.Error(codes.NotFound, "simulation not found")
+	}
+	session, err := srv.sessions.Get(id)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return session.App, nil
+}
+
+func (srv *GRPCService) CreateSimulation(ctx context.Context, req *pb.CreateSimulationRequest) (*pb.Simulation, error) {
+	if srv.sessions == nil {
+		return nil, status.Error(codes.Unimplemented, "server mode is disabled")
+	}
+	session, err := srv.sessions.Create(model.SimulationRequest{
+		Map:          req.Map,
+		Example:      req.Example,
+		NumAliens:    int(req.Aliens),
+		Seed:         req.Seed,
+		TickInterval: int(req.TickInterval),
+		MaxMoves:     int(req.MaxMoves),
+		Paused:       req.Paused,
+		History:      int(req.History),
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toSimulation(session.Info()), nil
+}
+
+func (srv *GRPCService) ListSimulations(ctx context.Context, req *pb.ListSimulationsRequest) (*pb.ListSimulationsResponse, error) {
+	resp := &pb.ListSimulationsResponse{}
+	if srv.sessions == nil {
+		return resp, nil
+	}
+	for _, session := range srv.sessions.List() {
+		resp.Simulations = append(resp.Simulations, toSimulation(session.Info()))
+	}
+	return resp, nil
+}
+
+func (srv *GRPCService) GetSimulation(ctx context.Context, req *pb.GetSimulationRequest) (*pb.Simulation, error) {
+	if srv.sessions == nil {
+		return nil, status.Error(codes.NotFound, "simulation not found")
+	}
+	session, err := srv.sessions.Get(req.SimulationId)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toSimulation(session.Info()), nil
+}
+
+func (srv *GRPCService) DeleteSimulation(ctx context.Context, req *pb.DeleteSimulationRequest) (*pb.DeleteSimulationResponse, error) {
+	if srv.sessions == nil {
+		return nil, status.Error(codes.NotFound, "simulation not found")
+	}
+	if err := srv.sessions.Delete(req.SimulationId); err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.DeleteSimulationResponse{}, nil
+}
+
+func (srv *GRPCService) GetStatus(ctx context.Context, req *pb.GetStatusRequest) (*pb.Status, error) {
+	invasion, err := srv.simulation(req.SimulationId)
+	if err != nil {
+		return nil, err
+	}
+	return toStatusMessage(invasion.Status()), nil
+}
+
+// ListCities returns cities (including destroyed ones) sorted by ID
+func (srv *GRPCService) ListCities(ctx context.Context, req *pb.ListCitiesRequest) (*pb.ListCitiesResponse, error) {
+	invasion, err := srv.simulation(req.SimulationId)
+	if err != nil {
+		return nil, err
+	}
+	offset, limit, err := page(req.Offset, req.Limit)
+	if err != nil {
+		return nil, err
+	}
+	cities, total := invasion.Cities(model.CityFilter{
+		Name:      req.Name,
+		Destroyed: req.Destroyed,
+		HasAliens: req.HasAliens,
+	}, offset, limit)
+	resp := &pb.ListCitiesResponse{Total: int32(total)}
+	for _, city := range cities {
+		resp.Cities = append(resp.Cities, toCity(city))
+	}
+	return resp, nil
+}
+
+// GetCity returns a city by ID or name
+func (srv *GRPCService) GetCity(ctx context.Context, req *pb.GetCityRequest) (*pb.City, error) {
+	invasion, err := srv.simulation(req.SimulationId)
+	if err != nil {
+		return nil, err
+	}
+	var city *model.City
+	switch c := req.City.(type) {
+	case *pb.GetCityRequest_Id:
+		city, err = invasion.City(int(c.Id))
+	case *pb.GetCityRequest_Name:
+		city, err = invasion.CityByName(c.Name)
+	default:
+		return nil, status.Error(codes.InvalidArgument, "city id or name is required")
+	}
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toCity(city), nil
+}
+
+// ListAliens returns alive aliens sorted by ID
+func (srv *GRPCService) ListAliens(ctx context.Context, req *pb.ListAliensRequest) (*pb.ListAliensResponse, error) {
+	invasion, err := srv.simulation(req.SimulationId)
+	if err != nil {
+		return nil, err
+	}
+	filter := model.AlienFilter{CityID: -1, Name: req.Name}
+	if req.City != nil {
+		filter.CityID = int(*req.City)
+	}
+	offset, limit, err := page(req.Offset, req.Limit)
+	if err != nil {
+		return nil, err
+	}
+	aliens, total := invasion.Aliens(filter, offset, limit)
+	resp := &pb.ListAliensResponse{Total: int32(total)}
+	for _, alien := range aliens {
+		resp.Aliens = append(resp.Aliens, toAlien(alien))
+	}
+	return resp, nil
+}
+
+func (srv *GRPCService) GetAlien(ctx context.Context, req *pb.GetAlienRequest) (*pb.Alien, error) {
+	invasion, err := srv.simulation(req.SimulationId)
+	if err != nil {
+		return nil, err
+	}
+	alien, err := invasion.Alien(int(req.Id))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toAlien(alien), nil
+}
+
+func (srv *GRPCService) Control(ctx context.Context, req *pb.ControlRequest) (*pb.ControlResponse, error) {
+	invasion, err := srv.simulation(req.SimulationId)
+	if err != nil {
+		return nil, err
+	}
+	switch req.Action {
+	case pb.ControlRequest_ACTION_PAUSE:
+		err = invasion.Pause()
+	case pb.ControlRequest_ACTION_RESUME:
+		err = invasion.Resume()
+	case pb.ControlRequest_ACTION_STEP:
+		err = invasion.Step()
+	default:
+		return nil, status.Error(codes.InvalidArgument, "invalid action")
+	}
+	if err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	snapshot := invasion.Snapshot()
+	return &pb.ControlResponse{Tick: int32(snapshot.Tick), Status: snapshot.Status}, nil
+}
+
+// StreamEvents sends the simulation events from a tick (or from the next tick) until the end event
+func (srv *GRPCService) StreamEvents(req *pb.StreamEventsRequest, stream pb.AlienInvasion_StreamEventsServer) error {
+	invasion, err := srv.simulation(req.SimulationId)
+	if err != nil {
+		return err
+	}
+	from := invasion.Tick() + 1
+	if req.FromTick != nil {
+		from = int(*req.FromTick)
+	}
+	backlog, events, cancel, err := invasion.Subscribe(from)
+	if errors.Is(err, app.ErrEventsExpired) {
+		return status.Error(codes.OutOfRange, err.Error())
+	}
+	if err != nil {
+		return err
+	}
+	defer cancel()
+	for _, event := range backlog {
+		if err := stream.Send(toEvent(event)); err != nil {
+			return err
+		}
+		if event.Type == model.EventEnd {
+			return nil
+		}
+	}
+	for {
+		select {
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		case <-srv.closing:
+			return status.Error(codes.Unavailable, "server shutting down")
+		case event, ok := <-events:
+			if !ok {
+				return status.Error(codes.ResourceExhausted, "subscriber too slow, resume from last tick")
+			}
+			if err := stream.Send(toEvent(event)); err != nil {
+				return err
+			}
+			if event.Type == model.EventEnd {
+				return nil
+			}
+		}
+	}
+}
+
+// page validates the offset and limit of a list request, a zero limit is the default one
+func page(offset, limit int32) (int, int, error) {
+	if offset < 0 {
+		return 0, 0, status.Error(codes.InvalidArgument, "invalid offset")
+	}
+	if limit == 0 {
+		limit = defaultLimit
+	}
+	if limit < 1 || limit > maxLimit {
+		return 0, 0, status.Error(codes.InvalidArgument, "invalid limit (should be between 1 and 1000)")
+	}
+	return int(offset), int(limit), nil
+}
+
+// toStatus maps app errors to gRPC status errors
+func toStatus(err error) error {
+	switch {
+	case errors.Is(err, app.ErrNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, app.ErrInvalidSimulation):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, app.ErrTooManySessions):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	}
+	return status.Error(codes.Internal, err.Error())
+}
@@ -0,0 +1,182 @@
+package grpc
+
+import (
+	"context"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/c-kuroki/alien_invasion/pkg/adapters/maps"
+	"github.com/c-kuroki/alien_invasion/pkg/adapters/renderer"
+	"github.com/c-kuroki/alien_invasion/pkg/adapters/world"
+	"github.com/c-kuroki/alien_invasion/pkg/app"
+	"github.com/c-kuroki/alien_invasion/pkg/model"
+	"github.com/c-kuroki/alien_invasion/pkg/ports/grpc/pb"
+)
+
+const exampleMapFile = "../../../examples/world.map"
+
+type GRPCTestSuite struct {
+	suite.Suite
+	invasion *app.AlienInvasionApp
+	srv      *GRPCService
+	sessions *app.SessionManager
+	client   pb.AlienInvasionClient
+	ctx      context.Context
+}
+
+func (suite *GRPCTestSuite) SetupTest() {
+	suite.ctx = context.Background()
+	data, err := os.ReadFile(exampleMapFile)
+	suite.Require().NoError(err)
+	dir := suite.T().TempDir()
+	suite.Require().NoError(os.WriteFile(filepath.Join(dir, "world.map"), data, 0o644))
+
+	rnd := renderer.NewSVGRenderer()
+	cfg := &model.Config{TickInterval: 10, MaxMoves: 5, NumAliens: 2, Seed: 1, NoFinalMap: true}
+	suite.invasion = app.NewAlienInvasionApp(cfg, world.NewInMemoryState(exampleMapFile), rnd, zap.NewNop().Sugar())
+	suite.Require().NoError(suite.invasion.Pause())
+	suite.Require().NoError(suite.invasion.Init())
+	go suite.invasion.MainLoop()
+	suite.T().Cleanup(suite.invasion.Stop)
+
+	suite.sessions = app.NewSessionManager(app.DefaultSessionsConfig(), maps.NewFileStore(dir), rnd, zap.NewNop().Sugar())
+	suite.T().Cleanup(suite.sessions.Close)
+	suite.srv = NewGRPCService(suite.invasion, "")
+	suite.srv.SetSessions(suite.sessions)
+
+	lis := bufconn.Listen(1 << 20)
+	go func() { _ = suite.srv.Serve(lis) }()
+	suite.T().Cleanup(func() { _ = suite.srv.Shutdown(context.Background()) })
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	suite.Require().NoError(err)
+	suite.T().Cleanup(func() { _ = conn.Close() })
+	suite.client = pb.NewAlienInvasionClient(conn)
+}
+
+func (suite *GRPCTestSuite) TestQueries() {
+	st, err := suite.client.GetStatus(suite.ctx, &pb.GetStatusRequest{})
+	suite.Require().NoError(err)
+	suite.Assert().Equal(int32(2), st.AliveAliens)
+	suite.Assert().Equal(model.StatusPaused, st.Status)
+
+	cities, err := suite.client.ListCities(suite.ctx, &pb.ListCitiesRequest{Limit: 2})
+	suite.Require().NoError(err)
+	suite.Assert().Len(cities.Cities, 2)
+	suite.Assert().Equal(st.RemainingCities, cities.Total)
+
+	city, err := suite.client.GetCity(suite.ctx, &pb.GetCityRequest{City: &pb.GetCityRequest_Name{Name: cities.Cities[1].Name}})
+	suite.Require().NoError(err)
+	suite.Assert().Equal(cities.Cities[1].Id, city.Id)
+
+	aliens, err := suite.client.ListAliens(suite.ctx, &pb.ListAliensRequest{})
+	suite.Require().NoError(err)
+	suite.Require().Len(aliens.Aliens, 2)
+	inCity, err := suite.client.ListAliens(suite.ctx, &pb.ListAliensRequest{City: proto.Int32(aliens.Aliens[0].City)})
+	suite.Require().NoError(err)
+	suite.Assert().NotEmpty(inCity.Aliens)
+
+	alien, err := suite.client.GetAlien(suite.ctx, &pb.GetAlienRequest{Id: aliens.Aliens[0].Id})
+	suite.Require().NoError(err)
+	suite.Assert().Equal(aliens.Aliens[0].Name, alien.Name)
+
+	_, err = suite.client.GetAlien(suite.ctx, &pb.GetAlienRequest{Id: 1000})
+	suite.Assert().Equal(codes.NotFound, status.Code(err))
+	_, err = suite.client.ListCities(suite.ctx, &pb.ListCitiesRequest{Limit: 5000})
+	suite.Assert().Equal(codes.InvalidArgument, status.Code(err))
+}
+
+func (suite *GRPCTestSuite) TestControlAndStream() {
+	ctx, cancel := context.WithTimeout(suite.ctx, 5*time.Second)
+	defer cancel()
+	stream, err := suite.client.StreamEvents(ctx, &pb.StreamEventsRequest{FromTick: proto.Int32(1)})
+	suite.Require().NoError(err)
+
+	resp, err := suite.client.Control(ctx, &pb.ControlRequest{Action: pb.ControlRequest_ACTION_STEP})
+	suite.Require().NoError(err)
+	suite.Assert().Equal(model.StatusPaused, resp.Status)
+
+	// the step executes tick 1
+	for {
+		event, err := stream.Recv()
+		suite.Require().NoError(err)
+		suite.Assert().Equal(int32(1), event.Tick)
+		if event.Type == model.EventTick {
+			break
+		}
+	}
+
+	// resume runs until max moves, the stream ends after the end event
+	_, err = suite.client.Control(ctx, &pb.ControlRequest{Action: pb.ControlRequest_ACTION_RESUME})
+	suite.Require().NoError(err)
+	var last *pb.Event
+	for {
+		event, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		suite.Require().NoError(err)
+		last = event
+	}
+	suite.Require().NotNil(last)
+	suite.Assert().Equal(model.EventEnd, last.Type)
+
+	_, err = suite.client.Control(ctx, &pb.ControlRequest{Action: pb.ControlRequest_ACTION_PAUSE})
+	suite.Assert().Equal(codes.FailedPrecondition, status.Code(err))
+
+	// streams of the finished simulation after its last tick only get the end event
+	stream, err = suite.client.StreamEvents(ctx, &pb.StreamEventsRequest{FromTick: proto.Int32(int32(suite.invasion.Tick() + 1))})
+	suite.Require().NoError(err)
+	event, err := stream.Recv()
+	suite.Require().NoError(err)
+	suite.Assert().Equal(model.EventEnd, event.Type)
+	_, err = stream.Recv()
+	suite.Assert().Equal(io.EOF, err)
+}
+
+func (suite *GRPCTestSuite) TestSessions() {
+	simulation, err := suite.client.CreateSimulation(suite.ctx, &pb.CreateSimulationRequest{
+		Example: "world", Aliens: 3, Seed: 7, TickInterval: 1000, MaxMoves: 10, Paused: true, History: 2,
+	})
+	suite.Require().NoError(err)
+	suite.Require().NotEmpty(simulation.Id)
+	session, err := suite.sessions.Get(simulation.Id)
+	suite.Require().NoError(err)
+	suite.Assert().Equal(2, session.Request.History)
+
+	list, err := suite.client.ListSimulations(suite.ctx, &pb.ListSimulationsRequest{})
+	suite.Require().NoError(err)
+	suite.Assert().Len(list.Simulations, 1)
+
+	aliens, err := suite.client.ListAliens(suite.ctx, &pb.ListAliensRequest{SimulationId: simulation.Id})
+	suite.Require().NoError(err)
+	suite.Assert().Equal(int32(3), aliens.Total)
+
+	_, err = suite.client.DeleteSimulation(suite.ctx, &pb.DeleteSimulationRequest{SimulationId: simulation.Id})
+	suite.Require().NoError(err)
+	_, err = suite.client.GetStatus(suite.ctx, &pb.GetStatusRequest{SimulationId: simulation.Id})
+	suite.Assert().Equal(codes.NotFound, status.Code(err))
+
+	_, err = suite.client.CreateSimulation(suite.ctx, &pb.CreateSimulationRequest{Aliens: 3})
+	suite.Assert().Equal(codes.InvalidArgument, status.Code(err))
+}
+
+// TestGRPC is the entry point of this test suite
+func TestGRPC(t *testing.T) {
+	suite.Run(t, new(GRPCTestSuite))
+}
@@ -0,0 +1,513 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: pb/alien_invasion.proto
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	AlienInvasion_CreateSimulation_FullMethodName = "/alieninvasion.v1.AlienInvasion/CreateSimulation"
+	AlienInvasion_ListSimulations_FullMethodName  = "/alieninvasion.v1.AlienInvasion/ListSimulations"
+	AlienInvasion_GetSimulation_FullMethodName    = "/alieninvasion.v1.AlienInvasion/GetSimulation"
+	AlienInvasion_DeleteSimulation_FullMethodName = "/alieninvasion.v1.AlienInvasion/DeleteSimulation"
+	AlienInvasion_GetStatus_FullMethodName        = "/alieninvasion.v1.AlienInvasion/GetStatus"
+	AlienInvasion_ListCities_FullMethodName       = "/alieninvasion.v1.AlienInvasion/ListCities"
+	AlienInvasion_GetCity_FullMethodName          = "/alieninvasion.v1.AlienInvasion/GetCity"
+	AlienInvasion_ListAliens_FullMethodName       = "/alieninvasion.v1.AlienInvasion/ListAliens"
+	AlienInvasion_GetAlien_FullMethodName         = "/alieninvasion.v1.AlienInvasion/GetAlien"
+	AlienInvasion_Control_FullMethodName          = "/alieninvasion.v1.AlienInvasion/Control"
+	AlienInvasion_StreamEvents_FullMethodName     = "/alieninvasion.v1.AlienInvasion/StreamEvents"
+)
+
+// AlienInvasionClient is the client API for AlienInvasion service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type AlienInvasionClient interface {
+	// CreateSimulation starts a new simulation (server mode)
+	CreateSimulation(ctx context.Context, in *CreateSimulationRequest, opts ...grpc.CallOption) (*Simulation, error)
+	ListSimulations(ctx context.Context, in *ListSimulationsRequest, opts ...grpc.CallOption) (*ListSimulationsResponse, error)
+	GetSimulation(ctx context.Context, in *GetSimulationRequest, opts ...grpc.CallOption) (*Simulation, error)
+	DeleteSimulation(ctx context.Context, in *DeleteSimulationRequest, opts ...grpc.CallOption) (*DeleteSimulationResponse, error)
+	GetStatus(ctx context.Context, in *GetStatusRequest, opts ...grpc.CallOption) (*Status, error)
+	ListCities(ctx context.Context, in *ListCitiesRequest, opts ...grpc.CallOption) (*ListCitiesResponse, error)
+	GetCity(ctx context.Context, in *GetCityRequest, opts ...grpc.CallOption) (*City, error)
+	ListAliens(ctx context.Context, in *ListAliensRequest, opts ...grpc.CallOption) (*ListAliensResponse, error)
+	GetAlien(ctx context.Context, in *GetAlienRequest, opts ...grpc.CallOption) (*Alien, error)
+	// Control pauses, resumes or steps the simulation playback
+	Control(ctx context.Context, in *ControlRequest, opts ...grpc.CallOption) (*ControlResponse, error)
+	// StreamEvents streams the simulation events from a tick until the end event
+	StreamEvents(ctx context.Context, in *StreamEventsRequest, opts ...grpc.CallOption) (AlienInvasion_StreamEventsClient, error)
+}
+
+type alienInvasionClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAlienInvasionClient(cc grpc.ClientConnInterface) AlienInvasionClient {
+	return &alienInvasionClient{cc}
+}
+
+func (c *alienInvasionClient) CreateSimulation(ctx context.Context, in *CreateSimulationRequest, opts ...grpc.CallOption) (*Simulation, error) {
+	out := new(Simulation)
+	err := c.cc.Invoke(ctx, AlienInvasion_CreateSimulation_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *alienInvasionClient) ListSimulations(ctx context.Context, in *ListSimulationsRequest, opts ...grpc.CallOption) (*ListSimulationsResponse, error) {
+	out := new(ListSimulationsResponse)
+	err := c.cc.Invoke(ctx, AlienInvasion_ListSimulations_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *alienInvasionClient) GetSimulation(ctx context.Context, in *GetSimulationRequest, opts ...grpc.CallOption) (*Simulation, error) {
+	out := new(Simulation)
+	err := c.cc.Invoke(ctx, AlienInvasion_GetSimulation_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *alienInvasionClient) DeleteSimulation(ctx context.Context, in *DeleteSimulationRequest, opts ...grpc.CallOption) (*DeleteSimulationResponse, error) {
+	out := new(DeleteSimulationResponse)
+	err := c.cc.Invoke(ctx, AlienInvasion_DeleteSimulation_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *alienInvasionClient) GetStatus(ctx context.Context, in *GetStatusRequest, opts ...grpc.CallOption) (*Status, error) {
+	out := new(Status)
+	err := c.cc.Invoke(ctx, AlienInvasion_GetStatus_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *alienInvasionClient) ListCities(ctx context.Context, in *ListCitiesRequest, opts ...grpc.CallOption) (*ListCitiesResponse, error) {
+	out := new(ListCitiesResponse)
+	err := c.cc.Invoke(ctx, AlienInvasion_ListCities_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *alienInvasionClient) GetCity(ctx context.Context, in *GetCityRequest, opts ...grpc.CallOption) (*City, error) {
+	out := new(City)
+	err := c.cc.Invoke(ctx, AlienInvasion_GetCity_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *alienInvasionClient) ListAliens(ctx context.Context, in *ListAliensRequest, opts ...grpc.CallOption) (*ListAliensResponse, error) {
+	out := new(ListAliensResponse)
+	err := c.cc.Invoke(ctx, AlienInvasion_ListAliens_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *alienInvasionClient) GetAlien(ctx context.Context, in *GetAlienRequest, opts ...grpc.CallOption) (*Alien, error) {
+	out := new(Alien)
+	err := c.cc.Invoke(ctx, AlienInvasion_GetAlien_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *alienInvasionClient) Control(ctx context.Context, in *ControlRequest, opts ...grpc.CallOption) (*ControlResponse, error) {
+	out := new(ControlResponse)
+	err := c.cc.Invoke(ctx, AlienInvasion_Control_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *alienInvasionClient) StreamEvents(ctx context.Context, in *StreamEventsRequest, opts ...grpc.CallOption) (AlienInvasion_StreamEventsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &AlienInvasion_ServiceDesc.Streams[0], AlienInvasion_StreamEvents_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &alienInvasionStreamEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type AlienInvasion_StreamEventsClient interface {
+	Recv() (*Event, error)
+	grpc.ClientStream
+}
+
+type alienInvasionStreamEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *alienInvasionStreamEventsClient) Recv() (*Event, error) {
+	m := new(Event)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// AlienInvasionServer is the server API for AlienInvasion service.
+// All implementations must embed UnimplementedAlienInvasionServer
+// for forward compatibility
+type AlienInvasionServer interface {
+	// CreateSimulation starts a new simulation (server mode)
+	CreateSimulation(context.Context, *CreateSimulationRequest) (*Simulation, error)
+	ListSimulations(context.Context, *ListSimulationsRequest) (*ListSimulationsResponse, error)
+	GetSimulation(context.Context, *GetSimulationRequest) (*Simulation, error)
+	DeleteSimulation(context.Context, *DeleteSimulationRequest) (*DeleteSimulationResponse, error)
+	GetStatus(context.Context, *GetStatusRequest) (*Status, error)
+	ListCities(context.Context, *ListCitiesRequest) (*ListCitiesResponse, error)
+	GetCity(context.Context, *GetCityRequest) (*City, error)
+	ListAliens(context.Context, *ListAliensRequest) (*ListAliensResponse, error)
+	GetAlien(context.Context, *GetAlienRequest) (*Alien, error)
+	// Control pauses, resumes or steps the simulation playback
+	Control(context.Context, *ControlRequest) (*ControlResponse, error)
+	// StreamEvents streams the simulation events from a tick until the end event
+	StreamEvents(*StreamEventsRequest, AlienInvasion_StreamEventsServer) error
+	mustEmbedUnimplementedAlienInvasionServer()
+}
+
+// UnimplementedAlienInvasionServer must be embedded to have forward compatible implementations.
+type UnimplementedAlienInvasionServer struct {
+}
+
+func (UnimplementedAlienInvasionServer) CreateSimulation(context.Context, *CreateSimulationRequest) (*Simulation, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateSimulation not implemented")
+}
+func (UnimplementedAlienInvasionServer) ListSimulations(context.Context, *ListSimulationsRequest) (*ListSimulationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSimulations not implemented")
+}
+func (UnimplementedAlienInvasionServer) GetSimulation(context.Context, *GetSimulationRequest) (*Simulation, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSimulation not implemented")
+}
+func (UnimplementedAlienInvasionServer) DeleteSimulation(context.Context, *DeleteSimulationRequest) (*DeleteSimulationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteSimulation not implemented")
+}
+func (UnimplementedAlienInvasionServer) GetStatus(context.Context, *GetStatusRequest) (*Status, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStatus not implemented")
+}
+func (UnimplementedAlienInvasionServer) ListCities(context.Context, *ListCitiesRequest) (*ListCitiesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCities not implemented")
+}
+func (UnimplementedAlienInvasionServer) GetCity(context.Context, *GetCityRequest) (*City, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCity not implemented")
+}
+func (UnimplementedAlienInvasionServer) ListAliens(context.Context, *ListAliensRequest) (*ListAliensResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAliens not implemented")
+}
+func (UnimplementedAlienInvasionServer) GetAlien(context.Context, *GetAlienRequest) (*Alien, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAlien not implemented")
+}
+func (UnimplementedAlienInvasionServer) Control(context.Context, *ControlRequest) (*ControlResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Control not implemented")
+}
+func (UnimplementedAlienInvasionServer) StreamEvents(*StreamEventsRequest, AlienInvasion_StreamEventsServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamEvents not implemented")
+}
+func (UnimplementedAlienInvasionServer) mustEmbedUnimplementedAlienInvasionServer() {}
+
+// UnsafeAlienInvasionServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AlienInvasionServer will
+// result in compilation errors.
+type UnsafeAlienInvasionServer interface {
+	mustEmbedUnimplementedAlienInvasionServer()
+}
+
+func RegisterAlienInvasionServer(s grpc.ServiceRegistrar, srv AlienInvasionServer) {
+	s.RegisterService(&AlienInvasion_ServiceDesc, srv)
+}
+
+func _AlienInvasion_CreateSimulation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateSimulationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AlienInvasionServer).CreateSimulation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AlienInvasion_CreateSimulation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AlienInvasionServer).CreateSimulation(ctx, req.(*CreateSimulationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AlienInvasion_ListSimulations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSimulationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AlienInvasionServer).ListSimulations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AlienInvasion_ListSimulations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AlienInvasionServer).ListSimulations(ctx, req.(*ListSimulationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AlienInvasion_GetSimulation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSimulationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AlienInvasionServer).GetSimulation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AlienInvasion_GetSimulation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AlienInvasionServer).GetSimulation(ctx, req.(*GetSimulationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AlienInvasion_DeleteSimulation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteSimulationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AlienInvasionServer).DeleteSimulation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AlienInvasion_DeleteSimulation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AlienInvasionServer).DeleteSimulation(ctx, req.(*DeleteSimulationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AlienInvasion_GetStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AlienInvasionServer).GetStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AlienInvasion_GetStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AlienInvasionServer).GetStatus(ctx, req.(*GetStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AlienInvasion_ListCities_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCitiesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AlienInvasionServer).ListCities(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AlienInvasion_ListCities_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AlienInvasionServer).ListCities(ctx, req.(*ListCitiesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AlienInvasion_GetCity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AlienInvasionServer).GetCity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AlienInvasion_GetCity_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AlienInvasionServer).GetCity(ctx, req.(*GetCityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AlienInvasion_ListAliens_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAliensRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AlienInvasionServer).ListAliens(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AlienInvasion_ListAliens_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AlienInvasionServer).ListAliens(ctx, req.(*ListAliensRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AlienInvasion_GetAlien_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAlienRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AlienInvasionServer).GetAlien(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AlienInvasion_GetAlien_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AlienInvasionServer).GetAlien(ctx, req.(*GetAlienRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AlienInvasion_Control_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ControlRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AlienInvasionServer).Control(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AlienInvasion_Control_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AlienInvasionServer).Control(ctx, req.(*ControlRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AlienInvasion_StreamEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AlienInvasionServer).StreamEvents(m, &alienInvasionStreamEventsServer{stream})
+}
+
+type AlienInvasion_StreamEventsServer interface {
+	Send(*Event) error
+	grpc.ServerStream
+}
+
+type alienInvasionStreamEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *alienInvasionStreamEventsServer) Send(m *Event) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// AlienInvasion_ServiceDesc is the grpc.ServiceDesc for AlienInvasion service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AlienInvasion_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "alieninvasion.v1.AlienInvasion",
+	HandlerType: (*AlienInvasionServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateSimulation",
+			Handler:    _AlienInvasion_CreateSimulation_Handler,
+		},
+		{
+			MethodName: "ListSimulations",
+			Handler:    _AlienInvasion_ListSimulations_Handler,
+		},
+		{
+			MethodName: "GetSimulation",
+			Handler:    _AlienInvasion_GetSimulation_Handler,
+		},
+		{
+			MethodName: "DeleteSimulation",
+			Handler:    _AlienInvasion_DeleteSimulation_Handler,
+		},
+		{
+			MethodName: "GetStatus",
+			Handler:    _AlienInvasion_GetStatus_Handler,
+		},
+		{
+			MethodName: "ListCities",
+			Handler:    _AlienInvasion_ListCities_Handler,
+		},
+		{
+			MethodName: "GetCity",
+			Handler:    _AlienInvasion_GetCity_Handler,
+		},
+		{
+			MethodName: "ListAliens",
+			Handler:    _AlienInvasion_ListAliens_Handler,
+		},
+		{
+			MethodName: "GetAlien",
+			Handler:    _AlienInvasion_GetAlien_Handler,
+		},
+		{
+			MethodName: "Control",
+			Handler:    _AlienInvasion_Control_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamEvents",
+			Handler:       _AlienInvasion_StreamEvents_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "pb/alien_invasion.proto",
+}
@@ -0,0 +1,208 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+
+	"github.com/c-kuroki/alien_invasion/pkg/app"
+	"github.com/c-kuroki/alien_invasion/pkg/model"
+)
+
+const (
+	defaultLimit = 100
+	maxLimit     = 1000
+)
+
+var (
+	errInvalidBy     = errors.New("invalid city lookup (by should be id or name)")
+	errInvalidCityID = errors.New("invalid city id")
+)
+
+// Page is a paginated list response
+type Page struct {
+	Items  interface{} `json:"items"`
+	Total  int         `json:"total"`
+	Offset int         `json:"offset"`
+	Limit  int         `json:"limit"`
+}
+
+// apiRoutes registers the versioned REST API of a simulation
+func (srv *HTTPService) apiRoutes(r chi.Router) {
+	r.Get("/status", srv.GetStatus)
+	r.Get("/cities", srv.ListCities)
+	r.Get("/cities/{city}", srv.GetCity)
+	r.Get("/cities/{city}/exits", srv.GetCityExits)
+	r.Get("/aliens", srv.ListAliens)
+	r.Get("/aliens/{id}", srv.GetAlien)
+}
+
+func (srv *HTTPService) GetStatus(w http.ResponseWriter, r *http.Request) {
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, invasionFrom(r).Status())
+}
+
+// ListCities returns cities (including destroyed ones) sorted by ID.
+// Filters: name (substring), destroyed (true/false), has_aliens (true/false)
+func (srv *HTTPService) ListCities(w http.ResponseWriter, r *http.Request) {
+	offset, limit, err := pagination(r)
+	if err != nil {
+		badRequest(w, r, err)
+		return
+	}
+	query := r.URL.Query()
+	destroyed, err := boolFilter(query.Get("destroyed"))
+	if err != nil {
+		badRequest(w, r, err)
+		return
+	}
+	hasAliens, err := boolFilter(query.Get("has_aliens"))
+	if err != nil {
+		badRequest(w, r, err)
+		return
+	}
+	cities, total := invasionFrom(r).Cities(model.CityFilter{
+		Name:      query.Get("name"),
+		Destroyed: destroyed,
+		HasAliens: hasAliens,
+	}, offset, limit)
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, &Page{Items: cities, Total: total, Offset: offset, Limit: limit})
+}
+
+// GetCity returns a city by ID or name, by=name or by=id skips the other lookup
+func (srv *HTTPService) GetCity(w http.ResponseWriter, r *http.Request) {
+	city, err := srv.cityParam(r)
+	if err != nil {
+		queryError(w, r, err)
+		return
+	}
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, city)
+}
+
+// GetCityExits returns the roads leaving a city
+func (srv *HTTPService) GetCityExits(w http.ResponseWriter, r *http.Request) {
+	city, err := srv.cityParam(r)
+	if err != nil {
+		queryError(w, r, err)
+		return
+	}
+	exits, err := invasionFrom(r).Exits(city.ID)
+	if err != nil {
+		queryError(w, r, err)
+		return
+	}
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, exits)
+}
+
+// ListAliens returns alive aliens sorted by ID. Filters: city (ID), name (substring)
+func (srv *HTTPService) ListAliens(w http.ResponseWriter, r *http.Request) {
+	offset, limit, err := pagination(r)
+	if err != nil {
+		badRequest(w, r, err)
+		return
+	}
+	query := r.URL.Query()
+	cityID := -1
+	if city := query.Get("city"); city != "" {
+		cityID, err = strconv.Atoi(city)
+		if err != nil {
+			badRequest(w, r, errors.New("invalid city"))
+			return
+		}
+	}
+	aliens, total := invasionFrom(r).Aliens(model.AlienFilter{CityID: cityID, Name: query.Get("name")}, offset, limit)
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, &Page{Items: aliens, Total: total, Offset: offset, Limit: limit})
+}
+
+func (srv *HTTPService) GetAlien(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		badRequest(w, r, errors.New("invalid alien id"))
+		return
+	}
+	alien, err := invasionFrom(r).Alien(id)
+	if err != nil {
+		queryError(w, r, err)
+		return
+	}
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, alien)
+}
+
+// cityParam resolves the city URL param as an ID, or as a name if it is not the ID of a city. The
+// by query param (id or name) only looks it up one way, for names that are the ID of another city
+func (srv *HTTPService) cityParam(r *http.Request) (*model.City, error) {
+	param := chi.URLParam(r, "city")
+	by := r.URL.Query().Get("by")
+	if by != "" && by != "id" && by != "name" {
+		return nil, errInvalidBy
+	}
+	if by != "name" {
+		id, err := strconv.Atoi(param)
+		if err == nil {
+			city, err := invasionFrom(r).City(id)
+			if by == "id" || !errors.Is(err, app.ErrNotFound) {
+				return city, err
+			}
+		} else if by == "id" {
+			return nil, errInvalidCityID
+		}
+	}
+	return invasionFrom(r).CityByName(param)
+}
+
+// pagination parses offset and limit query params
+func pagination(r *http.Request) (int, int, error) {
+	offset, limit := 0, defaultLimit
+	var err error
+	if v := r.URL.Query().Get("offset"); v != "" {
+		offset, err = strconv.Atoi(v)
+		if err != nil || offset < 0 {
+			return 0, 0, errors.New("invalid offset")
+		}
+	}
+	if v := r.URL.Query().Get("limit"); v != "" {
+		limit, err = strconv.Atoi(v)
+		if err != nil || limit < 1 || limit > maxLimit {
+			return 0, 0, errors.New("invalid limit (should be between 1 and 1000)")
+		}
+	}
+	return offset, limit, nil
+}
+
+// boolFilter parses an optional boolean filter
+func boolFilter(value string) (*bool, error) {
+	if value == "" {
+		return nil, nil
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return nil, errors.New("invalid boolean filter")
+	}
+	return &b, nil
+}
+
+func badRequest(w http.ResponseWriter, r *http.Request, err error) {
+	render.Status(r, http.StatusBadRequest)
+	render.JSON(w, r, err.Error())
+}
+
+// queryError replies 404 for not found errors, 400 for invalid lookups and 500 otherwise
+func queryError(w http.ResponseWriter, r *http.Request, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, app.ErrNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, errInvalidBy), errors.Is(err, errInvalidCityID):
+		status = http.StatusBadRequest
+	}
+	render.Status(r, status)
+	render.JSON(w, r, err.Error())
+}
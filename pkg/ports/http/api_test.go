@@ -0,0 +1,206 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+
+	"github.com/c-kuroki/alien_invasion/pkg/adapters/renderer"
+	"github.com/c-kuroki/alien_invasion/pkg/adapters/world"
+	"github.com/c-kuroki/alien_invasion/pkg/app"
+	"github.com/c-kuroki/alien_invasion/pkg/model"
+)
+
+// apiTestMap has cities named as numbers, "0" is also the ID of Foo and "7" is not an ID
+const apiTestMap = `Foo north=0 east=7 south=Bar
+0 south=Foo
+7 west=Foo
+Bar north=Foo west=Baz
+Baz east=Bar
+`
+
+type APITestSuite struct {
+	suite.Suite
+	invasion *app.AlienInvasionApp
+	router   http.Handler
+	snapshot *model.Snapshot
+}
+
+func (suite *APITestSuite) SetupTest() {
+	cfg := &model.Config{MaxMoves: 10, NumAliens: 8, Seed: 3, NoFinalMap: true}
+	state := world.NewInMemoryStateFromReader(strings.NewReader(apiTestMap))
+	suite.invasion = app.NewAlienInvasionApp(cfg, state, renderer.NewSVGRenderer(), zap.NewNop().Sugar())
+	suite.Require().NoError(suite.invasion.Init())
+	suite.router = NewHTTPService(suite.invasion, "127.0.0.1:0").Router()
+	suite.snapshot = suite.invasion.Snapshot()
+}
+
+// cityPage is a page of cities
+type cityPage struct {
+	Page
+	Items []*model.City `json:"items"`
+}
+
+// alienPage is a page of aliens
+type alienPage struct {
+	Page
+	Items []*model.Alien `json:"items"`
+}
+
+func (suite *APITestSuite) get(path string, v interface{}) int {
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+	if w.Code == http.StatusOK && v != nil {
+		suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), v))
+	}
+	return w.Code
+}
+
+func (suite *APITestSuite) TestPagination() {
+	var all cityPage
+	suite.Require().Equal(http.StatusOK, suite.get("/api/v1/cities", &all))
+	suite.Assert().Equal(suite.snapshot.Cities, all.Items)
+	suite.Assert().Equal(5, all.Total)
+	suite.Assert().Equal(defaultLimit, all.Limit)
+
+	var page cityPage
+	suite.Require().Equal(http.StatusOK, suite.get("/api/v1/cities?offset=1&limit=2", &page))
+	suite.Assert().Equal(all.Items[1:3], page.Items)
+	suite.Assert().Equal(5, page.Total)
+	suite.Assert().Equal(1, page.Offset)
+	suite.Assert().Equal(2, page.Limit)
+	suite.Require().Equal(http.StatusOK, suite.get("/api/v1/cities?offset=4&limit=2", &page))
+	suite.Assert().Equal(all.Items[4:], page.Items)
+	// offsets past the end return an empty page
+	suite.Require().Equal(http.StatusOK, suite.get("/api/v1/cities?offset=5", &page))
+	suite.Assert().Empty(page.Items)
+	suite.Assert().Equal(5, page.Total)
+
+	var aliens alienPage
+	suite.Require().Equal(http.StatusOK, suite.get("/api/v1/aliens?offset=2&limit=3", &aliens))
+	suite.Assert().Equal(suite.snapshot.Aliens[2:5], aliens.Items)
+	suite.Assert().Equal(8, aliens.Total)
+	suite.Require().Equal(http.StatusOK, suite.get(fmt.Sprintf("/api/v1/aliens?limit=%d", maxLimit), &aliens))
+	suite.Assert().Equal(suite.snapshot.Aliens, aliens.Items)
+
+	for _, query := range []string{"offset=-1", "offset=x", "limit=0", "limit=-1", fmt.Sprintf("limit=%d", maxLimit+1), "limit=x"} {
+		suite.Assert().Equal(http.StatusBadRequest, suite.get("/api/v1/cities?"+query, nil), query)
+		suite.Assert().Equal(http.StatusBadRequest, suite.get("/api/v1/aliens?"+query, nil), query)
+	}
+}
+
+func (suite *APITestSuite) TestFilters() {
+	// fights destroy some of the cities
+	suite.invasion.MainLoop()
+	snapshot := suite.invasion.Snapshot()
+	occupied := make(map[int]bool)
+	for _, alien := range snapshot.Aliens {
+		occupied[alien.City] = true
+	}
+	var destroyed, alive, withAliens, withoutAliens []*model.City
+	for _, city := range snapshot.Cities {
+		if city.Destroyed {
+			destroyed = append(destroyed, city)
+		} else {
+			alive = append(alive, city)
+		}
+		if occupied[city.ID] {
+			withAliens = append(withAliens, city)
+		} else {
+			withoutAliens = append(withoutAliens, city)
+		}
+	}
+	suite.Require().NotEmpty(destroyed)
+	suite.Require().NotEmpty(alive)
+
+	for query, expected := range map[string][]*model.City{
+		"destroyed=true":                 destroyed,
+		"destroyed=false":                alive,
+		"has_aliens=true":                withAliens,
+		"has_aliens=false":               withoutAliens,
+		"destroyed=true&has_aliens=true": nil,
+	} {
+		var page cityPage
+		suite.Require().Equal(http.StatusOK, suite.get("/api/v1/cities?"+query, &page), query)
+		suite.Assert().ElementsMatch(expected, page.Items, query)
+		suite.Assert().Equal(len(expected), page.Total, query)
+	}
+	var page cityPage
+	suite.Require().Equal(http.StatusOK, suite.get("/api/v1/cities?name=Ba", &page))
+	suite.Require().Len(page.Items, 2)
+	suite.Assert().Equal("Bar", page.Items[0].Name)
+	suite.Assert().Equal("Baz", page.Items[1].Name)
+	suite.Assert().Equal(http.StatusBadRequest, suite.get("/api/v1/cities?destroyed=maybe", nil))
+	suite.Assert().Equal(http.StatusBadRequest, suite.get("/api/v1/cities?has_aliens=maybe", nil))
+
+	for _, alien := range snapshot.Aliens {
+		var aliens alienPage
+		suite.Require().Equal(http.StatusOK, suite.get(fmt.Sprintf("/api/v1/aliens?city=%d", alien.City), &aliens))
+		suite.Assert().Contains(aliens.Items, alien)
+		for _, a := range aliens.Items {
+			suite.Assert().Equal(alien.City, a.City)
+		}
+		suite.Require().Equal(http.StatusOK, suite.get("/api/v1/aliens?name="+alien.Name, &aliens))
+		suite.Assert().Contains(aliens.Items, alien)
+	}
+	var aliens alienPage
+	suite.Require().Equal(http.StatusOK, suite.get(fmt.Sprintf("/api/v1/aliens?city=%d", destroyed[0].ID), &aliens))
+	suite.Assert().Empty(aliens.Items)
+	suite.Assert().Equal(0, aliens.Total)
+	suite.Assert().Equal(http.StatusBadRequest, suite.get("/api/v1/aliens?city=x", nil))
+}
+
+func (suite *APITestSuite) TestGetCity() {
+	foo, err := suite.invasion.CityByName("Foo")
+	suite.Require().NoError(err)
+	zero, err := suite.invasion.CityByName("0")
+	suite.Require().NoError(err)
+	seven, err := suite.invasion.CityByName("7")
+	suite.Require().NoError(err)
+	suite.Require().Equal(0, foo.ID)
+
+	for path, expected := range map[string]*model.City{
+		"/api/v1/cities/Foo":         foo,
+		"/api/v1/cities/0":           foo,
+		"/api/v1/cities/0?by=name":   zero,
+		"/api/v1/cities/Foo?by=name": foo,
+		"/api/v1/cities/0?by=id":     foo,
+		// no city has ID 7, so it is looked up as a name
+		"/api/v1/cities/7": seven,
+	} {
+		var city model.City
+		suite.Require().Equal(http.StatusOK, suite.get(path, &city), path)
+		suite.Assert().Equal(expected, &city, path)
+	}
+
+	var exits []model.Exit
+	suite.Require().Equal(http.StatusOK, suite.get("/api/v1/cities/7/exits", &exits))
+	suite.Assert().Equal([]model.Exit{{Direction: model.West, CityID: foo.ID, CityName: "Foo"}}, exits)
+	suite.Require().Equal(http.StatusOK, suite.get("/api/v1/cities/0/exits?by=name", &exits))
+	suite.Assert().Equal([]model.Exit{{Direction: model.South, CityID: foo.ID, CityName: "Foo"}}, exits)
+
+	for path, code := range map[string]int{
+		"/api/v1/cities/Qux":            http.StatusNotFound,
+		"/api/v1/cities/99":             http.StatusNotFound,
+		"/api/v1/cities/7?by=id":        http.StatusNotFound,
+		"/api/v1/cities/1?by=name":      http.StatusNotFound,
+		"/api/v1/cities/Qux/exits":      http.StatusNotFound,
+		"/api/v1/cities/Foo?by=id":      http.StatusBadRequest,
+		"/api/v1/cities/Foo?by=country": http.StatusBadRequest,
+		"/api/v1/aliens/99":             http.StatusNotFound,
+		"/api/v1/aliens/x":              http.StatusBadRequest,
+	} {
+		suite.Assert().Equal(code, suite.get(path, nil), path)
+	}
+}
+
+// TestAPITestSuite is the entry point of this test suite
+func TestAPITestSuite(t *testing.T) {
+	suite.Run(t, new(APITestSuite))
+}
@@ -0,0 +1,87 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/render"
+
+	"github.com/c-kuroki/alien_invasion/pkg/auth"
+)
+
+type identityKey struct{}
+
+var errAuthDisabled = errors.New("forbidden: authentication is not enabled (-auth)")
+
+// SetAuth enables authentication, endpoints then require the viewer or the operator role
+func (srv *HTTPService) SetAuth(authenticator *auth.Authenticator) {
+	srv.auth = authenticator
+}
+
+// authenticate resolves the caller identity from an API key or a signed token passed as
+// bearer token, X-API-Key header or access_token query param (for EventSource and WebSocket clients)
+func (srv *HTTPService) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		identity, err := srv.auth.Authenticate(credential(r))
+		if err != nil {
+			authError(w, r, err)
+			return
+		}
+		ctx := context.WithValue(r.Context(), identityKey{}, identity)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// require restricts a route to a role, it does nothing when authentication is disabled
+func (srv *HTTPService) require(role auth.Role) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if srv.auth == nil {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			identity, _ := r.Context().Value(identityKey{}).(*auth.Identity)
+			if err := auth.Authorize(identity, role); err != nil {
+				authError(w, r, err)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// protect restricts a route to a role like require, but rejects every request when
+// authentication is disabled (e.g. routes changing files on the server)
+func (srv *HTTPService) protect(role auth.Role) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if srv.auth == nil {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				render.Status(r, http.StatusForbidden)
+				render.JSON(w, r, errAuthDisabled.Error())
+			})
+		}
+		return srv.require(role)(next)
+	}
+}
+
+func credential(r *http.Request) string {
+	if header := r.Header.Get("Authorization"); strings.HasPrefix(header, "Bearer ") {
+		return strings.TrimSpace(strings.TrimPrefix(header, "Bearer "))
+	}
+	if key := r.Header.Get("X-API-Key"); key != "" {
+		return key
+	}
+	return r.URL.Query().Get("access_token")
+}
+
+func authError(w http.ResponseWriter, r *http.Request, err error) {
+	status := http.StatusUnauthorized
+	if errors.Is(err, auth.ErrForbidden) {
+		status = http.StatusForbidden
+	} else {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="alien_invasion"`)
+	}
+	render.Status(r, status)
+	render.JSON(w, r, err.Error())
+}
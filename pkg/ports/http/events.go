@@ -0,0 +1,203 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/render"
+	"github.com/gorilla/websocket"
+
+	"github.com/c-kuroki/alien_invasion/pkg/model"
+)
+
+const (
+	// interval between keep alive messages on idle streams
+	keepAliveInterval = 15 * time.Second
+	// marker to stream events starting from the next tick
+	fromNow = -1
+)
+
+var upgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 4096,
+}
+
+// fromTick returns the tick to resume a stream from, using the from query param or
+// the SSE Last-Event-ID header (the last complete tick received by the client)
+func fromTick(r *http.Request) (int, error) {
+	if from := r.URL.Query().Get("from"); from != "" {
+		return strconv.Atoi(from)
+	}
+	if lastID := r.Header.Get("Last-Event-ID"); lastID != "" {
+		tick, err := strconv.Atoi(lastID)
+		if err != nil {
+			return 0, err
+		}
+		return tick + 1, nil
+	}
+	// by default only new events
+	return fromNow, nil
+}
+
+// subscribe resolves the resume tick and subscribes to simulation events, replying with an
+// error if it fails
+func (srv *HTTPService) subscribe(w http.ResponseWriter, r *http.Request) ([]model.Event, <-chan model.Event, func(), bool) {
+	from, err := fromTick(r)
+	if err != nil {
+		badRequest(w, r, fmt.Errorf("invalid from tick: %w", err))
+		return nil, nil, nil, false
+	}
+	if from == fromNow {
+		from = invasionFrom(r).Tick() + 1
+	}
+	backlog, events, cancel, err := invasionFrom(r).Subscribe(from)
+	if err != nil {
+		// events of old ticks are not kept, the client has to start again from the current state
+		render.Status(r, http.StatusGone)
+		render.JSON(w, r, err.Error())
+		return nil, nil, nil, false
+	}
+	return backlog, events, cancel, true
+}
+
+// GetEvents streams simulation events as Server-Sent Events, tick and end events carry
+// the tick as event ID so clients resume from the last complete tick when reconnecting
+func (srv *HTTPService) GetEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, "streaming not supported")
+		return
+	}
+	backlog, events, cancel, ok := srv.subscribe(w, r)
+	if !ok {
+		return
+	}
+	defer cancel()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	write := func(event model.Event) error {
+		data, err := json.Marshal(event)
+		if err != nil {
+			return err
+		}
+		if event.Type == model.EventTick || event.Type == model.EventEnd {
+			if _, err := fmt.Fprintf(w, "id: %d\n", event.Tick); err != nil {
+				return err
+			}
+		}
+		_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Type, data)
+		return err
+	}
+	for _, event := range backlog {
+		if err := write(event); err != nil {
+			return
+		}
+		if event.Type == model.EventEnd {
+			flusher.Flush()
+			return
+		}
+	}
+	flusher.Flush()
+
+	keepAlive := time.NewTicker(keepAliveInterval)
+	defer keepAlive.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-srv.closing:
+			return
+		case <-keepAlive.C:
+			if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				return
+			}
+			flusher.Flush()
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			if err := write(event); err != nil {
+				return
+			}
+			// flush once per tick
+			if event.Type == model.EventTick || event.Type == model.EventEnd {
+				flusher.Flush()
+			}
+			if event.Type == model.EventEnd {
+				return
+			}
+		}
+	}
+}
+
+// GetEventsWS streams simulation events as JSON messages over a WebSocket
+func (srv *HTTPService) GetEventsWS(w http.ResponseWriter, r *http.Request) {
+	backlog, events, cancel, ok := srv.subscribe(w, r)
+	if !ok {
+		return
+	}
+	defer cancel()
+
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// upgrader already replied with an error
+		return
+	}
+	defer conn.Close()
+
+	// read messages only to detect the client closing the connection
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	for _, event := range backlog {
+		if err := conn.WriteJSON(event); err != nil {
+			return
+		}
+		if event.Type == model.EventEnd {
+			_ = conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, "simulation finished"))
+			return
+		}
+	}
+	keepAlive := time.NewTicker(keepAliveInterval)
+	defer keepAlive.Stop()
+	for {
+		select {
+		case <-closed:
+			return
+		case <-srv.closing:
+			_ = conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseGoingAway, "server shutting down"))
+			return
+		case <-keepAlive.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(time.Second)); err != nil {
+				return
+			}
+		case event, ok := <-events:
+			if !ok {
+				_ = conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseTryAgainLater, "subscriber too slow, resume from last tick"))
+				return
+			}
+			if err := conn.WriteJSON(event); err != nil {
+				return
+			}
+			if event.Type == model.EventEnd {
+				_ = conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, "simulation finished"))
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,183 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+
+	"github.com/c-kuroki/alien_invasion/pkg/adapters/renderer"
+	"github.com/c-kuroki/alien_invasion/pkg/adapters/world"
+	"github.com/c-kuroki/alien_invasion/pkg/app"
+	"github.com/c-kuroki/alien_invasion/pkg/model"
+)
+
+// number of ticks of events kept by the test simulation
+const testEventTicks = 5
+
+type EventsTestSuite struct {
+	suite.Suite
+	invasion *app.AlienInvasionApp
+	router   http.Handler
+}
+
+func (suite *EventsTestSuite) SetupTest() {
+	// a single alien never fights, so the run lasts MaxMoves+1 ticks
+	cfg := &model.Config{MaxMoves: 20, NumAliens: 1, Seed: 1, NoFinalMap: true, EventTicks: testEventTicks}
+	suite.invasion = app.NewAlienInvasionApp(cfg, world.NewInMemoryState(exampleMapFile), renderer.NewSVGRenderer(), zap.NewNop().Sugar())
+	suite.Require().NoError(suite.invasion.Init())
+	suite.invasion.MainLoop()
+	suite.router = NewHTTPService(suite.invasion, ":0").Router()
+}
+
+// sseEvent is an event parsed from a Server-Sent Events stream
+type sseEvent struct {
+	id    string
+	event string
+	data  model.Event
+}
+
+// getEvents reads the SSE stream of the finished simulation
+func (suite *EventsTestSuite) getEvents(path, lastEventID string) (int, []sseEvent) {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		return w.Code, nil
+	}
+	suite.Assert().Equal("text/event-stream", w.Header().Get("Content-Type"))
+	var events []sseEvent
+	for _, block := range strings.Split(strings.TrimSuffix(w.Body.String(), "\n\n"), "\n\n") {
+		var event sseEvent
+		for _, line := range strings.Split(block, "\n") {
+			field, value, ok := strings.Cut(line, ": ")
+			suite.Require().True(ok, "invalid line %q", line)
+			switch field {
+			case "id":
+				event.id = value
+			case "event":
+				event.event = value
+			case "data":
+				suite.Require().NoError(json.Unmarshal([]byte(value), &event.data))
+			default:
+				suite.Failf("unexpected field", "line %q", line)
+			}
+		}
+		events = append(events, event)
+	}
+	return w.Code, events
+}
+
+func (suite *EventsTestSuite) TestFraming() {
+	first := suite.invasion.Tick() - testEventTicks + 1
+	code, events := suite.getEvents(fmt.Sprintf("/events?from=%d", first), "")
+	suite.Require().Equal(http.StatusOK, code)
+	suite.Require().NotEmpty(events)
+	suite.Assert().Equal(first, events[0].data.Tick)
+	for _, event := range events {
+		suite.Assert().Equal(event.event, event.data.Type)
+		// only complete ticks have an ID to resume from
+		if event.event == model.EventTick || event.event == model.EventEnd {
+			suite.Assert().Equal(strconv.Itoa(event.data.Tick), event.id)
+		} else {
+			suite.Assert().Empty(event.id)
+		}
+	}
+	suite.Assert().Equal(model.EventEnd, events[len(events)-1].event)
+}
+
+func (suite *EventsTestSuite) TestLastEventID() {
+	last := suite.invasion.Tick()
+	code, events := suite.getEvents("/events", strconv.Itoa(last-2))
+	suite.Require().Equal(http.StatusOK, code)
+	suite.Require().NotEmpty(events)
+	suite.Assert().Equal(last-1, events[0].data.Tick)
+
+	// the from param has priority
+	code, events = suite.getEvents(fmt.Sprintf("/events?from=%d", last), strconv.Itoa(last-2))
+	suite.Require().Equal(http.StatusOK, code)
+	suite.Assert().Equal(last, events[0].data.Tick)
+}
+
+func (suite *EventsTestSuite) TestExpired() {
+	code, _ := suite.getEvents("/events?from=0", "")
+	suite.Assert().Equal(http.StatusGone, code)
+	code, _ = suite.getEvents("/events", "0")
+	suite.Assert().Equal(http.StatusGone, code)
+	code, _ = suite.getEvents("/events?from=x", "")
+	suite.Assert().Equal(http.StatusBadRequest, code)
+	code, _ = suite.getEvents("/events", "x")
+	suite.Assert().Equal(http.StatusBadRequest, code)
+}
+
+func (suite *EventsTestSuite) TestWebSocket() {
+	server := httptest.NewServer(suite.router)
+	defer server.Close()
+	first := suite.invasion.Tick() - 1
+	url := fmt.Sprintf("ws%s/events/ws?from=%d", strings.TrimPrefix(server.URL, "http"), first)
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	suite.Require().NoError(err)
+	defer conn.Close()
+
+	var events []model.Event
+	for {
+		var event model.Event
+		err := conn.ReadJSON(&event)
+		if err != nil {
+			suite.Assert().True(websocket.IsCloseError(err, websocket.CloseNormalClosure), err.Error())
+			break
+		}
+		events = append(events, event)
+	}
+	backlog, _, cancel, err := suite.invasion.Subscribe(first)
+	suite.Require().NoError(err)
+	cancel()
+	suite.Assert().Equal(backlog, events)
+
+	_, resp, err := websocket.DefaultDialer.Dial(strings.Replace(url, fmt.Sprintf("from=%d", first), "from=0", 1), nil)
+	suite.Require().Error(err)
+	suite.Assert().Equal(http.StatusGone, resp.StatusCode)
+}
+
+func (suite *EventsTestSuite) TestAfterEnd() {
+	// the simulation finished before subscribing, streams after its last tick get the end event
+	last := suite.invasion.Tick()
+	for _, from := range []int{last + 1, last + 10} {
+		code, events := suite.getEvents(fmt.Sprintf("/events?from=%d", from), "")
+		suite.Require().Equal(http.StatusOK, code)
+		suite.Require().Len(events, 1)
+		suite.Assert().Equal(model.EventEnd, events[0].event)
+		suite.Assert().Equal(last, events[0].data.Tick)
+		suite.Assert().Equal("max moves reached", events[0].data.Reason)
+	}
+	code, events := suite.getEvents("/events", strconv.Itoa(last))
+	suite.Require().Equal(http.StatusOK, code)
+	suite.Require().Len(events, 1)
+	suite.Assert().Equal(model.EventEnd, events[0].event)
+
+	server := httptest.NewServer(suite.router)
+	defer server.Close()
+	conn, _, err := websocket.DefaultDialer.Dial(fmt.Sprintf("ws%s/events/ws?from=%d", strings.TrimPrefix(server.URL, "http"), last+1), nil)
+	suite.Require().NoError(err)
+	defer conn.Close()
+	var event model.Event
+	suite.Require().NoError(conn.ReadJSON(&event))
+	suite.Assert().Equal(model.EventEnd, event.Type)
+	err = conn.ReadJSON(&event)
+	suite.Assert().True(websocket.IsCloseError(err, websocket.CloseNormalClosure), "%v", err)
+}
+
+// TestEventsTestSuite is the entry point of this test suite
+func TestEventsTestSuite(t *testing.T) {
+	suite.Run(t, new(EventsTestSuite))
+}
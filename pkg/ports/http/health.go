@@ -0,0 +1,33 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/go-chi/render"
+
+	"github.com/c-kuroki/alien_invasion/pkg/version"
+)
+
+// GetHealth reports the service is alive
+func (srv *HTTPService) GetHealth(w http.ResponseWriter, r *http.Request) {
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, map[string]string{"status": "ok"})
+}
+
+// GetReady reports the service is ready once the simulation map has loaded
+// (a service only hosting sessions is always ready)
+func (srv *HTTPService) GetReady(w http.ResponseWriter, r *http.Request) {
+	if srv.invasion != nil && !srv.invasion.Ready() {
+		render.Status(r, http.StatusServiceUnavailable)
+		render.JSON(w, r, map[string]string{"status": "not ready"})
+		return
+	}
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, map[string]string{"status": "ready"})
+}
+
+// GetVersion returns the build info
+func (srv *HTTPService) GetVersion(w http.ResponseWriter, r *http.Request) {
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, version.Get())
+}
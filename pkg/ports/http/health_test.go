@@ -0,0 +1,78 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+
+	"github.com/c-kuroki/alien_invasion/pkg/adapters/renderer"
+	"github.com/c-kuroki/alien_invasion/pkg/adapters/world"
+	"github.com/c-kuroki/alien_invasion/pkg/app"
+	"github.com/c-kuroki/alien_invasion/pkg/model"
+)
+
+type HealthTestSuite struct {
+	suite.Suite
+	invasion *app.AlienInvasionApp
+	srv      *HTTPService
+}
+
+func (suite *HealthTestSuite) SetupTest() {
+	cfg := &model.Config{TickInterval: 1000, MaxMoves: 10, NumAliens: 2}
+	suite.invasion = app.NewAlienInvasionApp(cfg, world.NewInMemoryState(exampleMapFile), renderer.NewSVGRenderer(), zap.NewNop().Sugar())
+	suite.srv = NewHTTPService(suite.invasion, "127.0.0.1:0")
+}
+
+func (suite *HealthTestSuite) get(path string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	suite.srv.Router().ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+	return w
+}
+
+func (suite *HealthTestSuite) TestHealth() {
+	suite.Assert().Equal(http.StatusOK, suite.get("/healthz").Code)
+}
+
+func (suite *HealthTestSuite) TestReadyOnceLoaded() {
+	suite.Assert().Equal(http.StatusServiceUnavailable, suite.get("/readyz").Code)
+	suite.Require().NoError(suite.invasion.Init())
+	suite.Assert().Equal(http.StatusOK, suite.get("/readyz").Code)
+}
+
+func (suite *HealthTestSuite) TestVersion() {
+	w := suite.get("/version")
+	suite.Require().Equal(http.StatusOK, w.Code)
+	var info model.BuildInfo
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &info))
+	suite.Assert().NotEmpty(info.Version)
+	suite.Assert().NotEmpty(info.GoVersion)
+}
+
+func (suite *HealthTestSuite) TestShutdown() {
+	errs := make(chan error, 1)
+	go func() { errs <- suite.srv.Start() }()
+	// wait for the server to be registered
+	suite.Require().Eventually(func() bool {
+		suite.srv.mu.Lock()
+		defer suite.srv.mu.Unlock()
+		return suite.srv.httpServer != nil
+	}, time.Second, 10*time.Millisecond)
+	suite.Require().NoError(suite.srv.Shutdown(context.Background()))
+	suite.Assert().NoError(<-errs)
+}
+
+func (suite *HealthTestSuite) TestTLSRequiresBothFiles() {
+	suite.srv.SetServerConfig(ServerConfig{TLSCertFile: "cert.pem"})
+	suite.Assert().Error(suite.srv.Start())
+}
+
+// TestHealth is the entry point of this test suite
+func TestHealth(t *testing.T) {
+	suite.Run(t, new(HealthTestSuite))
+}
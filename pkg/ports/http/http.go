@@ -1,65 +1,233 @@
 package http
 
 import (
+	"context"
+	"errors"
 	"log"
 	"net/http"
+	"sync"
 	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/render"
 
+	"github.com/c-kuroki/alien_invasion/pkg/adapters/renderer"
 	"github.com/c-kuroki/alien_invasion/pkg/app"
+	"github.com/c-kuroki/alien_invasion/pkg/auth"
+	"github.com/c-kuroki/alien_invasion/pkg/metrics"
 )
 
 type HTTPService struct {
 	invasion       *app.AlienInvasionApp
+	sessions       *app.SessionManager
+	maps           *app.MapsApp
 	serviceAddress string
+	// monitoring
+	metrics         *metrics.Registry
+	requestDuration *metrics.Histogram
+	// authentication, disabled if nil
+	auth *auth.Authenticator
+	// server lifecycle
+	cfg        ServerConfig
+	mu         sync.Mutex
+	httpServer *http.Server
+	// closing is closed on Shutdown to end event streams, which never become idle
+	closing   chan struct{}
+	closeOnce sync.Once
 }
 
+// ServerConfig configures the http server, zero timeouts mean no timeout
+type ServerConfig struct {
+	ReadTimeout time.Duration
+	// WriteTimeout also applies to event streams, which are closed when it expires
+	WriteTimeout time.Duration
+	IdleTimeout  time.Duration
+	// TLS is enabled when both files are set
+	TLSCertFile string
+	TLSKeyFile  string
+}
+
+// NewHTTPService returns a service for a single simulation, invasion can be nil when
+// the service only hosts sessions (see SetSessions)
 func NewHTTPService(invasion *app.AlienInvasionApp, serviceAddress string) *HTTPService {
 	return &HTTPService{
 		invasion:       invasion,
 		serviceAddress: serviceAddress,
+		closing:        make(chan struct{}),
+	}
+}
+
+// SetSessions enables the multi-simulation endpoints
+func (srv *HTTPService) SetSessions(sessions *app.SessionManager) {
+	srv.sessions = sessions
+}
+
+// SetServerConfig sets the server timeouts and TLS, it must be called before Start
+func (srv *HTTPService) SetServerConfig(cfg ServerConfig) {
+	srv.cfg = cfg
+}
+
+// Start serves requests until Shutdown is called, it returns nil after a Shutdown
+func (srv *HTTPService) Start() error {
+	if (srv.cfg.TLSCertFile == "") != (srv.cfg.TLSKeyFile == "") {
+		return errors.New("tls requires both cert and key files")
+	}
+	httpServer := &http.Server{
+		Addr:         srv.serviceAddress,
+		Handler:      srv.Router(),
+		ReadTimeout:  srv.cfg.ReadTimeout,
+		WriteTimeout: srv.cfg.WriteTimeout,
+		IdleTimeout:  srv.cfg.IdleTimeout,
 	}
+	srv.mu.Lock()
+	if srv.httpServer != nil {
+		srv.mu.Unlock()
+		return errors.New("http server already started")
+	}
+	srv.httpServer = httpServer
+	srv.mu.Unlock()
+
+	var err error
+	if srv.cfg.TLSCertFile != "" {
+		log.Printf("Starting https server at %s\n", srv.serviceAddress)
+		err = httpServer.ListenAndServeTLS(srv.cfg.TLSCertFile, srv.cfg.TLSKeyFile)
+	} else {
+		log.Printf("Starting http server at %s\n", srv.serviceAddress)
+		err = httpServer.ListenAndServe()
+	}
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+	return err
+}
+
+// Shutdown stops accepting connections and waits for active requests until ctx is done
+func (srv *HTTPService) Shutdown(ctx context.Context) error {
+	srv.closeOnce.Do(func() { close(srv.closing) })
+	srv.mu.Lock()
+	httpServer := srv.httpServer
+	srv.mu.Unlock()
+	if httpServer == nil {
+		return nil
+	}
+	return httpServer.Shutdown(ctx)
+}
+
+// SetMaps enables the map management endpoints
+func (srv *HTTPService) SetMaps(maps *app.MapsApp) {
+	srv.maps = maps
 }
 
-func (srv *HTTPService) Start() {
+// Router returns the service routes
+func (srv *HTTPService) Router() chi.Router {
 	r := chi.NewRouter()
 
 	//r.Use(middleware.Logger)
 	r.Use(middleware.Recoverer)
-	r.Use(middleware.Timeout(60 * time.Second))
+	if srv.metrics != nil {
+		r.Use(srv.measure)
+	}
 
-	// public endpoint
-	r.Get("/", srv.GetIndex)
-	r.Get("/map", srv.GetMap)
+	// public endpoints, credentials are not checked (a bad one does not fail health checks)
+	r.Get("/openapi.json", srv.GetOpenAPI)
+	r.Get("/healthz", srv.GetHealth)
+	r.Get("/readyz", srv.GetReady)
+	r.Get("/version", srv.GetVersion)
+	// static viewer files, the viewer authenticates its requests with the page access_token
+	r.Get("/viewer/*", viewerHandler().ServeHTTP)
+	if srv.invasion != nil {
+		r.With(middleware.Timeout(60*time.Second)).Get("/", srv.GetIndex)
+	}
 
-	log.Printf("Starting http server at %s\n", srv.serviceAddress)
-	httpServer := &http.Server{Addr: srv.serviceAddress, Handler: r}
-	err := httpServer.ListenAndServe()
-	if err != nil {
-		panic(err)
+	// viewer endpoints, mutations require the operator role
+	r.Group(func(r chi.Router) {
+		if srv.auth != nil {
+			r.Use(srv.authenticate)
+		}
+		r.Use(srv.require(auth.RoleViewer))
+		if srv.metrics != nil {
+			r.Get("/metrics", srv.GetMetrics)
+		}
+		if srv.invasion != nil {
+			r.Group(func(r chi.Router) {
+				r.Use(withInvasion(srv.invasion))
+				srv.simulationRoutes(r)
+			})
+		}
+		r.Route("/api/v1", func(r chi.Router) {
+			if srv.invasion != nil {
+				r.Group(func(r chi.Router) {
+					r.Use(withInvasion(srv.invasion))
+					r.Use(middleware.Timeout(60 * time.Second))
+					srv.apiRoutes(r)
+				})
+			}
+			if srv.sessions != nil {
+				r.Route("/simulations", srv.sessionRoutes)
+			}
+			if srv.maps != nil {
+				r.Route("/maps", srv.mapRoutes)
+			}
+		})
+	})
+	return r
+}
+
+// simulationRoutes registers the endpoints to observe and control a simulation
+func (srv *HTTPService) simulationRoutes(r chi.Router) {
+	r.Group(func(r chi.Router) {
+		r.Use(middleware.Timeout(60 * time.Second))
+		r.Get("/map", srv.GetMap)
+		r.Get("/state", srv.GetState)
+		r.Get("/diff", srv.GetDiff)
+		r.With(srv.require(auth.RoleOperator)).Post("/control/{action}", srv.PostControl)
+	})
+	// streaming endpoints (no timeout)
+	r.Get("/events", srv.GetEvents)
+	r.Get("/events/ws", srv.GetEventsWS)
+}
+
+type invasionKey struct{}
+
+// withInvasion sets the simulation used by handlers
+func withInvasion(invasion *app.AlienInvasionApp) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := context.WithValue(r.Context(), invasionKey{}, invasion)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
 	}
 }
 
-func (srv *HTTPService) GetIndex(w http.ResponseWriter, r *http.Request) {
-	render.Status(r, http.StatusOK)
-	render.HTML(w, r, `
-<html>
-<head>
-<meta http-equiv="refresh" content="1" />
-</head>
-<body>
-<img src="/map" />
-</body>
-</html>
-`)
+// invasionFrom returns the simulation handled by a request
+func invasionFrom(r *http.Request) *app.AlienInvasionApp {
+	return r.Context().Value(invasionKey{}).(*app.AlienInvasionApp)
 }
 
+// GetMap returns a SVG map, an optional overlay query param colors cities by a metric
+// (visits, fights, time_to_destruction or destruction_probability) and an optional theme
+// query param selects the theme by name
 func (srv *HTTPService) GetMap(w http.ResponseWriter, r *http.Request) {
+	overlay := renderer.Overlay(r.URL.Query().Get("overlay"))
+	if overlay != renderer.OverlayNone && !renderer.IsValidOverlay(overlay) {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, "invalid overlay")
+		return
+	}
+	ctx := themeContext(r)
 	w.Header().Set("Content-Type", "image/svg+xml")
-	err := srv.invasion.RenderMap(r.Context(), w)
+	var err error
+	if overlay != renderer.OverlayNone {
+		err = invasionFrom(r).RenderOverlay(ctx, overlay, w)
+	} else {
+		err = invasionFrom(r).RenderMap(ctx, w)
+	}
+	if errors.Is(err, renderer.ErrUnknownTheme) {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, err.Error())
+		return
+	}
 	if err != nil {
 		render.Status(r, http.StatusInternalServerError)
 		render.JSON(w, r, err.Error())
@@ -67,3 +235,11 @@ func (srv *HTTPService) GetMap(w http.ResponseWriter, r *http.Request) {
 	}
 	render.Status(r, http.StatusOK)
 }
+
+// themeContext returns the request context selecting the theme passed as query param
+func themeContext(r *http.Request) context.Context {
+	if theme := r.URL.Query().Get("theme"); theme != "" {
+		return renderer.WithTheme(r.Context(), theme)
+	}
+	return r.Context()
+}
@@ -0,0 +1,165 @@
+package http
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+
+	"github.com/c-kuroki/alien_invasion/pkg/adapters/maps"
+	"github.com/c-kuroki/alien_invasion/pkg/adapters/renderer"
+	"github.com/c-kuroki/alien_invasion/pkg/app"
+	"github.com/c-kuroki/alien_invasion/pkg/auth"
+	"github.com/c-kuroki/alien_invasion/pkg/model"
+)
+
+// mapRoutes registers the map management endpoints
+func (srv *HTTPService) mapRoutes(r chi.Router) {
+	r.Use(middleware.Timeout(60 * time.Second))
+	r.Get("/", srv.ListMaps)
+	r.Post("/validate", srv.ValidateMap)
+	r.Post("/preview", srv.PreviewMap)
+	r.Get("/{name}", srv.DownloadMap)
+	// maps are only changed by authenticated operators
+	r.With(srv.protect(auth.RoleOperator)).Put("/{name}", srv.UploadMap)
+	r.With(srv.protect(auth.RoleOperator)).Delete("/{name}", srv.DeleteMap)
+	r.Get("/{name}/preview", srv.PreviewStoredMap)
+}
+
+func (srv *HTTPService) ListMaps(w http.ResponseWriter, r *http.Request) {
+	list, err := srv.maps.List()
+	if err != nil {
+		mapError(w, r, err)
+		return
+	}
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, list)
+}
+
+// ValidateMap validates a map sent as request body (or as "map" multipart file) without storing it
+func (srv *HTTPService) ValidateMap(w http.ResponseWriter, r *http.Request) {
+	data, err := readMap(r)
+	if err != nil {
+		badRequest(w, r, err)
+		return
+	}
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, srv.maps.Validate(data))
+}
+
+// PreviewMap renders a map sent as request body without storing it
+func (srv *HTTPService) PreviewMap(w http.ResponseWriter, r *http.Request) {
+	data, err := readMap(r)
+	if err != nil {
+		badRequest(w, r, err)
+		return
+	}
+	srv.preview(w, r, func(w io.Writer) error {
+		return srv.maps.Preview(themeContext(r), data, w)
+	})
+}
+
+func (srv *HTTPService) PreviewStoredMap(w http.ResponseWriter, r *http.Request) {
+	name := chi.URLParam(r, "name")
+	srv.preview(w, r, func(w io.Writer) error {
+		return srv.maps.PreviewStored(themeContext(r), name, w)
+	})
+}
+
+// preview renders on a buffer, so errors can be returned as JSON
+func (srv *HTTPService) preview(w http.ResponseWriter, r *http.Request, renderFunc func(io.Writer) error) {
+	var buf bytes.Buffer
+	if err := renderFunc(&buf); err != nil {
+		var invalid *model.MapError
+		if errors.As(err, &invalid) {
+			render.Status(r, http.StatusUnprocessableEntity)
+			render.JSON(w, r, invalid)
+			return
+		}
+		mapError(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", "image/svg+xml")
+	w.WriteHeader(http.StatusOK)
+	_, _ = buf.WriteTo(w)
+}
+
+func (srv *HTTPService) DownloadMap(w http.ResponseWriter, r *http.Request) {
+	data, err := srv.maps.Get(chi.URLParam(r, "name"))
+	if err != nil {
+		mapError(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(data)
+}
+
+// UploadMap validates and stores a map sent as request body (or as "map" multipart file),
+// invalid maps are rejected with 422 and the validation result
+func (srv *HTTPService) UploadMap(w http.ResponseWriter, r *http.Request) {
+	data, err := readMap(r)
+	if err != nil {
+		badRequest(w, r, err)
+		return
+	}
+	info, validation, err := srv.maps.Upload(chi.URLParam(r, "name"), data)
+	if errors.Is(err, app.ErrInvalidMap) {
+		render.Status(r, http.StatusUnprocessableEntity)
+		render.JSON(w, r, validation)
+		return
+	}
+	if err != nil {
+		mapError(w, r, err)
+		return
+	}
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, info)
+}
+
+func (srv *HTTPService) DeleteMap(w http.ResponseWriter, r *http.Request) {
+	if err := srv.maps.Delete(chi.URLParam(r, "name")); err != nil {
+		mapError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// readMap reads a map from the request body, or from the "map" file of a multipart form
+func readMap(r *http.Request) ([]byte, error) {
+	// the whole body is limited, ParseMultipartForm only limits the memory used
+	r.Body = http.MaxBytesReader(nil, r.Body, maxMapSize)
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		if err := r.ParseMultipartForm(maxMapSize); err != nil {
+			return nil, err
+		}
+		file, _, err := r.FormFile("map")
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+		return io.ReadAll(file)
+	}
+	return io.ReadAll(r.Body)
+}
+
+// mapError replies with the status matching a map store error
+func mapError(w http.ResponseWriter, r *http.Request, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, maps.ErrNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, maps.ErrInvalidName), errors.Is(err, renderer.ErrUnknownTheme):
+		status = http.StatusBadRequest
+	case errors.Is(err, maps.ErrReadOnly):
+		status = http.StatusForbidden
+	}
+	render.Status(r, status)
+	render.JSON(w, r, err.Error())
+}
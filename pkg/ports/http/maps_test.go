@@ -0,0 +1,187 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/c-kuroki/alien_invasion/pkg/adapters/maps"
+	"github.com/c-kuroki/alien_invasion/pkg/adapters/renderer"
+	"github.com/c-kuroki/alien_invasion/pkg/app"
+	"github.com/c-kuroki/alien_invasion/pkg/auth"
+	"github.com/c-kuroki/alien_invasion/pkg/model"
+)
+
+const invalidMap = "Foo north=Bar\nBar up=Foo\n"
+
+type MapsTestSuite struct {
+	suite.Suite
+	dir     string
+	srv     *HTTPService
+	router  http.Handler
+	example []byte
+}
+
+func (suite *MapsTestSuite) SetupTest() {
+	var err error
+	suite.example, err = os.ReadFile(exampleMapFile)
+	suite.Require().NoError(err)
+	suite.dir = suite.T().TempDir()
+	presets := suite.T().TempDir()
+	suite.Require().NoError(os.WriteFile(filepath.Join(presets, "preset.map"), suite.example, 0o644))
+
+	suite.srv = NewHTTPService(nil, ":0")
+	suite.srv.SetMaps(app.NewMapsApp(maps.NewFileStore(suite.dir, presets), renderer.NewSVGRenderer()))
+	authenticator, err := auth.NewAuthenticator(&auth.Config{
+		Keys:      []auth.Key{{Name: "ops", Key: "operator-key-for-tests", Role: auth.RoleOperator}},
+		Anonymous: auth.RoleViewer,
+	})
+	suite.Require().NoError(err)
+	suite.srv.SetAuth(authenticator)
+	suite.router = suite.srv.Router()
+}
+
+// do sends a request as operator, returning the status and the body
+func (suite *MapsTestSuite) do(method, path, contentType string, body []byte) (int, []byte) {
+	req := httptest.NewRequest(method, path, bytes.NewReader(body))
+	req.Header.Set("X-API-Key", "operator-key-for-tests")
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+	return w.Code, w.Body.Bytes()
+}
+
+// multipartMap returns a multipart form with data as "map" file
+func multipartMap(data []byte) (string, []byte) {
+	var b bytes.Buffer
+	mw := multipart.NewWriter(&b)
+	fw, _ := mw.CreateFormFile("map", "world.map")
+	_, _ = fw.Write(data)
+	_ = mw.Close()
+	return mw.FormDataContentType(), b.Bytes()
+}
+
+func (suite *MapsTestSuite) list() []*model.MapInfo {
+	code, body := suite.do(http.MethodGet, "/api/v1/maps", "", nil)
+	suite.Require().Equal(http.StatusOK, code)
+	var list []*model.MapInfo
+	suite.Require().NoError(json.Unmarshal(body, &list))
+	return list
+}
+
+func (suite *MapsTestSuite) TestUploadAndDelete() {
+	code, _ := suite.do(http.MethodPut, "/api/v1/maps/world", "text/plain", suite.example)
+	suite.Require().Equal(http.StatusOK, code)
+	code, body := suite.do(http.MethodGet, "/api/v1/maps/world", "", nil)
+	suite.Assert().Equal(http.StatusOK, code)
+	suite.Assert().Equal(suite.example, body)
+
+	// multipart uploads replace the map
+	contentType, form := multipartMap([]byte("Foo\n"))
+	code, _ = suite.do(http.MethodPut, "/api/v1/maps/world", contentType, form)
+	suite.Require().Equal(http.StatusOK, code)
+	_, body = suite.do(http.MethodGet, "/api/v1/maps/world", "", nil)
+	suite.Assert().Equal("Foo\n", string(body))
+	suite.Assert().Len(suite.list(), 2)
+
+	code, _ = suite.do(http.MethodDelete, "/api/v1/maps/world", "", nil)
+	suite.Assert().Equal(http.StatusNoContent, code)
+	code, _ = suite.do(http.MethodGet, "/api/v1/maps/world", "", nil)
+	suite.Assert().Equal(http.StatusNotFound, code)
+	code, _ = suite.do(http.MethodDelete, "/api/v1/maps/world", "", nil)
+	suite.Assert().Equal(http.StatusNotFound, code)
+}
+
+func (suite *MapsTestSuite) TestInvalidNames() {
+	for _, name := range []string{"bad.name", "..%2F..%2Fetc%2Fpasswd", "%2E%2E", "with%20space", strings.Repeat("a", 65)} {
+		path := "/api/v1/maps/" + name
+		code, _ := suite.do(http.MethodPut, path, "text/plain", suite.example)
+		suite.Assert().Equal(http.StatusBadRequest, code, "PUT %s", name)
+		code, _ = suite.do(http.MethodGet, path, "", nil)
+		suite.Assert().Equal(http.StatusBadRequest, code, "GET %s", name)
+		code, _ = suite.do(http.MethodDelete, path, "", nil)
+		suite.Assert().Equal(http.StatusBadRequest, code, "DELETE %s", name)
+	}
+	entries, err := os.ReadDir(suite.dir)
+	suite.Require().NoError(err)
+	suite.Assert().Empty(entries)
+}
+
+func (suite *MapsTestSuite) TestInvalidMap() {
+	code, body := suite.do(http.MethodPut, "/api/v1/maps/broken", "text/plain", []byte(invalidMap))
+	suite.Require().Equal(http.StatusUnprocessableEntity, code)
+	var validation model.MapValidation
+	suite.Require().NoError(json.Unmarshal(body, &validation))
+	suite.Assert().False(validation.Valid)
+	suite.Require().NotNil(validation.Error)
+	suite.Assert().Equal(uint64(2), validation.Error.Line)
+	code, _ = suite.do(http.MethodGet, "/api/v1/maps/broken", "", nil)
+	suite.Assert().Equal(http.StatusNotFound, code)
+
+	code, body = suite.do(http.MethodPost, "/api/v1/maps/validate", "text/plain", []byte(invalidMap))
+	suite.Require().Equal(http.StatusOK, code)
+	suite.Require().NoError(json.Unmarshal(body, &validation))
+	suite.Assert().False(validation.Valid)
+	suite.Assert().Equal(uint64(2), validation.Error.Line)
+}
+
+func (suite *MapsTestSuite) TestTooLarge() {
+	contentType, form := multipartMap(bytes.Repeat([]byte("a"), maxMapSize+1))
+	code, _ := suite.do(http.MethodPut, "/api/v1/maps/large", contentType, form)
+	suite.Assert().Equal(http.StatusBadRequest, code)
+	code, _ = suite.do(http.MethodPut, "/api/v1/maps/large", "text/plain", bytes.Repeat([]byte("a"), maxMapSize+1))
+	suite.Assert().Equal(http.StatusBadRequest, code)
+}
+
+func (suite *MapsTestSuite) TestPresets() {
+	list := suite.list()
+	suite.Require().Len(list, 1)
+	suite.Assert().Equal("preset", list[0].Name)
+	suite.Assert().True(list[0].ReadOnly)
+	code, _ := suite.do(http.MethodDelete, "/api/v1/maps/preset", "", nil)
+	suite.Assert().Equal(http.StatusForbidden, code)
+
+	// an uploaded map hides the preset until deleted
+	code, _ = suite.do(http.MethodPut, "/api/v1/maps/preset", "text/plain", []byte("Foo\n"))
+	suite.Require().Equal(http.StatusOK, code)
+	_, body := suite.do(http.MethodGet, "/api/v1/maps/preset", "", nil)
+	suite.Assert().Equal("Foo\n", string(body))
+	suite.Assert().False(suite.list()[0].ReadOnly)
+	code, _ = suite.do(http.MethodDelete, "/api/v1/maps/preset", "", nil)
+	suite.Assert().Equal(http.StatusNoContent, code)
+	_, body = suite.do(http.MethodGet, "/api/v1/maps/preset", "", nil)
+	suite.Assert().Equal(suite.example, body)
+}
+
+func (suite *MapsTestSuite) TestWithoutAuth() {
+	srv := NewHTTPService(nil, ":0")
+	srv.SetMaps(suite.srv.maps)
+	router := srv.Router()
+	for _, method := range []string{http.MethodPut, http.MethodDelete} {
+		req := httptest.NewRequest(method, "/api/v1/maps/preset", io.NopCloser(bytes.NewReader(suite.example)))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		suite.Assert().Equal(http.StatusForbidden, w.Code, method)
+	}
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/maps/preset", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	suite.Assert().Equal(http.StatusOK, w.Code)
+	suite.Assert().Len(suite.list(), 1)
+}
+
+// TestMapsTestSuite is the entry point of this test suite
+func TestMapsTestSuite(t *testing.T) {
+	suite.Run(t, new(MapsTestSuite))
+}
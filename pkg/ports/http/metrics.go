@@ -0,0 +1,85 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+
+	"github.com/c-kuroki/alien_invasion/pkg/metrics"
+	"github.com/c-kuroki/alien_invasion/pkg/model"
+)
+
+// simulation label value of the simulation of a single simulation service
+const defaultSimulation = "default"
+
+// SetMetrics enables the /metrics endpoint, registering simulation and request metrics on reg
+func (srv *HTTPService) SetMetrics(reg *metrics.Registry) {
+	srv.metrics = reg
+	srv.requestDuration = reg.NewHistogram("alien_invasion_http_request_duration_seconds",
+		"Duration of HTTP requests by method, route and status code.", nil, "method", "route", "code")
+
+	gauge := func(name, help string, value func(*model.Metrics) float64) {
+		reg.NewGaugeFunc(name, help, srv.collectSimulations(value), "simulation")
+	}
+	counter := func(name, help string, value func(*model.Metrics) float64) {
+		reg.NewCounterFunc(name, help, srv.collectSimulations(value), "simulation")
+	}
+	gauge("alien_invasion_tick", "Last executed tick.",
+		func(m *model.Metrics) float64 { return float64(m.Tick) })
+	gauge("alien_invasion_alive_aliens", "Aliens still alive.",
+		func(m *model.Metrics) float64 { return float64(m.AliveAliens) })
+	gauge("alien_invasion_remaining_cities", "Cities not destroyed.",
+		func(m *model.Metrics) float64 { return float64(m.RemainingCities) })
+	gauge("alien_invasion_destroyed_cities", "Cities destroyed by fights.",
+		func(m *model.Metrics) float64 { return float64(m.DestroyedCities) })
+	gauge("alien_invasion_tick_fights", "Fights during the last tick.",
+		func(m *model.Metrics) float64 { return float64(m.TickFights) })
+	gauge("alien_invasion_moves_per_second", "Alien moves per second during the last tick.",
+		func(m *model.Metrics) float64 { return m.MovesPerSecond })
+	counter("alien_invasion_fights_total", "Fights since the simulation start.",
+		func(m *model.Metrics) float64 { return float64(m.Fights) })
+	counter("alien_invasion_moves_total", "Alien moves since the simulation start.",
+		func(m *model.Metrics) float64 { return float64(m.Moves) })
+}
+
+// collectSimulations emits a metric value for every simulation, labeled by session ID
+func (srv *HTTPService) collectSimulations(value func(*model.Metrics) float64) metrics.CollectFunc {
+	return func(emit func(float64, ...string)) {
+		if srv.invasion != nil {
+			emit(value(srv.invasion.Metrics()), defaultSimulation)
+		}
+		if srv.sessions != nil {
+			for _, session := range srv.sessions.List() {
+				emit(value(session.App.Metrics()), session.ID)
+			}
+		}
+	}
+}
+
+// GetMetrics returns the metrics in Prometheus text format
+func (srv *HTTPService) GetMetrics(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	_, _ = srv.metrics.WriteTo(w)
+}
+
+// measure records the duration of requests by route pattern (not path, to keep cardinality low)
+func (srv *HTTPService) measure(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+		next.ServeHTTP(ww, r)
+		route := "unmatched"
+		if rctx := chi.RouteContext(r.Context()); rctx != nil && rctx.RoutePattern() != "" {
+			route = rctx.RoutePattern()
+		}
+		status := ww.Status()
+		if status == 0 {
+			status = http.StatusOK
+		}
+		srv.requestDuration.Observe(time.Since(start).Seconds(), r.Method, route, strconv.Itoa(status))
+	})
+}
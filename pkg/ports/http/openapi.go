@@ -0,0 +1,17 @@
+package http
+
+import (
+	_ "embed"
+	"net/http"
+)
+
+// openAPI is the OpenAPI 3 document of the service, routes are checked against it by tests
+//
+//go:embed openapi.json
+var openAPI []byte
+
+func (srv *HTTPService) GetOpenAPI(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(openAPI)
+}
@@ -0,0 +1,91 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+
+	"github.com/c-kuroki/alien_invasion/pkg/adapters/maps"
+	"github.com/c-kuroki/alien_invasion/pkg/adapters/renderer"
+	"github.com/c-kuroki/alien_invasion/pkg/adapters/world"
+	"github.com/c-kuroki/alien_invasion/pkg/app"
+	"github.com/c-kuroki/alien_invasion/pkg/metrics"
+	"github.com/c-kuroki/alien_invasion/pkg/model"
+)
+
+const exampleMapFile = "../../../examples/world.map"
+
+// routes not documented on the OpenAPI document
+var undocumentedRoutes = map[string]bool{
+	"GET /viewer/*": true,
+}
+
+type OpenAPITestSuite struct {
+	suite.Suite
+	srv *HTTPService
+}
+
+func (suite *OpenAPITestSuite) SetupTest() {
+	rnd := renderer.NewSVGRenderer()
+	cfg := &model.Config{TickInterval: 1000, MaxMoves: 10, NumAliens: 2}
+	invasion := app.NewAlienInvasionApp(cfg, world.NewInMemoryState(exampleMapFile), rnd, zap.NewNop().Sugar())
+	store := maps.NewFileStore("../../../examples")
+	suite.srv = NewHTTPService(invasion, ":0")
+	suite.srv.SetMaps(app.NewMapsApp(store, rnd))
+	sessions := app.NewSessionManager(app.DefaultSessionsConfig(), store, rnd, zap.NewNop().Sugar())
+	suite.T().Cleanup(sessions.Close)
+	suite.srv.SetSessions(sessions)
+	suite.srv.SetMetrics(metrics.NewRegistry())
+}
+
+func (suite *OpenAPITestSuite) TestRoutesMatchDocument() {
+	var doc struct {
+		OpenAPI string                            `json:"openapi"`
+		Paths   map[string]map[string]interface{} `json:"paths"`
+	}
+	suite.Require().NoError(json.Unmarshal(openAPI, &doc))
+	suite.Assert().True(strings.HasPrefix(doc.OpenAPI, "3."))
+
+	documented := make(map[string]bool)
+	for path, operations := range doc.Paths {
+		for method := range operations {
+			documented[strings.ToUpper(method)+" "+path] = true
+		}
+	}
+
+	routed := make(map[string]bool)
+	err := chi.Walk(suite.srv.Router(), func(method, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+		// sub routers register "/" routes with a trailing slash
+		if route != "/" {
+			route = strings.TrimSuffix(route, "/")
+		}
+		key := method + " " + route
+		if !undocumentedRoutes[key] {
+			routed[key] = true
+		}
+		return nil
+	})
+	suite.Require().NoError(err)
+
+	suite.Assert().Equal(sortedKeys(documented), sortedKeys(routed))
+}
+
+// TestOpenAPI is the entry point of this test suite
+func TestOpenAPI(t *testing.T) {
+	suite.Run(t, new(OpenAPITestSuite))
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,159 @@
+package http
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+
+	"github.com/c-kuroki/alien_invasion/pkg/app"
+	"github.com/c-kuroki/alien_invasion/pkg/auth"
+	"github.com/c-kuroki/alien_invasion/pkg/model"
+)
+
+// max size of an uploaded map
+const maxMapSize = 32 << 20
+
+// sessionRoutes registers the multi-simulation endpoints
+func (srv *HTTPService) sessionRoutes(r chi.Router) {
+	r.Group(func(r chi.Router) {
+		r.Use(middleware.Timeout(60 * time.Second))
+		r.With(srv.require(auth.RoleOperator)).Post("/", srv.CreateSimulation)
+		r.Get("/", srv.ListSimulations)
+	})
+	r.Route("/{sid}", func(r chi.Router) {
+		r.Use(srv.withSession)
+		r.Group(func(r chi.Router) {
+			r.Use(middleware.Timeout(60 * time.Second))
+			r.Get("/", srv.GetSimulation)
+			r.With(srv.require(auth.RoleOperator)).Delete("/", srv.DeleteSimulation)
+			// the viewer of the session, its requests are relative to this path
+			r.Get("/view", srv.GetIndex)
+			srv.apiRoutes(r)
+		})
+		srv.simulationRoutes(r)
+	})
+}
+
+// withSession resolves the {sid} URL param and sets the session simulation used by handlers
+func (srv *HTTPService) withSession(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		session, err := srv.sessions.Get(chi.URLParam(r, "sid"))
+		if err != nil {
+			queryError(w, r, err)
+			return
+		}
+		withInvasion(session.App)(next).ServeHTTP(w, r)
+	})
+}
+
+// CreateSimulation starts a new simulation from a JSON request, or from a multipart form
+// with the map uploaded as "map" file and the rest of the parameters as form values
+func (srv *HTTPService) CreateSimulation(w http.ResponseWriter, r *http.Request) {
+	req, err := parseSimulationRequest(r)
+	if err != nil {
+		badRequest(w, r, err)
+		return
+	}
+	session, err := srv.sessions.Create(req)
+	switch {
+	case errors.Is(err, app.ErrInvalidSimulation):
+		badRequest(w, r, err)
+		return
+	case errors.Is(err, app.ErrTooManySessions):
+		render.Status(r, http.StatusTooManyRequests)
+		render.JSON(w, r, err.Error())
+		return
+	case err != nil:
+		queryError(w, r, err)
+		return
+	}
+	w.Header().Set("Location", path.Join(r.URL.Path, session.ID))
+	render.Status(r, http.StatusCreated)
+	render.JSON(w, r, session.Info())
+}
+
+func (srv *HTTPService) ListSimulations(w http.ResponseWriter, r *http.Request) {
+	sessions := srv.sessions.List()
+	infos := make([]*model.SessionInfo, 0, len(sessions))
+	for _, session := range sessions {
+		infos = append(infos, session.Info())
+	}
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, infos)
+}
+
+func (srv *HTTPService) GetSimulation(w http.ResponseWriter, r *http.Request) {
+	session, err := srv.sessions.Get(chi.URLParam(r, "sid"))
+	if err != nil {
+		queryError(w, r, err)
+		return
+	}
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, session.Info())
+}
+
+func (srv *HTTPService) DeleteSimulation(w http.ResponseWriter, r *http.Request) {
+	err := srv.sessions.Delete(chi.URLParam(r, "sid"))
+	if err != nil {
+		queryError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// parseSimulationRequest reads a JSON or multipart simulation request
+func parseSimulationRequest(r *http.Request) (model.SimulationRequest, error) {
+	var req model.SimulationRequest
+	r.Body = http.MaxBytesReader(nil, r.Body, maxMapSize)
+	if !strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			return req, errors.New("invalid simulation request")
+		}
+		return req, nil
+	}
+	if err := r.ParseMultipartForm(maxMapSize); err != nil {
+		return req, err
+	}
+	file, _, err := r.FormFile("map")
+	if err == nil {
+		defer file.Close()
+		data, err := io.ReadAll(file)
+		if err != nil {
+			return req, err
+		}
+		req.Map = string(data)
+	}
+	req.Example = r.FormValue("example")
+	intValues := map[string]*int{
+		"aliens":        &req.NumAliens,
+		"tick_interval": &req.TickInterval,
+		"max_moves":     &req.MaxMoves,
+		"history":       &req.History,
+	}
+	for name, value := range intValues {
+		if v := r.FormValue(name); v != "" {
+			if *value, err = strconv.Atoi(v); err != nil {
+				return req, errors.New("invalid " + name)
+			}
+		}
+	}
+	if v := r.FormValue("seed"); v != "" {
+		if req.Seed, err = strconv.ParseInt(v, 10, 64); err != nil {
+			return req, errors.New("invalid seed")
+		}
+	}
+	if v := r.FormValue("paused"); v != "" {
+		if req.Paused, err = strconv.ParseBool(v); err != nil {
+			return req, errors.New("invalid paused")
+		}
+	}
+	return req, nil
+}
@@ -0,0 +1,108 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+
+	"github.com/c-kuroki/alien_invasion/pkg/adapters/maps"
+	"github.com/c-kuroki/alien_invasion/pkg/adapters/renderer"
+	"github.com/c-kuroki/alien_invasion/pkg/app"
+	"github.com/c-kuroki/alien_invasion/pkg/model"
+)
+
+type SessionsTestSuite struct {
+	suite.Suite
+	router http.Handler
+}
+
+func (suite *SessionsTestSuite) SetupTest() {
+	cfg := app.DefaultSessionsConfig()
+	cfg.MaxSessions = 1
+	rnd := renderer.NewSVGRenderer()
+	sessions := app.NewSessionManager(cfg, maps.NewFileStore("../../../examples"), rnd, zap.NewNop().Sugar())
+	suite.T().Cleanup(sessions.Close)
+	srv := NewHTTPService(nil, ":0")
+	srv.SetSessions(sessions)
+	suite.router = srv.Router()
+}
+
+func (suite *SessionsTestSuite) do(method, path string, body interface{}) *httptest.ResponseRecorder {
+	var b bytes.Buffer
+	if body != nil {
+		suite.Require().NoError(json.NewEncoder(&b).Encode(body))
+	}
+	req := httptest.NewRequest(method, path, &b)
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+	return w
+}
+
+func (suite *SessionsTestSuite) TestCreateAndDelete() {
+	req := model.SimulationRequest{Example: "world", NumAliens: 2, Seed: 1, Paused: true}
+	w := suite.do(http.MethodPost, "/api/v1/simulations", req)
+	suite.Require().Equal(http.StatusCreated, w.Code)
+	var info model.SessionInfo
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &info))
+	suite.Assert().Equal("/api/v1/simulations/"+info.ID, w.Header().Get("Location"))
+	suite.Require().NotNil(info.ExpiresAt)
+
+	// the limit is reached
+	w = suite.do(http.MethodPost, "/api/v1/simulations", req)
+	suite.Assert().Equal(http.StatusTooManyRequests, w.Code)
+
+	w = suite.do(http.MethodGet, "/api/v1/simulations/"+info.ID+"/aliens?city=-1", nil)
+	suite.Assert().Equal(http.StatusOK, w.Code)
+	w = suite.do(http.MethodDelete, "/api/v1/simulations/"+info.ID, nil)
+	suite.Assert().Equal(http.StatusNoContent, w.Code)
+	w = suite.do(http.MethodGet, "/api/v1/simulations/"+info.ID, nil)
+	suite.Assert().Equal(http.StatusNotFound, w.Code)
+	w = suite.do(http.MethodDelete, "/api/v1/simulations/"+info.ID, nil)
+	suite.Assert().Equal(http.StatusNotFound, w.Code)
+
+	// the location has no double slash when posting with a trailing one
+	w = suite.do(http.MethodPost, "/api/v1/simulations/", req)
+	suite.Require().Equal(http.StatusCreated, w.Code)
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &info))
+	suite.Assert().Equal("/api/v1/simulations/"+info.ID, w.Header().Get("Location"))
+}
+
+func (suite *SessionsTestSuite) TestInvalid() {
+	w := suite.do(http.MethodPost, "/api/v1/simulations", model.SimulationRequest{Example: "world"})
+	suite.Assert().Equal(http.StatusBadRequest, w.Code)
+	w = suite.do(http.MethodPost, "/api/v1/simulations", model.SimulationRequest{Example: "missing", NumAliens: 1})
+	suite.Assert().Equal(http.StatusBadRequest, w.Code)
+	suite.Assert().Contains(w.Body.String(), "unknown example [missing]")
+	suite.Assert().NotContains(w.Body.String(), "examples")
+	w = suite.do(http.MethodPost, "/api/v1/simulations", "not a request")
+	suite.Assert().Equal(http.StatusBadRequest, w.Code)
+}
+
+func (suite *SessionsTestSuite) TestViewer() {
+	w := suite.do(http.MethodPost, "/api/v1/simulations", model.SimulationRequest{Example: "world", NumAliens: 2, Seed: 1, Paused: true})
+	suite.Require().Equal(http.StatusCreated, w.Code)
+	var info model.SessionInfo
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &info))
+
+	// the viewer page of a session, its requests are relative to the session path
+	w = suite.do(http.MethodGet, "/api/v1/simulations/"+info.ID+"/view", nil)
+	suite.Assert().Equal(http.StatusOK, w.Code)
+	suite.Assert().Contains(w.Body.String(), `<script src="/viewer/viewer.js">`)
+	suite.Assert().Equal(http.StatusOK, suite.do(http.MethodGet, "/viewer/viewer.js", nil).Code)
+	suite.Assert().Equal(http.StatusOK, suite.do(http.MethodGet, "/api/v1/simulations/"+info.ID+"/state", nil).Code)
+	suite.Assert().Equal(http.StatusOK, suite.do(http.MethodPost, "/api/v1/simulations/"+info.ID+"/control/resume", nil).Code)
+	suite.Assert().Equal(http.StatusNotFound, suite.do(http.MethodGet, "/api/v1/simulations/unknown/view", nil).Code)
+	// without a single simulation there is no root viewer
+	suite.Assert().Equal(http.StatusNotFound, suite.do(http.MethodGet, "/", nil).Code)
+}
+
+// TestSessionsTestSuite is the entry point of this test suite
+func TestSessionsTestSuite(t *testing.T) {
+	suite.Run(t, new(SessionsTestSuite))
+}
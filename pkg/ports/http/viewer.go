@@ -0,0 +1,132 @@
+package http
+
+import (
+	"embed"
+	"errors"
+	"io/fs"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+
+	"github.com/c-kuroki/alien_invasion/pkg/app"
+)
+
+// viewer is a single page application served offline from the binary
+//
+//go:embed viewer
+var viewer embed.FS
+
+// viewerHandler serves viewer static files under /viewer/
+func viewerHandler() http.Handler {
+	return http.FileServer(http.FS(viewer))
+}
+
+func (srv *HTTPService) GetIndex(w http.ResponseWriter, r *http.Request) {
+	index, err := fs.ReadFile(viewer, "viewer/index.html")
+	if err != nil {
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, err.Error())
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(index)
+}
+
+// GetState returns the simulation state as JSON, when since (tick) and status query params
+// match the current state it returns 204 (no content) so clients only fetch changes. The tick
+// query param returns the state after a past tick, if the simulation keeps its history
+func (srv *HTTPService) GetState(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Query().Has("tick") {
+		tick, ok := tickParam(w, r, "tick")
+		if !ok {
+			return
+		}
+		snapshot, err := invasionFrom(r).SnapshotAt(tick)
+		if err != nil {
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, err.Error())
+			return
+		}
+		render.Status(r, http.StatusOK)
+		render.JSON(w, r, snapshot)
+		return
+	}
+	snapshot := invasionFrom(r).Snapshot()
+	if since := r.URL.Query().Get("since"); since != "" {
+		tick, err := strconv.Atoi(since)
+		if err != nil {
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, "invalid since tick")
+			return
+		}
+		if tick == snapshot.Tick && r.URL.Query().Get("status") == snapshot.Status {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+	}
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, snapshot)
+}
+
+// PostControl pauses, resumes or steps the simulation
+func (srv *HTTPService) PostControl(w http.ResponseWriter, r *http.Request) {
+	var err error
+	switch action := chi.URLParam(r, "action"); action {
+	case "pause":
+		err = invasionFrom(r).Pause()
+	case "resume":
+		err = invasionFrom(r).Resume()
+	case "step":
+		err = invasionFrom(r).Step()
+	default:
+		render.Status(r, http.StatusNotFound)
+		render.JSON(w, r, "invalid action")
+		return
+	}
+	if err != nil {
+		render.Status(r, http.StatusConflict)
+		render.JSON(w, r, err.Error())
+		return
+	}
+	snapshot := invasionFrom(r).Snapshot()
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, map[string]interface{}{"tick": snapshot.Tick, "status": snapshot.Status})
+}
+
+// GetDiff returns the changes of the world between the from and to ticks
+func (srv *HTTPService) GetDiff(w http.ResponseWriter, r *http.Request) {
+	from, ok := tickParam(w, r, "from")
+	if !ok {
+		return
+	}
+	to, ok := tickParam(w, r, "to")
+	if !ok {
+		return
+	}
+	diff, err := invasionFrom(r).Diff(from, to)
+	if errors.Is(err, app.ErrInvalidRange) {
+		badRequest(w, r, err)
+		return
+	}
+	if err != nil {
+		render.Status(r, http.StatusNotFound)
+		render.JSON(w, r, err.Error())
+		return
+	}
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, diff)
+}
+
+// tickParam parses a tick query param, writing a bad request response if invalid
+func tickParam(w http.ResponseWriter, r *http.Request, name string) (int, bool) {
+	tick, err := strconv.Atoi(r.URL.Query().Get(name))
+	if err != nil || tick < 0 {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, "invalid "+name+" tick")
+		return 0, false
+	}
+	return tick, true
+}
@@ -0,0 +1,508 @@
+// Alien Invasion viewer: loads the state once and follows the simulation events, keeping a
+// client side history for the timeline as a snapshot every few ticks plus the events of each
+// tick. Past ticks not kept on the client are fetched from the server history. API paths are
+// resolved relative to the page, so the viewer also works under a session mount.
+(function () {
+  "use strict";
+
+  const CELL = 120;
+  const CITY_RADIUS = 40;
+  const ALIEN_RADIUS = 12;
+  const MAX_HISTORY = 2000;
+  const KEYFRAME_EVERY = 50;
+  const RETRY_INTERVAL = 2000;
+  const EVENT_TYPES = ["spawn", "move", "destroy", "tick", "end"];
+  const OPPOSITE = { north: "south", east: "west", south: "north", west: "east" };
+  const SVG_NS = "http://www.w3.org/2000/svg";
+
+  const svg = document.getElementById("map");
+  const timeline = document.getElementById("timeline");
+  const statusLabel = document.getElementById("status");
+  const inspector = document.getElementById("inspector");
+  const jump = document.getElementById("jump");
+
+  // API key or token passed to the page, forwarded on every request
+  const accessToken = new URLSearchParams(window.location.search).get("access_token");
+
+  // api returns the URL of an endpoint relative to the page, e.g. state on
+  // /api/v1/simulations/{sid}/view is /api/v1/simulations/{sid}/state
+  function api(path) {
+    const url = new URL(path, window.location.href).pathname;
+    if (!accessToken) {
+      return url;
+    }
+    return url + (url.indexOf("?") < 0 ? "?" : "&") + "access_token=" + encodeURIComponent(accessToken);
+  }
+
+  function withParams(url, params) {
+    return url + (url.indexOf("?") < 0 ? "?" : "&") + params;
+  }
+
+  // frames of the timeline, one per tick: {tick, status, events, keyframe}, keyframe is a full
+  // snapshot on the first frame and every KEYFRAME_EVERY ticks, null on the others
+  const frames = [];
+  // live snapshot, and the events of the tick being received
+  let live = null;
+  let pending = [];
+  let source = null;
+  // index of the frame being displayed, null follows the live state
+  let viewIndex = null;
+  // snapshot rebuilt for viewIndex
+  let viewed = null;
+  // past snapshot fetched from the server, displayed instead of the history
+  let jumped = null;
+  let jumpedDiff = "";
+  let viewBox = null;
+  let selected = null;
+
+  function current() {
+    if (jumped) {
+      return jumped;
+    }
+    return viewIndex === null ? live : viewed;
+  }
+
+  function clone(snapshot) {
+    return JSON.parse(JSON.stringify(snapshot));
+  }
+
+  // state and events
+
+  async function start() {
+    try {
+      const resp = await fetch(api("state"));
+      if (resp.status !== 200) {
+        throw new Error(await resp.json());
+      }
+      const snapshot = await resp.json();
+      live = snapshot;
+      pending = [];
+      addFrame([], true);
+      if (snapshot.status !== "finished") {
+        connect(snapshot.tick + 1);
+      }
+    } catch (e) {
+      statusLabel.textContent = "disconnected";
+      setTimeout(start, RETRY_INTERVAL);
+    }
+  }
+
+  // connect follows the events from a tick. The from param has priority over the Last-Event-ID
+  // of browser reconnections, so a broken stream is closed and started again from the state
+  function connect(from) {
+    source = new EventSource(withParams(api("events"), "from=" + from));
+    EVENT_TYPES.forEach(function (type) {
+      source.addEventListener(type, function (msg) {
+        onEvent(JSON.parse(msg.data));
+      });
+    });
+    source.addEventListener("error", function () {
+      source.close();
+      source = null;
+      pending = [];
+      statusLabel.textContent = "disconnected";
+      setTimeout(start, RETRY_INTERVAL);
+    });
+  }
+
+  function onEvent(event) {
+    if (event.type !== "tick" && event.type !== "end") {
+      pending.push(event);
+      return;
+    }
+    pending.push(event);
+    const events = pending;
+    pending = [];
+    events.forEach(function (e) {
+      apply(live, e);
+    });
+    addFrame(events, false);
+    if (event.type === "end") {
+      source.close();
+      source = null;
+    }
+  }
+
+  // apply applies an event to a snapshot, as the simulation does to its world
+  function apply(snapshot, event) {
+    switch (event.type) {
+      case "spawn":
+        // names are not sent on events
+        snapshot.aliens = snapshot.aliens || [];
+        snapshot.aliens.push({ id: event.alien_id, name: "alien" + event.alien_id, city: event.city_id, origin: event.city_id, moves: 0 });
+        break;
+      case "move":
+        (snapshot.aliens || []).forEach(function (alien) {
+          if (alien.id === event.alien_id) {
+            alien.city = event.to;
+            alien.moves++;
+          }
+        });
+        break;
+      case "destroy": {
+        const byName = {};
+        snapshot.cities.forEach(function (city) {
+          byName[city.name] = city;
+        });
+        const city = snapshot.cities.find(function (c) {
+          return c.id === event.city_id;
+        });
+        if (city) {
+          city.destroyed = true;
+          // roads to the city are removed from its neighbours
+          Object.keys(OPPOSITE).forEach(function (direction) {
+            const neighbour = byName[city[direction]];
+            if (neighbour && !neighbour.destroyed) {
+              delete neighbour[OPPOSITE[direction]];
+            }
+          });
+        }
+        const killed = event.aliens || [];
+        snapshot.aliens = (snapshot.aliens || []).filter(function (alien) {
+          return killed.indexOf(alien.id) < 0;
+        });
+        break;
+      }
+      case "tick":
+        snapshot.tick = event.tick;
+        break;
+      case "end":
+        snapshot.tick = event.tick;
+        snapshot.status = "finished";
+        break;
+    }
+  }
+
+  function addFrame(events, keyframe) {
+    const last = frames[frames.length - 1];
+    if (last && last.tick === live.tick && !keyframe) {
+      // e.g. the end event of the last tick
+      last.events = last.events.concat(events);
+      last.status = live.status;
+    } else {
+      frames.push({
+        tick: live.tick,
+        status: live.status,
+        events: events,
+        keyframe: keyframe || live.tick % KEYFRAME_EVERY === 0 ? clone(live) : null,
+      });
+    }
+    if (frames.length > MAX_HISTORY) {
+      // the next frame becomes the first one, so it needs its snapshot
+      if (!frames[1].keyframe) {
+        frames[1].keyframe = rebuild(1);
+      }
+      frames.shift();
+      if (viewIndex !== null) {
+        viewIndex = Math.max(0, viewIndex - 1);
+      }
+    }
+    timeline.max = frames.length - 1;
+    if (viewIndex === null && !jumped) {
+      timeline.value = frames.length - 1;
+      draw(live);
+    }
+  }
+
+  // rebuild returns the snapshot of a frame, applying the events since the closest keyframe
+  function rebuild(ix) {
+    let start = ix;
+    while (!frames[start].keyframe) {
+      start--;
+    }
+    const snapshot = clone(frames[start].keyframe);
+    for (let i = start + 1; i <= ix; i++) {
+      frames[i].events.forEach(function (event) {
+        apply(snapshot, event);
+      });
+      snapshot.status = frames[i].status;
+    }
+    return snapshot;
+  }
+
+  // rendering
+
+  function el(name, attrs, parent) {
+    const node = document.createElementNS(SVG_NS, name);
+    for (const key in attrs) {
+      node.setAttribute(key, attrs[key]);
+    }
+    if (parent) {
+      parent.appendChild(node);
+    }
+    return node;
+  }
+
+  function center(city) {
+    return [city.x * CELL + CELL / 2, city.y * CELL + CELL / 2];
+  }
+
+  function draw(snapshot) {
+    if (!viewBox) {
+      viewBox = { x: 0, y: 0, w: Math.max(1, snapshot.width) * CELL, h: Math.max(1, snapshot.height) * CELL };
+      applyViewBox();
+    }
+    statusLabel.textContent = "tick " + snapshot.tick + " - " + snapshot.status +
+      (jumped ? " (past" + jumpedDiff + ")" : viewIndex === null ? "" : " (history)");
+    while (svg.firstChild) {
+      svg.removeChild(svg.firstChild);
+    }
+    const byName = {};
+    snapshot.cities.forEach(function (city) {
+      byName[city.name] = city;
+    });
+    const aliensByCity = {};
+    (snapshot.aliens || []).forEach(function (alien) {
+      (aliensByCity[alien.city] = aliensByCity[alien.city] || []).push(alien);
+    });
+
+    // roads, each one drawn once from its north or east end
+    const roads = el("g", {}, svg);
+    snapshot.cities.forEach(function (city) {
+      if (city.destroyed) {
+        return;
+      }
+      [city.north, city.east].forEach(function (name) {
+        const other = byName[name];
+        if (!other || other.destroyed) {
+          return;
+        }
+        const a = center(city);
+        const b = center(other);
+        el("line", { class: "road", x1: a[0], y1: a[1], x2: b[0], y2: b[1] }, roads);
+      });
+    });
+
+    // cities
+    snapshot.cities.forEach(function (city) {
+      const c = center(city);
+      const g = el("g", { class: "city" + (city.destroyed ? " destroyed" : "") }, svg);
+      const circle = el("circle", { cx: c[0], cy: c[1], r: CITY_RADIUS }, g);
+      const label = el("text", { x: c[0], y: c[1] }, g);
+      label.textContent = city.name;
+      circle.addEventListener("click", function () {
+        select({ type: "city", id: city.id });
+      });
+      if (isSelected("city", city.id)) {
+        circle.classList.add("selected");
+      }
+    });
+
+    // aliens, laid out around their city
+    Object.keys(aliensByCity).forEach(function (cityID) {
+      const aliens = aliensByCity[cityID];
+      const city = snapshot.cities.find(function (c) {
+        return c.id === Number(cityID);
+      });
+      if (!city) {
+        return;
+      }
+      const c = center(city);
+      aliens.forEach(function (alien, ix) {
+        let x = c[0];
+        let y = c[1] + CELL / 8;
+        if (aliens.length > 1) {
+          const angle = 2 * Math.PI * ix / aliens.length;
+          x = c[0] + CITY_RADIUS * 0.75 * Math.sin(angle);
+          y = c[1] + CITY_RADIUS * 0.75 * Math.cos(angle);
+        }
+        const marker = el("circle", {
+          class: "alien" + (aliens.length > 1 ? " contested" : ""),
+          cx: x, cy: y, r: ALIEN_RADIUS,
+        }, svg);
+        el("title", {}, marker).textContent = alien.name + " (#" + alien.id + ")";
+        marker.addEventListener("click", function () {
+          select({ type: "alien", id: alien.id });
+        });
+        if (isSelected("alien", alien.id)) {
+          marker.classList.add("selected");
+        }
+      });
+    });
+    inspect(snapshot);
+  }
+
+  // inspector
+
+  function isSelected(type, id) {
+    return selected !== null && selected.type === type && selected.id === id;
+  }
+
+  function select(item) {
+    selected = item;
+    draw(current());
+  }
+
+  function field(dl, name, value) {
+    const dt = document.createElement("dt");
+    dt.textContent = name;
+    const dd = document.createElement("dd");
+    dd.textContent = value;
+    dl.appendChild(dt);
+    dl.appendChild(dd);
+  }
+
+  function inspect(snapshot) {
+    if (selected === null) {
+      return;
+    }
+    const dl = document.createElement("dl");
+    const cityByID = {};
+    snapshot.cities.forEach(function (city) {
+      cityByID[city.id] = city;
+    });
+    if (selected.type === "city") {
+      const city = cityByID[selected.id];
+      if (!city) {
+        return;
+      }
+      field(dl, "City", city.name + " (#" + city.id + ")");
+      field(dl, "Position", "(" + city.x + "," + city.y + ")");
+      field(dl, "State", city.destroyed ? "destroyed" : "standing");
+      field(dl, "Roads", ["north", "east", "south", "west"].filter(function (d) {
+        return city[d];
+      }).map(function (d) {
+        return d + "=" + city[d];
+      }).join(" ") || "none");
+      field(dl, "Aliens", (snapshot.aliens || []).filter(function (a) {
+        return a.city === city.id;
+      }).map(function (a) {
+        return a.name;
+      }).join(", ") || "none");
+    } else {
+      const alien = (snapshot.aliens || []).find(function (a) {
+        return a.id === selected.id;
+      });
+      if (!alien) {
+        field(dl, "Alien", "#" + selected.id + " (destroyed)");
+      } else {
+        field(dl, "Alien", alien.name + " (#" + alien.id + ")");
+        field(dl, "City", (cityByID[alien.city] || { name: "#" + alien.city }).name);
+        field(dl, "Origin", (cityByID[alien.origin] || { name: "#" + alien.origin }).name);
+        field(dl, "Moves", alien.moves);
+      }
+    }
+    inspector.innerHTML = "";
+    inspector.appendChild(dl);
+  }
+
+  // zoom and pan
+
+  function applyViewBox() {
+    svg.setAttribute("viewBox", [viewBox.x, viewBox.y, viewBox.w, viewBox.h].join(" "));
+  }
+
+  function toMap(evt) {
+    const rect = svg.getBoundingClientRect();
+    return [
+      viewBox.x + (evt.clientX - rect.left) / rect.width * viewBox.w,
+      viewBox.y + (evt.clientY - rect.top) / rect.height * viewBox.h,
+    ];
+  }
+
+  svg.addEventListener("wheel", function (evt) {
+    if (!viewBox) {
+      return;
+    }
+    evt.preventDefault();
+    const factor = evt.deltaY > 0 ? 1.1 : 1 / 1.1;
+    const p = toMap(evt);
+    viewBox.x = p[0] - (p[0] - viewBox.x) * factor;
+    viewBox.y = p[1] - (p[1] - viewBox.y) * factor;
+    viewBox.w *= factor;
+    viewBox.h *= factor;
+    applyViewBox();
+  }, { passive: false });
+
+  let panStart = null;
+  svg.addEventListener("mousedown", function (evt) {
+    if (viewBox) {
+      panStart = toMap(evt);
+      svg.classList.add("panning");
+    }
+  });
+  window.addEventListener("mousemove", function (evt) {
+    if (!panStart) {
+      return;
+    }
+    const p = toMap(evt);
+    viewBox.x -= p[0] - panStart[0];
+    viewBox.y -= p[1] - panStart[1];
+    applyViewBox();
+  });
+  window.addEventListener("mouseup", function () {
+    panStart = null;
+    svg.classList.remove("panning");
+  });
+
+  // playback controls
+
+  async function control(action) {
+    const resp = await fetch(api("control/" + action), { method: "POST" });
+    if (resp.status !== 200) {
+      statusLabel.textContent = await resp.json();
+      return;
+    }
+    // pauses and resumes are not events
+    const state = await resp.json();
+    if (live && live.status !== "finished") {
+      live.status = state.status;
+      if (viewIndex === null && !jumped) {
+        draw(live);
+      }
+    }
+  }
+
+  document.getElementById("play").addEventListener("click", function () {
+    control("resume");
+  });
+  document.getElementById("pause").addEventListener("click", function () {
+    control("pause");
+  });
+  document.getElementById("step").addEventListener("click", function () {
+    control("step");
+  });
+  // jump to a past tick, showing the changes until the live tick
+  async function jumpTo(tick) {
+    const resp = await fetch(withParams(api("state"), "tick=" + tick));
+    if (resp.status !== 200) {
+      statusLabel.textContent = await resp.json();
+      return;
+    }
+    const snapshot = await resp.json();
+    jumpedDiff = "";
+    if (live && live.tick > tick) {
+      const diffResp = await fetch(withParams(api("diff"), "from=" + tick + "&to=" + live.tick));
+      if (diffResp.status === 200) {
+        const diff = await diffResp.json();
+        jumpedDiff = ", until tick " + live.tick + ": " + diff.destroyed_cities.length + " cities destroyed, " +
+          diff.moved_aliens.length + " aliens moved, " + diff.killed_aliens.length + " aliens killed";
+      }
+    }
+    jumped = snapshot;
+    draw(snapshot);
+  }
+
+  jump.addEventListener("change", function () {
+    if (jump.value !== "") {
+      jumpTo(Number(jump.value));
+    }
+  });
+  document.getElementById("live").addEventListener("click", function () {
+    jumped = null;
+    jump.value = "";
+    viewIndex = null;
+    timeline.value = frames.length - 1;
+    draw(current());
+  });
+  timeline.addEventListener("input", function () {
+    jumped = null;
+    jump.value = "";
+    const ix = Number(timeline.value);
+    viewIndex = ix >= frames.length - 1 ? null : ix;
+    viewed = viewIndex === null ? null : rebuild(viewIndex);
+    draw(current());
+  });
+
+  start();
+})();
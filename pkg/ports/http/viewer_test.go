@@ -0,0 +1,167 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+
+	"github.com/c-kuroki/alien_invasion/pkg/adapters/renderer"
+	"github.com/c-kuroki/alien_invasion/pkg/adapters/world"
+	"github.com/c-kuroki/alien_invasion/pkg/app"
+	"github.com/c-kuroki/alien_invasion/pkg/model"
+)
+
+type HistoryTestSuite struct {
+	suite.Suite
+	srv *HTTPService
+}
+
+func (suite *HistoryTestSuite) SetupTest() {
+	cfg := &model.Config{MaxMoves: 20, NumAliens: 2, Seed: 1, NoFinalMap: true}
+	state := world.NewEventSourcedState(world.NewInMemoryState(exampleMapFile), 5)
+	invasion := app.NewAlienInvasionApp(cfg, state, renderer.NewSVGRenderer(), zap.NewNop().Sugar())
+	suite.Require().NoError(invasion.Init())
+	invasion.MainLoop()
+	suite.srv = NewHTTPService(invasion, "127.0.0.1:0")
+}
+
+func (suite *HistoryTestSuite) get(path string, v interface{}) int {
+	w := httptest.NewRecorder()
+	suite.srv.Router().ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+	if w.Code == http.StatusOK {
+		suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), v))
+	}
+	return w.Code
+}
+
+func (suite *HistoryTestSuite) TestStateAt() {
+	var initial, last model.Snapshot
+	suite.Require().Equal(http.StatusOK, suite.get("/state?tick=0", &initial))
+	suite.Assert().Equal(0, initial.Tick)
+	suite.Assert().Equal(2, len(initial.Aliens))
+	suite.Assert().Equal(5, len(initial.Cities))
+
+	var diff model.Diff
+	suite.Require().Equal(http.StatusOK, suite.get("/diff?from=0&to=1", &diff))
+	suite.Assert().Equal(0, diff.From)
+	suite.Assert().Equal(1, diff.To)
+
+	current := suite.srv.invasion.Snapshot()
+	suite.Require().Equal(http.StatusOK, suite.get(fmt.Sprintf("/state?tick=%d", current.Tick), &last))
+	suite.Assert().Equal(current.Cities, last.Cities)
+	suite.Assert().Equal(current.Aliens, last.Aliens)
+}
+
+func (suite *HistoryTestSuite) TestInvalidTick() {
+	var snapshot model.Snapshot
+	suite.Assert().Equal(http.StatusBadRequest, suite.get("/state?tick=x", &snapshot))
+	suite.Assert().Equal(http.StatusNotFound, suite.get("/state?tick=1000", &snapshot))
+	suite.Assert().Equal(http.StatusBadRequest, suite.get("/diff?from=0", &snapshot))
+	// diffs can not go backwards
+	var diff model.Diff
+	suite.Assert().Equal(http.StatusBadRequest, suite.get("/diff?from=2&to=1", &diff))
+	suite.Assert().Equal(http.StatusOK, suite.get("/diff?from=1&to=1", &diff))
+	suite.Assert().Empty(diff.MovedAliens)
+}
+
+type ViewerTestSuite struct {
+	suite.Suite
+	invasion *app.AlienInvasionApp
+	router   http.Handler
+}
+
+func (suite *ViewerTestSuite) SetupTest() {
+	// the main loop is not run, so the simulation stays at its first tick
+	cfg := &model.Config{MaxMoves: 20, NumAliens: 2, Seed: 1, NoFinalMap: true}
+	suite.invasion = app.NewAlienInvasionApp(cfg, world.NewInMemoryState(exampleMapFile), renderer.NewSVGRenderer(), zap.NewNop().Sugar())
+	suite.Require().NoError(suite.invasion.Init())
+	suite.router = NewHTTPService(suite.invasion, "127.0.0.1:0").Router()
+}
+
+func (suite *ViewerTestSuite) do(method, path string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, httptest.NewRequest(method, path, nil))
+	return w
+}
+
+func (suite *ViewerTestSuite) TestState() {
+	w := suite.do(http.MethodGet, "/state")
+	suite.Require().Equal(http.StatusOK, w.Code)
+	var snapshot model.Snapshot
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &snapshot))
+	suite.Assert().Equal(suite.invasion.Snapshot(), &snapshot)
+
+	// unchanged states are not sent again
+	suite.Assert().Equal(http.StatusNoContent, suite.do(http.MethodGet, "/state?since=0&status=running").Code)
+	suite.Assert().Equal(http.StatusOK, suite.do(http.MethodGet, "/state?since=0&status=paused").Code)
+	suite.Assert().Equal(http.StatusOK, suite.do(http.MethodGet, "/state?since=1&status=running").Code)
+	suite.Assert().Equal(http.StatusBadRequest, suite.do(http.MethodGet, "/state?since=x").Code)
+	// past ticks need the simulation history
+	suite.Assert().Equal(http.StatusNotFound, suite.do(http.MethodGet, "/state?tick=0").Code)
+	suite.Assert().Equal(http.StatusBadRequest, suite.do(http.MethodGet, "/state?tick=-1").Code)
+}
+
+// control posts a playback action and returns the status code and the replied status
+func (suite *ViewerTestSuite) control(action string) (int, string) {
+	w := suite.do(http.MethodPost, "/control/"+action)
+	if w.Code != http.StatusOK {
+		return w.Code, ""
+	}
+	var state struct {
+		Tick   int    `json:"tick"`
+		Status string `json:"status"`
+	}
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &state))
+	suite.Assert().Equal(0, state.Tick)
+	return w.Code, state.Status
+}
+
+func (suite *ViewerTestSuite) TestControl() {
+	code, _ := suite.control("step")
+	suite.Assert().Equal(http.StatusConflict, code, "step while running")
+	code, status := suite.control("pause")
+	suite.Assert().Equal(http.StatusOK, code)
+	suite.Assert().Equal(model.StatusPaused, status)
+	code, status = suite.control("step")
+	suite.Assert().Equal(http.StatusOK, code)
+	suite.Assert().Equal(model.StatusPaused, status)
+	code, status = suite.control("resume")
+	suite.Assert().Equal(http.StatusOK, code)
+	suite.Assert().Equal(model.StatusRunning, status)
+	code, _ = suite.control("rewind")
+	suite.Assert().Equal(http.StatusNotFound, code)
+	suite.Assert().Equal(http.StatusMethodNotAllowed, suite.do(http.MethodGet, "/control/pause").Code)
+
+	// finished simulations can not be controlled
+	suite.invasion.Stop()
+	suite.invasion.MainLoop()
+	code, _ = suite.control("pause")
+	suite.Assert().Equal(http.StatusConflict, code)
+}
+
+func (suite *ViewerTestSuite) TestViewer() {
+	w := suite.do(http.MethodGet, "/")
+	suite.Require().Equal(http.StatusOK, w.Code)
+	suite.Assert().Contains(w.Body.String(), `<script src="/viewer/viewer.js">`)
+	w = suite.do(http.MethodGet, "/viewer/viewer.js")
+	suite.Require().Equal(http.StatusOK, w.Code)
+	// API requests are relative to the page, so the viewer works on session mounts
+	for _, path := range []string{`"/state`, `"/control`, `"/diff`, `"/events`} {
+		suite.Assert().NotContains(w.Body.String(), path)
+	}
+}
+
+// TestHistory is the entry point of this test suite
+func TestHistory(t *testing.T) {
+	suite.Run(t, new(HistoryTestSuite))
+}
+
+// TestViewerTestSuite is the entry point of this test suite
+func TestViewerTestSuite(t *testing.T) {
+	suite.Run(t, new(ViewerTestSuite))
+}
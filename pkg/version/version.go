@@ -0,0 +1,35 @@
+// Package version reports the build version of the binary
+package version
+
+import (
+	"runtime"
+	"runtime/debug"
+
+	"github.com/c-kuroki/alien_invasion/pkg/model"
+)
+
+// Version is set at build time with -ldflags "-X github.com/c-kuroki/alien_invasion/pkg/version.Version=<version>"
+var Version = "dev"
+
+// Get returns the version and the VCS info embedded by the go tool
+func Get() *model.BuildInfo {
+	info := &model.BuildInfo{
+		Version:   Version,
+		GoVersion: runtime.Version(),
+	}
+	build, ok := debug.ReadBuildInfo()
+	if !ok {
+		return info
+	}
+	for _, setting := range build.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			info.Revision = setting.Value
+		case "vcs.time":
+			info.Time = setting.Value
+		case "vcs.modified":
+			info.Modified = setting.Value == "true"
+		}
+	}
+	return info
+}